@@ -2,12 +2,33 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 type Config struct {
-	JWTSecret []byte
-	DBUrl     string
+	JWTSecret          []byte
+	DBUrl              string
+	ExpiryWarningDays  int32
+	DefaultWarehouseId int32
+	// ReplenishmentWindowDays is how far back dispatches are averaged and
+	// ReplenishmentCoverageDays how many days of demand a reorder should cover.
+	ReplenishmentWindowDays   int32
+	ReplenishmentCoverageDays int32
+	// DunningDays are the days past the due date on which payment reminders
+	// go out.
+	DunningDays []int32
+	// AtRiskScoreThreshold is the risk score from which a company is at
+	// risk. AtRiskOverdueDays is how long an invoice may stay overdue before
+	// its company is at risk whatever its score.
+	AtRiskScoreThreshold int32
+	AtRiskOverdueDays    int32
+	// StandingOrderLeadDays is how many days ahead of a delivery date the
+	// order of a standing order is placed.
+	StandingOrderLeadDays int32
 }
 
 func Load() (*Config, error) {
@@ -25,5 +46,90 @@ func Load() (*Config, error) {
 		return nil, errors.New("DATABASE_URL environment must be set")
 	}
 
+	expiryWarningDays, err := lookupInt("EXPIRY_WARNING_DAYS", 7)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ExpiryWarningDays = int32(expiryWarningDays)
+
+	defaultWarehouseId, err := lookupInt("DEFAULT_WAREHOUSE_ID", 1)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DefaultWarehouseId = int32(defaultWarehouseId)
+
+	replenishmentWindowDays, err := lookupInt("REPLENISHMENT_WINDOW_DAYS", 30)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ReplenishmentWindowDays = int32(replenishmentWindowDays)
+
+	replenishmentCoverageDays, err := lookupInt("REPLENISHMENT_COVERAGE_DAYS", 14)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ReplenishmentCoverageDays = int32(replenishmentCoverageDays)
+
+	dunningDays, err := lookupIntList("DUNNING_DAYS", []int{3, 14, 30})
+	if err != nil {
+		return nil, err
+	}
+	for _, days := range dunningDays {
+		cfg.DunningDays = append(cfg.DunningDays, int32(days))
+	}
+
+	atRiskOverdueDays, err := lookupInt("AT_RISK_OVERDUE_DAYS", 30)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AtRiskOverdueDays = int32(atRiskOverdueDays)
+
+	atRiskScoreThreshold, err := lookupInt("AT_RISK_SCORE_THRESHOLD", 50)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AtRiskScoreThreshold = int32(atRiskScoreThreshold)
+
+	standingOrderLeadDays, err := lookupInt("STANDING_ORDER_LEAD_DAYS", 2)
+	if err != nil {
+		return nil, err
+	}
+	cfg.StandingOrderLeadDays = int32(standingOrderLeadDays)
+
 	return &cfg, nil
 }
+
+func lookupInt(name string, fallback int) (int, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok || v == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s environment must be a non-negative number", name)
+	}
+
+	return n, nil
+}
+
+// lookupIntList reads a comma separated list of non-negative numbers and
+// returns it sorted, without duplicates.
+func lookupIntList(name string, fallback []int) ([]int, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok || v == "" {
+		return fallback, nil
+	}
+
+	var list []int
+	for _, part := range strings.Split(v, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s environment must be a comma separated list of non-negative numbers", name)
+		}
+		list = append(list, n)
+	}
+
+	slices.Sort(list)
+	return slices.Compact(list), nil
+}
@@ -0,0 +1,30 @@
+CREATE TABLE stock_lot
+(
+    id              INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    product_id      INT          NOT NULL REFERENCES product (id),
+    batch_number    VARCHAR(100) NOT NULL,
+    production_date DATE         NOT NULL,
+    best_before     DATE         NOT NULL,
+    quantity        INT          NOT NULL CHECK (quantity >= 0),
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    CONSTRAINT uq_stock_lot_batch UNIQUE (product_id, batch_number)
+);
+
+CREATE TABLE stock_movement_lot
+(
+    id              INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    movement_id     INT NOT NULL REFERENCES stock_movement (id) ON DELETE CASCADE,
+    lot_id          INT NOT NULL REFERENCES stock_lot (id),
+    quantity_change INT NOT NULL
+);
+
+CREATE INDEX idx_stock_lot_product ON stock_lot (product_id, best_before);
+
+CREATE INDEX idx_stock_movement_lot_movement ON stock_movement_lot (movement_id);
+
+-- Stock received before lot tracking has no batch information, so it is kept
+-- in a single untracked lot per product that never expires.
+INSERT INTO stock_lot (product_id, batch_number, production_date, best_before, quantity)
+SELECT product_id, 'UNTRACKED', current_date, DATE '9999-12-31', quantity
+FROM stock
+WHERE quantity > 0;
@@ -0,0 +1,2 @@
+ALTER TABLE stock_movement
+    ADD COLUMN override_reason VARCHAR(255);
@@ -0,0 +1,2 @@
+ALTER TABLE stock
+    ADD COLUMN reserved_quantity INT NOT NULL DEFAULT 0 CHECK (reserved_quantity >= 0);
@@ -0,0 +1,13 @@
+CREATE TABLE pick_list_item
+(
+    id         INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_id   INT         NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id INT         NOT NULL REFERENCES product (id),
+    lot_id     INT         NOT NULL REFERENCES stock_lot (id),
+    quantity   INT         NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pick_list_item_order ON pick_list_item (order_id);
+
+CREATE INDEX idx_pick_list_item_lot ON pick_list_item (lot_id);
@@ -0,0 +1,63 @@
+ALTER TYPE movement_type ADD VALUE 'TRANSFER';
+
+CREATE TABLE warehouse
+(
+    id         INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    code       VARCHAR(20)  NOT NULL UNIQUE,
+    name       VARCHAR(200) NOT NULL,
+    address    VARCHAR(255),
+    is_active  BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+-- Everything stored before locations existed belongs to the main cold store.
+INSERT INTO warehouse (code, name)
+VALUES ('MAIN', 'Chłodnia główna');
+
+ALTER TABLE stock
+    ADD COLUMN warehouse_id INT REFERENCES warehouse (id);
+
+UPDATE stock
+SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'MAIN');
+
+ALTER TABLE stock
+    ALTER COLUMN warehouse_id SET NOT NULL,
+    ADD CONSTRAINT uq_stock_warehouse_product UNIQUE (warehouse_id, product_id);
+
+ALTER TABLE stock_lot
+    ADD COLUMN warehouse_id INT REFERENCES warehouse (id);
+
+UPDATE stock_lot
+SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'MAIN');
+
+ALTER TABLE stock_lot
+    ALTER COLUMN warehouse_id SET NOT NULL,
+    DROP CONSTRAINT uq_stock_lot_batch,
+    ADD CONSTRAINT uq_stock_lot_batch UNIQUE (warehouse_id, product_id, batch_number);
+
+DROP INDEX idx_stock_lot_product;
+
+CREATE INDEX idx_stock_lot_product ON stock_lot (warehouse_id, product_id, best_before);
+
+ALTER TABLE stock_movement
+    ADD COLUMN warehouse_id        INT REFERENCES warehouse (id),
+    ADD COLUMN related_movement_id INT REFERENCES stock_movement (id);
+
+UPDATE stock_movement
+SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'MAIN');
+
+ALTER TABLE stock_movement
+    ALTER COLUMN warehouse_id SET NOT NULL;
+
+ALTER TABLE orders
+    ADD COLUMN warehouse_id INT REFERENCES warehouse (id);
+
+UPDATE orders
+SET warehouse_id = (SELECT id FROM warehouse WHERE code = 'MAIN');
+
+ALTER TABLE orders
+    ALTER COLUMN warehouse_id SET NOT NULL;
+
+-- WAREHOUSE users whose employee has a site can only work on that site.
+ALTER TABLE employee
+    ADD COLUMN warehouse_id INT REFERENCES warehouse (id);
@@ -0,0 +1,33 @@
+CREATE TYPE stocktake_status AS ENUM ('OPEN', 'CLOSED', 'CANCELLED');
+
+CREATE TABLE stocktake
+(
+    id           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    warehouse_id INT              NOT NULL REFERENCES warehouse (id),
+    status       stocktake_status NOT NULL DEFAULT 'OPEN',
+    note         VARCHAR(255),
+    opened_by    INT              NOT NULL REFERENCES user_account (id),
+    opened_at    TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    closed_by    INT REFERENCES user_account (id),
+    closed_at    TIMESTAMPTZ
+);
+
+-- Only one count can run per warehouse at a time.
+CREATE UNIQUE INDEX uq_stocktake_open_warehouse ON stocktake (warehouse_id) WHERE status = 'OPEN';
+
+-- A row without lot_id counts the whole product; rows with lot_id count single
+-- lots of it. The two are never mixed for one product within a session.
+-- expected_quantity is the book quantity frozen when the session is closed.
+CREATE TABLE stocktake_count
+(
+    id                INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    stocktake_id      INT         NOT NULL REFERENCES stocktake (id) ON DELETE CASCADE,
+    product_id        INT         NOT NULL REFERENCES product (id),
+    lot_id            INT REFERENCES stock_lot (id),
+    counted_quantity  INT         NOT NULL CHECK (counted_quantity >= 0),
+    expected_quantity INT,
+    counted_by        INT         NOT NULL REFERENCES user_account (id),
+    counted_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_stocktake_count_stocktake ON stocktake_count (stocktake_id, product_id);
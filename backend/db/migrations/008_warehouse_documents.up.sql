@@ -0,0 +1,55 @@
+CREATE TYPE warehouse_document_type AS ENUM ('PZ', 'WZ');
+
+-- PZ (przyjęcie zewnętrzne) groups the INBOUND lines of one supplier delivery,
+-- WZ (wydanie zewnętrzne) groups the DISPATCH lines of one shipped order.
+CREATE TABLE warehouse_document
+(
+    id               INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    document_type    warehouse_document_type NOT NULL,
+    document_number  VARCHAR(50)             NOT NULL UNIQUE,
+    warehouse_id     INT                     NOT NULL REFERENCES warehouse (id),
+    issue_date       TIMESTAMPTZ             NOT NULL DEFAULT now(),
+    supplier_name    VARCHAR(200),
+    related_order_id INT REFERENCES orders (id),
+    note             VARCHAR(255),
+    employee_id      INT REFERENCES employee (id)
+);
+
+CREATE INDEX idx_warehouse_document_order ON warehouse_document (related_order_id);
+
+-- Numbers run per document type and year without gaps, e.g. PZ/2026/0001.
+CREATE TABLE warehouse_document_sequence
+(
+    document_type warehouse_document_type NOT NULL,
+    year          INT                     NOT NULL,
+    last_number   INT                     NOT NULL,
+    PRIMARY KEY (document_type, year)
+);
+
+CREATE FUNCTION set_warehouse_document_number() RETURNS trigger AS
+$$
+DECLARE
+    next_number INT;
+BEGIN
+    INSERT INTO warehouse_document_sequence (document_type, year, last_number)
+    VALUES (NEW.document_type, extract(YEAR FROM NEW.issue_date)::int, 1)
+    ON CONFLICT (document_type, year) DO UPDATE
+        SET last_number = warehouse_document_sequence.last_number + 1
+    RETURNING last_number INTO next_number;
+
+    NEW.document_number := NEW.document_type::text || '/' || extract(YEAR FROM NEW.issue_date)::text || '/' ||
+                           lpad(next_number::text, 4, '0');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER warehouse_document_number_trigger
+    BEFORE INSERT
+    ON warehouse_document
+    FOR EACH ROW
+EXECUTE FUNCTION set_warehouse_document_number();
+
+ALTER TABLE stock_movement
+    ADD COLUMN document_id INT REFERENCES warehouse_document (id);
+
+CREATE INDEX idx_stock_movement_document ON stock_movement (document_id);
@@ -0,0 +1,69 @@
+CREATE TYPE purchase_order_status AS ENUM ('DRAFT', 'SENT', 'PARTIALLY_RECEIVED', 'RECEIVED', 'CANCELLED');
+
+-- Tolerances are whole percentages of the ordered quantity and are copied onto
+-- every new purchase order as its defaults.
+CREATE TABLE supplier
+(
+    id                           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name                         VARCHAR(200) NOT NULL,
+    tax_id                       VARCHAR(20),
+    email                        VARCHAR(255),
+    phone                        VARCHAR(20),
+    address                      VARCHAR(255),
+    over_delivery_tolerance_pct  INT          NOT NULL DEFAULT 0 CHECK (over_delivery_tolerance_pct BETWEEN 0 AND 100),
+    under_delivery_tolerance_pct INT          NOT NULL DEFAULT 0 CHECK (under_delivery_tolerance_pct BETWEEN 0 AND 100),
+    is_active                    BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at                   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE TABLE purchase_order
+(
+    id                           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_number                 VARCHAR(50)           NOT NULL UNIQUE,
+    supplier_id                  INT                   NOT NULL REFERENCES supplier (id),
+    warehouse_id                 INT                   NOT NULL REFERENCES warehouse (id),
+    status                       purchase_order_status NOT NULL DEFAULT 'DRAFT',
+    order_date                   TIMESTAMPTZ           NOT NULL DEFAULT now(),
+    expected_delivery_date       DATE,
+    over_delivery_tolerance_pct  INT                   NOT NULL DEFAULT 0 CHECK (over_delivery_tolerance_pct BETWEEN 0 AND 100),
+    under_delivery_tolerance_pct INT                   NOT NULL DEFAULT 0 CHECK (under_delivery_tolerance_pct BETWEEN 0 AND 100),
+    note                         VARCHAR(255),
+    created_by                   INT                   NOT NULL REFERENCES user_account (id)
+);
+
+CREATE INDEX idx_purchase_order_supplier ON purchase_order (supplier_id);
+
+-- A line may promise a later date than the order as a whole.
+CREATE TABLE purchase_order_line
+(
+    id                     INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    purchase_order_id      INT            NOT NULL REFERENCES purchase_order (id) ON DELETE CASCADE,
+    product_id             INT            NOT NULL REFERENCES product (id),
+    ordered_quantity       INT            NOT NULL CHECK (ordered_quantity > 0),
+    received_quantity      INT            NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    unit_price             NUMERIC(18, 2) NOT NULL,
+    expected_delivery_date DATE,
+    UNIQUE (purchase_order_id, product_id)
+);
+
+CREATE FUNCTION set_purchase_order_number() RETURNS trigger AS
+$$
+BEGIN
+    NEW.order_number := 'ZD/' || extract(YEAR FROM NEW.order_date)::text || '/' || lpad(NEW.id::text, 4, '0');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER purchase_order_number_trigger
+    BEFORE INSERT
+    ON purchase_order
+    FOR EACH ROW
+EXECUTE FUNCTION set_purchase_order_number();
+
+ALTER TABLE stock_movement
+    ADD COLUMN purchase_order_line_id INT REFERENCES purchase_order_line (id);
+
+CREATE INDEX idx_stock_movement_purchase_order_line ON stock_movement (purchase_order_line_id);
+
+ALTER TABLE warehouse_document
+    ADD COLUMN purchase_order_id INT REFERENCES purchase_order (id);
@@ -0,0 +1,15 @@
+-- The supplier a product is normally bought from, together with the terms the
+-- replenishment report plans with.
+CREATE TABLE product_supplier
+(
+    product_id         INT PRIMARY KEY REFERENCES product (id),
+    supplier_id        INT            NOT NULL REFERENCES supplier (id),
+    lead_time_days     INT            NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    min_order_quantity INT            NOT NULL DEFAULT 1 CHECK (min_order_quantity > 0),
+    unit_price         NUMERIC(18, 2) NOT NULL
+);
+
+CREATE INDEX idx_product_supplier_supplier ON product_supplier (supplier_id);
+
+CREATE INDEX idx_stock_movement_dispatch ON stock_movement (warehouse_id, product_id, created_at)
+    WHERE movement_type = 'DISPATCH';
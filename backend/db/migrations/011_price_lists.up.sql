@@ -0,0 +1,42 @@
+CREATE TYPE price_source AS ENUM ('CUSTOMER_PRICE_LIST', 'GROUP_PRICE_LIST', 'DEFAULT_PRICE');
+
+CREATE TABLE customer_group
+(
+    id         INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+ALTER TABLE customer_company
+    ADD COLUMN customer_group_id INT REFERENCES customer_group (id);
+
+-- A price list belongs to a single customer or to a customer group, never both.
+-- An open-ended list has no valid_to.
+CREATE TABLE price_list
+(
+    id                  INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name                VARCHAR(200) NOT NULL,
+    customer_company_id INT REFERENCES customer_company (id),
+    customer_group_id   INT REFERENCES customer_group (id),
+    valid_from          DATE         NOT NULL,
+    valid_to            DATE,
+    is_active           BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at          TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    CONSTRAINT ck_price_list_owner CHECK (customer_company_id IS NULL OR customer_group_id IS NULL),
+    CONSTRAINT ck_price_list_validity CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX idx_price_list_customer_company ON price_list (customer_company_id);
+CREATE INDEX idx_price_list_customer_group ON price_list (customer_group_id);
+
+CREATE TABLE price_list_item
+(
+    price_list_id INT            NOT NULL REFERENCES price_list (id) ON DELETE CASCADE,
+    product_id    INT            NOT NULL REFERENCES product (id),
+    price         NUMERIC(18, 2) NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (price_list_id, product_id)
+);
+
+ALTER TABLE order_item
+    ADD COLUMN price_source  price_source NOT NULL DEFAULT 'DEFAULT_PRICE',
+    ADD COLUMN price_list_id INT REFERENCES price_list (id);
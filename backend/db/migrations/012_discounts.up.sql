@@ -0,0 +1,38 @@
+CREATE TYPE discount_type AS ENUM ('PERCENTAGE', 'FIXED_AMOUNT');
+
+-- A rule targets either a single product or a whole category. min_quantity
+-- turns it into a quantity break; a rule without dates is always in force.
+-- FIXED_AMOUNT values are taken off the unit price.
+CREATE TABLE discount_rule
+(
+    id            INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name          VARCHAR(200)   NOT NULL,
+    product_id    INT REFERENCES product (id),
+    category      VARCHAR(100),
+    discount_type discount_type  NOT NULL,
+    value         NUMERIC(18, 2) NOT NULL CHECK (value > 0),
+    min_quantity  INT            NOT NULL DEFAULT 1 CHECK (min_quantity > 0),
+    valid_from    DATE,
+    valid_to      DATE,
+    is_active     BOOLEAN        NOT NULL DEFAULT TRUE,
+    created_at    TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    CONSTRAINT ck_discount_rule_target CHECK ((product_id IS NULL) <> (category IS NULL)),
+    CONSTRAINT ck_discount_rule_percentage CHECK (discount_type <> 'PERCENTAGE' OR value <= 100),
+    CONSTRAINT ck_discount_rule_validity CHECK (valid_from IS NULL OR valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX idx_discount_rule_product ON discount_rule (product_id);
+CREATE INDEX idx_discount_rule_category ON discount_rule (category);
+
+-- unit_price stays the net price actually charged; list_price is the price
+-- before any discount and discount_amount the total taken off the line.
+ALTER TABLE order_item
+    ADD COLUMN list_price       NUMERIC(18, 2),
+    ADD COLUMN discount_amount  NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN discount_rule_id INT REFERENCES discount_rule (id);
+
+UPDATE order_item
+SET list_price = unit_price;
+
+ALTER TABLE order_item
+    ALTER COLUMN list_price SET NOT NULL;
@@ -0,0 +1,66 @@
+-- ZW is the exempt rate (zwolniony): no tax is charged, but it is reported
+-- separately from 0%.
+CREATE TYPE vat_rate AS ENUM ('23', '8', '5', '0', 'ZW');
+
+-- Most of the range is dairy at the reduced 5% rate, so existing products are
+-- backfilled with it; new products have to state their rate.
+ALTER TABLE product
+    ADD COLUMN vat_rate vat_rate NOT NULL DEFAULT '5';
+
+ALTER TABLE product
+    ALTER COLUMN vat_rate DROP DEFAULT;
+
+CREATE FUNCTION vat_percentage(rate vat_rate) RETURNS NUMERIC AS
+$$
+SELECT CASE rate
+           WHEN '23' THEN 23
+           WHEN '8' THEN 8
+           WHEN '5' THEN 5
+           ELSE 0
+           END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- line_total is the net value of the line.
+ALTER TABLE order_item
+    ADD COLUMN vat_rate    vat_rate,
+    ADD COLUMN vat_amount  NUMERIC(18, 2),
+    ADD COLUMN gross_total NUMERIC(18, 2);
+
+UPDATE order_item oi
+SET vat_rate    = p.vat_rate,
+    vat_amount  = round(oi.line_total * vat_percentage(p.vat_rate) / 100, 2),
+    gross_total = oi.line_total + round(oi.line_total * vat_percentage(p.vat_rate) / 100, 2)
+FROM product p
+WHERE p.id = oi.product_id;
+
+ALTER TABLE order_item
+    ALTER COLUMN vat_rate SET NOT NULL,
+    ALTER COLUMN vat_amount SET NOT NULL,
+    ALTER COLUMN gross_total SET NOT NULL;
+
+-- Orders that have not been invoiced yet are payable gross from now on.
+UPDATE orders o
+SET total_amount = (SELECT coalesce(sum(oi.gross_total), 0) FROM order_item oi WHERE oi.order_id = o.id)
+WHERE NOT EXISTS (SELECT 1 FROM invoice i WHERE i.order_id = o.id);
+
+-- total_amount stays the amount payable, i.e. the gross total. Invoices issued
+-- before VAT was tracked carried no tax.
+ALTER TABLE invoice
+    ADD COLUMN net_amount NUMERIC(18, 2),
+    ADD COLUMN vat_amount NUMERIC(18, 2) NOT NULL DEFAULT 0;
+
+UPDATE invoice
+SET net_amount = total_amount;
+
+ALTER TABLE invoice
+    ALTER COLUMN net_amount SET NOT NULL;
+
+CREATE TABLE invoice_vat_summary
+(
+    invoice_id   INT            NOT NULL REFERENCES invoice (id) ON DELETE CASCADE,
+    vat_rate     vat_rate       NOT NULL,
+    net_amount   NUMERIC(18, 2) NOT NULL,
+    vat_amount   NUMERIC(18, 2) NOT NULL,
+    gross_amount NUMERIC(18, 2) NOT NULL,
+    PRIMARY KEY (invoice_id, vat_rate)
+);
@@ -0,0 +1,40 @@
+-- Logos are never updated in place: uploading a new one adds a row, so
+-- invoices keep pointing at the logo they were issued with.
+CREATE TABLE company_logo
+(
+    id           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    content_type VARCHAR(50) NOT NULL,
+    data         BYTEA       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- The seller's own details. There is only ever one row.
+CREATE TABLE company_settings
+(
+    id           INT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    legal_name   VARCHAR(255) NOT NULL,
+    tax_id       VARCHAR(20)  NOT NULL,
+    regon        VARCHAR(14)  NULL,
+    address_line VARCHAR(255) NOT NULL,
+    city         VARCHAR(100) NOT NULL,
+    postal_code  VARCHAR(20)  NOT NULL,
+    country      VARCHAR(100) NOT NULL,
+    iban         VARCHAR(34)  NOT NULL,
+    bank_name    VARCHAR(100) NULL,
+    logo_id      INT          NULL REFERENCES company_logo (id),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+-- Seller details as they were when the invoice was issued. Invoices issued
+-- before the settings existed have none.
+ALTER TABLE invoice
+    ADD COLUMN seller_name         VARCHAR(255) NULL,
+    ADD COLUMN seller_tax_id       VARCHAR(20)  NULL,
+    ADD COLUMN seller_regon        VARCHAR(14)  NULL,
+    ADD COLUMN seller_address_line VARCHAR(255) NULL,
+    ADD COLUMN seller_city         VARCHAR(100) NULL,
+    ADD COLUMN seller_postal_code  VARCHAR(20)  NULL,
+    ADD COLUMN seller_country      VARCHAR(100) NULL,
+    ADD COLUMN seller_iban         VARCHAR(34)  NULL,
+    ADD COLUMN seller_bank_name    VARCHAR(100) NULL,
+    ADD COLUMN seller_logo_id      INT          NULL REFERENCES company_logo (id);
@@ -0,0 +1,46 @@
+-- Addresses are copied onto orders and invoices when those are created, so
+-- editing a company's addresses later does not rewrite past documents. The
+-- address id is kept only as a reference to where the copy came from.
+ALTER TABLE orders
+    ADD COLUMN shipping_address_id   INT          NULL REFERENCES company_address (id) ON DELETE SET NULL,
+    ADD COLUMN shipping_address_line VARCHAR(255) NULL,
+    ADD COLUMN shipping_city         VARCHAR(100) NULL,
+    ADD COLUMN shipping_postal_code  VARCHAR(20)  NULL,
+    ADD COLUMN shipping_country      VARCHAR(100) NULL;
+
+ALTER TABLE invoice
+    ADD COLUMN buyer_name            VARCHAR(200) NULL,
+    ADD COLUMN buyer_tax_id          VARCHAR(20)  NULL,
+    ADD COLUMN billing_address_id    INT          NULL REFERENCES company_address (id) ON DELETE SET NULL,
+    ADD COLUMN billing_address_line  VARCHAR(255) NULL,
+    ADD COLUMN billing_city          VARCHAR(100) NULL,
+    ADD COLUMN billing_postal_code   VARCHAR(20)  NULL,
+    ADD COLUMN billing_country       VARCHAR(100) NULL;
+
+-- Existing invoices get the buyer as it is today, which is the best record
+-- there is.
+UPDATE invoice i
+SET buyer_name   = c.name,
+    buyer_tax_id = c.tax_id
+FROM orders o
+         JOIN customer_company c ON c.id = o.customer_id
+WHERE o.id = i.order_id;
+
+UPDATE invoice i
+SET billing_address_id   = a.id,
+    billing_address_line = a.address_line,
+    billing_city         = a.city,
+    billing_postal_code  = a.postal_code,
+    billing_country      = a.country
+FROM orders o
+         JOIN LATERAL (SELECT *
+                       FROM company_address ca
+                       WHERE ca.customer_company_id = o.customer_id
+                         AND ca.type = 'BILLING'
+                       ORDER BY ca.id
+                       LIMIT 1) a ON TRUE
+WHERE o.id = i.order_id;
+
+ALTER TABLE invoice
+    ALTER COLUMN buyer_name SET NOT NULL,
+    ALTER COLUMN buyer_tax_id SET NOT NULL;
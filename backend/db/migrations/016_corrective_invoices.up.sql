@@ -0,0 +1,89 @@
+-- A corrective invoice (faktura korygująca) changes quantities or prices of an
+-- issued invoice. Its amounts are the difference it makes, so a correction
+-- that lowers the invoice carries negative totals.
+CREATE TABLE corrective_invoice
+(
+    id                INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    invoice_id        INT            NOT NULL REFERENCES invoice (id),
+    correction_number VARCHAR(50)    NOT NULL UNIQUE,
+    issue_date        TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    reason            VARCHAR(255)   NOT NULL,
+    -- the RETURN movement the correction was issued for; each return is
+    -- credited at most once
+    stock_movement_id INT            NULL UNIQUE REFERENCES stock_movement (id),
+    net_amount        NUMERIC(18, 2) NOT NULL,
+    vat_amount        NUMERIC(18, 2) NOT NULL,
+    total_amount      NUMERIC(18, 2) NOT NULL,
+    created_by        INT            NOT NULL REFERENCES user_account (id)
+);
+
+CREATE INDEX idx_corrective_invoice_invoice ON corrective_invoice (invoice_id);
+
+-- Before values are the line as it stood after any earlier corrections.
+CREATE TABLE corrective_invoice_line
+(
+    id                    INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    corrective_invoice_id INT            NOT NULL REFERENCES corrective_invoice (id) ON DELETE CASCADE,
+    order_item_id         INT            NOT NULL REFERENCES order_item (id),
+    vat_rate              vat_rate       NOT NULL,
+    quantity_before       INT            NOT NULL,
+    quantity_after        INT            NOT NULL CHECK (quantity_after >= 0),
+    unit_price_before     NUMERIC(18, 2) NOT NULL,
+    unit_price_after      NUMERIC(18, 2) NOT NULL CHECK (unit_price_after >= 0),
+    net_change            NUMERIC(18, 2) NOT NULL,
+    vat_change            NUMERIC(18, 2) NOT NULL,
+    gross_change          NUMERIC(18, 2) NOT NULL
+);
+
+CREATE INDEX idx_corrective_invoice_line_item ON corrective_invoice_line (order_item_id);
+
+-- Numbers run per year without gaps, e.g. KOR/2026/0001.
+CREATE TABLE corrective_invoice_sequence
+(
+    year        INT PRIMARY KEY,
+    last_number INT NOT NULL
+);
+
+CREATE FUNCTION set_correction_number() RETURNS trigger AS
+$$
+DECLARE
+    next_number INT;
+BEGIN
+    INSERT INTO corrective_invoice_sequence (year, last_number)
+    VALUES (extract(YEAR FROM NEW.issue_date)::int, 1)
+    ON CONFLICT (year) DO UPDATE
+        SET last_number = corrective_invoice_sequence.last_number + 1
+    RETURNING last_number INTO next_number;
+
+    NEW.correction_number := 'KOR/' || extract(YEAR FROM NEW.issue_date)::text || '/' ||
+                             lpad(next_number::text, 4, '0');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER correction_number_trigger
+    BEFORE INSERT
+    ON corrective_invoice
+    FOR EACH ROW
+EXECUTE FUNCTION set_correction_number();
+
+-- Every amount billed to a customer: invoices and the corrections to them.
+CREATE VIEW customer_ledger AS
+SELECT o.customer_id,
+       i.id             AS invoice_id,
+       'INVOICE'::text  AS document_type,
+       i.invoice_number AS document_number,
+       i.issue_date     AS entry_date,
+       i.total_amount   AS amount
+FROM invoice i
+         JOIN orders o ON o.id = i.order_id
+UNION ALL
+SELECT o.customer_id,
+       ci.invoice_id,
+       'CORRECTION'::text,
+       ci.correction_number,
+       ci.issue_date,
+       ci.total_amount
+FROM corrective_invoice ci
+         JOIN invoice i ON i.id = ci.invoice_id
+         JOIN orders o ON o.id = i.order_id;
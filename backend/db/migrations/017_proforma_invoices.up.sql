@@ -0,0 +1,60 @@
+-- Companies that must pay before their orders are prepared. At-risk
+-- companies are held to the same rule.
+ALTER TABLE customer_company
+    ADD COLUMN requires_prepayment BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TYPE proforma_status AS ENUM ('UNPAID', 'PAID', 'CANCELLED');
+
+-- A pro-forma asks for payment up front. It is not a VAT invoice; once it is
+-- paid the final invoice is issued and linked here.
+CREATE TABLE proforma_invoice
+(
+    id                   INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_id             INT             NOT NULL UNIQUE REFERENCES orders (id),
+    proforma_number      VARCHAR(50)     NOT NULL UNIQUE,
+    issue_date           TIMESTAMPTZ     NOT NULL DEFAULT now(),
+    due_date             TIMESTAMPTZ     NOT NULL,
+    net_amount           NUMERIC(18, 2)  NOT NULL,
+    vat_amount           NUMERIC(18, 2)  NOT NULL,
+    total_amount         NUMERIC(18, 2)  NOT NULL,
+    status               proforma_status NOT NULL DEFAULT 'UNPAID',
+    buyer_name           VARCHAR(200)    NOT NULL,
+    buyer_tax_id         VARCHAR(20)     NOT NULL,
+    billing_address_line VARCHAR(255)    NOT NULL,
+    billing_city         VARCHAR(100)    NOT NULL,
+    billing_postal_code  VARCHAR(20)     NOT NULL,
+    billing_country      VARCHAR(100)    NOT NULL,
+    paid_at              TIMESTAMPTZ     NULL,
+    payment_reference    VARCHAR(100)    NULL,
+    invoice_id           INT             NULL REFERENCES invoice (id)
+);
+
+-- Numbers run per year without gaps, e.g. PRO/2026/0001.
+CREATE TABLE proforma_invoice_sequence
+(
+    year        INT PRIMARY KEY,
+    last_number INT NOT NULL
+);
+
+CREATE FUNCTION set_proforma_number() RETURNS trigger AS
+$$
+DECLARE
+    next_number INT;
+BEGIN
+    INSERT INTO proforma_invoice_sequence (year, last_number)
+    VALUES (extract(YEAR FROM NEW.issue_date)::int, 1)
+    ON CONFLICT (year) DO UPDATE
+        SET last_number = proforma_invoice_sequence.last_number + 1
+    RETURNING last_number INTO next_number;
+
+    NEW.proforma_number := 'PRO/' || extract(YEAR FROM NEW.issue_date)::text || '/' ||
+                           lpad(next_number::text, 4, '0');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER proforma_number_trigger
+    BEFORE INSERT
+    ON proforma_invoice
+    FOR EACH ROW
+EXECUTE FUNCTION set_proforma_number();
@@ -0,0 +1,94 @@
+ALTER TYPE invoice_status ADD VALUE 'PARTIALLY_PAID';
+
+CREATE TYPE payment_method AS ENUM ('BANK_TRANSFER', 'CASH', 'CARD', 'OTHER');
+
+-- Money received from a customer. Whatever part of it is not allocated to
+-- invoices stays on the customer's account as credit.
+CREATE TABLE payment
+(
+    id           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    customer_id  INT            NOT NULL REFERENCES customer_company (id),
+    amount       NUMERIC(18, 2) NOT NULL CHECK (amount > 0),
+    payment_date DATE           NOT NULL,
+    method       payment_method NOT NULL,
+    reference    VARCHAR(255)   NULL,
+    created_by   INT            NULL REFERENCES user_account (id),
+    created_at   TIMESTAMPTZ    NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_payment_customer ON payment (customer_id);
+
+CREATE TABLE payment_allocation
+(
+    id         INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    payment_id INT            NOT NULL REFERENCES payment (id) ON DELETE CASCADE,
+    invoice_id INT            NOT NULL REFERENCES invoice (id),
+    amount     NUMERIC(18, 2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ    NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_payment_allocation_payment ON payment_allocation (payment_id);
+CREATE INDEX idx_payment_allocation_invoice ON payment_allocation (invoice_id);
+
+-- What each invoice comes to after corrections and how much of it is paid.
+CREATE VIEW invoice_balance AS
+SELECT i.id                                 AS invoice_id,
+       o.customer_id,
+       i.total_amount + coalesce(c.total, 0) AS amount_due,
+       coalesce(a.total, 0)                 AS amount_paid
+FROM invoice i
+         JOIN orders o ON o.id = i.order_id
+         LEFT JOIN (SELECT invoice_id, sum(total_amount) AS total
+                    FROM corrective_invoice
+                    GROUP BY invoice_id) c ON c.invoice_id = i.id
+         LEFT JOIN (SELECT invoice_id, sum(amount) AS total
+                    FROM payment_allocation
+                    GROUP BY invoice_id) a ON a.invoice_id = i.id;
+
+-- Credit is money the customer has paid and we have not billed: unallocated
+-- payments plus invoices paid above what a correction brought them down to.
+CREATE VIEW customer_credit AS
+SELECT c.id                                             AS customer_id,
+       coalesce(p.unallocated, 0) + coalesce(b.overpaid, 0) AS amount
+FROM customer_company c
+         LEFT JOIN (SELECT p.customer_id, sum(p.amount - coalesce(a.allocated, 0)) AS unallocated
+                    FROM payment p
+                             LEFT JOIN (SELECT payment_id, sum(amount) AS allocated
+                                        FROM payment_allocation
+                                        GROUP BY payment_id) a ON a.payment_id = p.id
+                    GROUP BY p.customer_id) p ON p.customer_id = c.id
+         LEFT JOIN (SELECT customer_id, sum(greatest(amount_paid - amount_due, 0)) AS overpaid
+                    FROM invoice_balance
+                    GROUP BY customer_id) b ON b.customer_id = c.id;
+
+-- Payments join the ledger as negative entries. They belong to the customer
+-- rather than to one invoice, so their invoice_id is empty.
+DROP VIEW customer_ledger;
+
+CREATE VIEW customer_ledger AS
+SELECT o.customer_id,
+       i.id                    AS invoice_id,
+       'INVOICE'::text         AS document_type,
+       i.invoice_number::text  AS document_number,
+       i.issue_date            AS entry_date,
+       i.total_amount          AS amount
+FROM invoice i
+         JOIN orders o ON o.id = i.order_id
+UNION ALL
+SELECT o.customer_id,
+       ci.invoice_id,
+       'CORRECTION'::text,
+       ci.correction_number::text,
+       ci.issue_date,
+       ci.total_amount
+FROM corrective_invoice ci
+         JOIN invoice i ON i.id = ci.invoice_id
+         JOIN orders o ON o.id = i.order_id
+UNION ALL
+SELECT p.customer_id,
+       NULL,
+       'PAYMENT'::text,
+       coalesce(p.reference, '')::text,
+       p.payment_date::timestamptz,
+       -p.amount
+FROM payment p;
@@ -0,0 +1,37 @@
+CREATE TYPE bank_statement_format AS ENUM ('MT940', 'CSV');
+
+CREATE TYPE bank_transaction_status AS ENUM ('MATCHED', 'UNMATCHED', 'ASSIGNED', 'IGNORED');
+
+CREATE TABLE bank_statement
+(
+    id          INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    format      bank_statement_format NOT NULL,
+    imported_by INT                   NOT NULL REFERENCES user_account (id),
+    imported_at TIMESTAMPTZ           NOT NULL DEFAULT now()
+);
+
+-- Incoming transfers read from a statement. Matched ones are booked as
+-- payments straight away; UNMATCHED ones form the reconciliation queue until
+-- someone assigns them to a company or ignores them.
+CREATE TABLE bank_transaction
+(
+    id                   INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    statement_id         INT                     NOT NULL REFERENCES bank_statement (id),
+    booking_date         DATE                    NOT NULL,
+    amount               NUMERIC(18, 2)          NOT NULL CHECK (amount > 0),
+    counterparty_name    VARCHAR(255)            NULL,
+    counterparty_account VARCHAR(50)             NULL,
+    title                TEXT                    NOT NULL,
+    bank_reference       VARCHAR(100)            NULL,
+    -- Statements overlap, so the same transfer can be uploaded twice. The
+    -- fingerprint, built from the bank's transaction reference where the
+    -- statement has one, makes the second copy a no-op.
+    fingerprint          CHAR(64)                NOT NULL UNIQUE,
+    status               bank_transaction_status NOT NULL,
+    payment_id           INT                     NULL REFERENCES payment (id),
+    resolved_by          INT                     NULL REFERENCES user_account (id),
+    resolved_at          TIMESTAMPTZ             NULL
+);
+
+CREATE INDEX idx_bank_transaction_statement ON bank_transaction (statement_id);
+CREATE INDEX idx_bank_transaction_status ON bank_transaction (status);
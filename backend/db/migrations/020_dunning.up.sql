@@ -0,0 +1,14 @@
+-- Payment reminders for overdue invoices. stage is the position in the
+-- configured dunning sequence, so each reminder goes out once per invoice.
+-- There is no mail delivery yet; the reminder records who it is addressed to.
+CREATE TABLE dunning_reminder
+(
+    id                 INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    invoice_id         INT            NOT NULL REFERENCES invoice (id),
+    stage              INT            NOT NULL CHECK (stage > 0),
+    days_overdue       INT            NOT NULL,
+    recipient          VARCHAR(200)   NOT NULL,
+    amount_outstanding NUMERIC(18, 2) NOT NULL,
+    sent_at            TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    UNIQUE (invoice_id, stage)
+);
@@ -0,0 +1,24 @@
+ALTER TYPE order_status ADD VALUE 'ON_HOLD';
+
+CREATE TYPE credit_limit_action AS ENUM ('REJECT', 'HOLD');
+
+-- A company without a credit limit can order any amount. Over the limit its
+-- orders are either refused or held until someone releases them.
+ALTER TABLE customer_company
+    ADD COLUMN credit_limit        NUMERIC(18, 2)      NULL CHECK (credit_limit >= 0),
+    ADD COLUMN payment_terms_days  INT                 NOT NULL DEFAULT 14 CHECK (payment_terms_days >= 0),
+    ADD COLUMN credit_limit_action credit_limit_action NOT NULL DEFAULT 'HOLD';
+
+-- Orders put ON_HOLD for exceeding the credit limit, with the exposure that
+-- caused it and who released the order and why.
+CREATE TABLE order_credit_hold
+(
+    id            INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_id      INT            NOT NULL UNIQUE REFERENCES orders (id),
+    exposure      NUMERIC(18, 2) NOT NULL,
+    credit_limit  NUMERIC(18, 2) NOT NULL,
+    held_at       TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    released_by   INT            NULL REFERENCES user_account (id),
+    released_at   TIMESTAMPTZ    NULL,
+    justification TEXT           NULL
+);
@@ -0,0 +1,24 @@
+CREATE TYPE risk_score_source AS ENUM ('SCORING', 'OVERRIDE');
+
+-- at_risk is now set by the nightly scoring job. An admin can pin it with
+-- at_risk_override, which the job leaves alone until it is cleared.
+ALTER TABLE customer_company
+    ADD COLUMN risk_score       INT     NULL CHECK (risk_score BETWEEN 0 AND 100),
+    ADD COLUMN at_risk_override BOOLEAN NULL;
+
+-- One row whenever a company's score or at-risk status changes, with the
+-- reasons behind it.
+CREATE TABLE company_risk_score
+(
+    id             INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    customer_id    INT               NOT NULL REFERENCES customer_company (id),
+    source         risk_score_source NOT NULL,
+    score          INT               NULL CHECK (score BETWEEN 0 AND 100),
+    at_risk        BOOLEAN           NOT NULL,
+    status_changed BOOLEAN           NOT NULL,
+    reasons        TEXT[]            NOT NULL,
+    created_by     INT               NULL REFERENCES user_account (id),
+    created_at     TIMESTAMPTZ       NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_company_risk_score_customer ON company_risk_score (customer_id, created_at);
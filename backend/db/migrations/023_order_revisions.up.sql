@@ -0,0 +1,29 @@
+-- An edited order is checked against the credit limit again, so it can be
+-- held more than once. Only one hold may be open at a time.
+ALTER TABLE order_credit_hold
+    DROP CONSTRAINT order_credit_hold_order_id_key;
+
+CREATE UNIQUE INDEX idx_order_credit_hold_open ON order_credit_hold (order_id) WHERE released_at IS NULL;
+
+-- Every edit of a NEW order, numbered per order, with the quantities of the
+-- products it changed.
+CREATE TABLE order_revision
+(
+    id           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_id     INT            NOT NULL REFERENCES orders (id),
+    revision     INT            NOT NULL,
+    edited_by    INT            NOT NULL REFERENCES user_account (id),
+    edited_at    TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    total_before NUMERIC(18, 2) NOT NULL,
+    total_after  NUMERIC(18, 2) NOT NULL,
+    UNIQUE (order_id, revision)
+);
+
+CREATE TABLE order_revision_line
+(
+    revision_id     INT NOT NULL REFERENCES order_revision (id) ON DELETE CASCADE,
+    product_id      INT NOT NULL REFERENCES product (id),
+    quantity_before INT NOT NULL,
+    quantity_after  INT NOT NULL,
+    PRIMARY KEY (revision_id, product_id)
+);
@@ -0,0 +1,60 @@
+CREATE TYPE standing_order_occurrence_status AS ENUM ('PENDING', 'SKIPPED', 'CREATED', 'FAILED');
+
+-- A template a company orders from on fixed weekdays (1 = Monday ... 7 =
+-- Sunday) between its start and end date.
+CREATE TABLE standing_order
+(
+    id           INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    customer_id  INT          NOT NULL REFERENCES customer_company (id),
+    name         VARCHAR(200) NOT NULL,
+    address_id   INT          NOT NULL REFERENCES company_address (id),
+    warehouse_id INT          NULL REFERENCES warehouse (id),
+    weekdays     INT[]        NOT NULL CHECK (cardinality(weekdays) > 0 AND weekdays <@ ARRAY [1, 2, 3, 4, 5, 6, 7]),
+    start_date   DATE         NOT NULL,
+    end_date     DATE         NULL CHECK (end_date >= start_date),
+    is_paused    BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_by   INT          NOT NULL REFERENCES user_account (id),
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_standing_order_customer ON standing_order (customer_id);
+
+CREATE TABLE standing_order_line
+(
+    standing_order_id INT NOT NULL REFERENCES standing_order (id) ON DELETE CASCADE,
+    product_id        INT NOT NULL REFERENCES product (id),
+    quantity          INT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (standing_order_id, product_id)
+);
+
+-- Dates the template does not deliver on, such as public holidays.
+CREATE TABLE standing_order_skip
+(
+    standing_order_id INT  NOT NULL REFERENCES standing_order (id) ON DELETE CASCADE,
+    skip_date         DATE NOT NULL,
+    PRIMARY KEY (standing_order_id, skip_date)
+);
+
+-- A single delivery date of a template. It exists once the client has
+-- changed or skipped that date, or once the scheduler has placed its order.
+-- With custom_lines the date's own lines replace the template's.
+CREATE TABLE standing_order_occurrence
+(
+    id                INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    standing_order_id INT                              NOT NULL REFERENCES standing_order (id) ON DELETE CASCADE,
+    delivery_date     DATE                             NOT NULL,
+    status            standing_order_occurrence_status NOT NULL DEFAULT 'PENDING',
+    custom_lines      BOOLEAN                          NOT NULL DEFAULT FALSE,
+    order_id          INT                              NULL REFERENCES orders (id),
+    failure_reason    TEXT                             NULL,
+    processed_at      TIMESTAMPTZ                      NULL,
+    UNIQUE (standing_order_id, delivery_date)
+);
+
+CREATE TABLE standing_order_occurrence_line
+(
+    occurrence_id INT NOT NULL REFERENCES standing_order_occurrence (id) ON DELETE CASCADE,
+    product_id    INT NOT NULL REFERENCES product (id),
+    quantity      INT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (occurrence_id, product_id)
+);
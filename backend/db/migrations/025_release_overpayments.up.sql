@@ -0,0 +1,36 @@
+-- A correction that brings an invoice below what was allocated to it now
+-- releases the excess back to the payments, newest allocation first, so the
+-- money can be allocated to another invoice. Credit is then just the
+-- unallocated part of the payments.
+WITH excess AS (SELECT invoice_id, amount_paid - greatest(amount_due, 0) AS excess
+                FROM invoice_balance
+                WHERE amount_paid > greatest(amount_due, 0)),
+     releases AS (SELECT a.id,
+                         a.amount,
+                         least(a.amount, greatest(e.excess - (sum(a.amount)
+                                                              OVER (PARTITION BY a.invoice_id ORDER BY a.id DESC) -
+                                                              a.amount), 0)) AS released
+                  FROM payment_allocation a
+                           JOIN excess e ON e.invoice_id = a.invoice_id),
+     reduced AS (UPDATE payment_allocation a
+         SET amount = a.amount - r.released
+         FROM releases r
+         WHERE a.id = r.id
+           AND r.released > 0
+           AND r.released < r.amount)
+DELETE
+FROM payment_allocation a
+    USING releases r
+WHERE a.id = r.id
+  AND r.released = r.amount;
+
+CREATE OR REPLACE VIEW customer_credit AS
+SELECT c.id                       AS customer_id,
+       coalesce(p.unallocated, 0) AS amount
+FROM customer_company c
+         LEFT JOIN (SELECT p.customer_id, sum(p.amount - coalesce(a.allocated, 0)) AS unallocated
+                    FROM payment p
+                             LEFT JOIN (SELECT payment_id, sum(amount) AS allocated
+                                        FROM payment_allocation
+                                        GROUP BY payment_id) a ON a.payment_id = p.id
+                    GROUP BY p.customer_id) p ON p.customer_id = c.id;
@@ -0,0 +1,87 @@
+-- name: CreateBankStatement :one
+INSERT INTO bank_statement (format, imported_by)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: CreateBankTransaction :one
+-- Returns no row when the statement entry has been imported before.
+INSERT INTO bank_transaction (statement_id, booking_date, amount, counterparty_name, counterparty_account, title,
+                              bank_reference, fingerprint, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (fingerprint) DO NOTHING
+RETURNING id;
+
+-- name: GetBankStatementById :one
+SELECT s.id,
+       s.format,
+       s.imported_at,
+       u.email AS imported_by
+FROM bank_statement s
+         JOIN user_account u ON u.id = s.imported_by
+WHERE s.id = $1;
+
+-- name: GetBankTransactionForUpdate :one
+SELECT id,
+       booking_date,
+       amount::text AS amount,
+       title,
+       bank_reference,
+       status
+FROM bank_transaction
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: ListBankStatements :many
+SELECT s.id,
+       s.format,
+       s.imported_at,
+       u.email                                           AS imported_by,
+       count(t.id)                                       AS transaction_count,
+       count(t.id) FILTER (WHERE t.status = 'UNMATCHED') AS unmatched_count
+FROM bank_statement s
+         JOIN user_account u ON u.id = s.imported_by
+         LEFT JOIN bank_transaction t ON t.statement_id = s.id
+GROUP BY s.id, u.email
+ORDER BY s.imported_at DESC;
+
+-- name: ListBankTransactions :many
+SELECT t.id,
+       t.statement_id,
+       t.booking_date,
+       t.amount::text AS amount,
+       t.counterparty_name,
+       t.counterparty_account,
+       t.title,
+       t.bank_reference,
+       t.status,
+       t.payment_id,
+       p.customer_id,
+       c.name         AS company_name
+FROM bank_transaction t
+         LEFT JOIN payment p ON p.id = t.payment_id
+         LEFT JOIN customer_company c ON c.id = p.customer_id
+WHERE (sqlc.narg(statement_id)::int4 IS NULL OR t.statement_id = sqlc.narg(statement_id)::int4)
+  AND (sqlc.narg(status)::bank_transaction_status IS NULL OR
+       t.status = sqlc.narg(status)::bank_transaction_status)
+ORDER BY t.booking_date DESC, t.id DESC;
+
+-- name: ListCompanyIdsByTaxId :many
+-- Tax ids are compared by their digits, so "PL 123-456-78-90" matches
+-- "1234567890".
+SELECT id
+FROM customer_company
+WHERE regexp_replace(tax_id, '[^0-9]', '', 'g') = sqlc.arg(tax_id)::text;
+
+-- name: ListInvoicesWithOutstandingAmount :many
+SELECT invoice_id
+FROM invoice_balance
+WHERE customer_id = sqlc.arg(customer_id)::int4
+  AND amount_due - amount_paid = sqlc.arg(amount)::numeric;
+
+-- name: ResolveBankTransaction :exec
+UPDATE bank_transaction
+SET status      = $2,
+    payment_id  = $3,
+    resolved_by = $4,
+    resolved_at = now()
+WHERE id = $1;
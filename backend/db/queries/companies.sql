@@ -27,10 +27,36 @@ SELECT c.id,
            ELSE 'INACTIVE'
            END::company_status                                                          AS status,
        c.created_at,
+       c.requires_prepayment,
        count(o.id) FILTER (WHERE o.status != 'CANCELLED')                               AS order_count,
        coalesce(sum(o.total_amount) FILTER (WHERE o.status != 'CANCELLED'), 0)::text AS total_orders_value,
-       count(o.id) FILTER (WHERE o.status = 'SHIPPED')                                  AS completed_orders
+       count(o.id) FILTER (WHERE o.status = 'SHIPPED')                                  AS completed_orders,
+       (SELECT coalesce(sum(greatest(b.amount_due - b.amount_paid, 0)), 0)
+        FROM invoice_balance b
+        WHERE b.customer_id = c.id)::text                                               AS balance,
+       cc.amount::text                                                                  AS credit,
+       c.credit_limit::text                                                             AS credit_limit,
+       c.payment_terms_days,
+       c.credit_limit_action,
+       c.risk_score,
+       c.at_risk_override
 FROM customer_company AS c
+         JOIN customer_credit AS cc ON (cc.customer_id = c.id)
          LEFT JOIN orders AS o ON (o.customer_id = c.id)
 WHERE c.id = $1
-GROUP BY c.id;
\ No newline at end of file
+GROUP BY c.id, cc.amount;
+
+-- name: GetCompanyExposure :one
+-- Exposure is what the company owes on open invoices plus the value of its
+-- orders that have not been invoiced yet. The order being checked is left out.
+SELECT ((SELECT coalesce(sum(greatest(b.amount_due - b.amount_paid, 0)), 0)
+         FROM invoice_balance b
+                  JOIN invoice i ON i.id = b.invoice_id
+         WHERE b.customer_id = sqlc.arg(customer_id)::int
+           AND i.status IN ('UNPAID', 'PARTIALLY_PAID', 'OVERDUE')) +
+        (SELECT coalesce(sum(o.total_amount), 0)
+         FROM orders o
+         WHERE o.customer_id = sqlc.arg(customer_id)::int
+           AND o.id != sqlc.arg(exclude_order_id)::int
+           AND o.status != 'CANCELLED'
+           AND NOT EXISTS (SELECT 1 FROM invoice i WHERE i.order_id = o.id)))::text AS exposure;
@@ -3,6 +3,28 @@ INSERT INTO company_address (customer_company_id, address_line, city, postal_cod
 VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
+-- name: GetCompanyAddress :one
+SELECT *
+FROM company_address
+WHERE id = $1
+  AND customer_company_id = $2;
+
+-- name: GetCompanyBillingAddress :one
+SELECT *
+FROM company_address
+WHERE customer_company_id = $1
+  AND type = 'BILLING'
+ORDER BY id
+LIMIT 1;
+
+-- name: GetCompanyShippingAddress :one
+SELECT *
+FROM company_address
+WHERE customer_company_id = $1
+  AND type = 'SHIPPING'
+ORDER BY id
+LIMIT 1;
+
 -- name: ListCompanyAddresses :many
 SELECT *
 FROM company_address
@@ -0,0 +1,37 @@
+-- name: GetCompanySettings :one
+SELECT *
+FROM company_settings
+WHERE id = 1;
+
+-- name: UpsertCompanySettings :one
+INSERT INTO company_settings (id, legal_name, tax_id, regon, address_line, city, postal_code, country, iban, bank_name)
+VALUES (1, $1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (id) DO UPDATE
+    SET legal_name   = excluded.legal_name,
+        tax_id       = excluded.tax_id,
+        regon        = excluded.regon,
+        address_line = excluded.address_line,
+        city         = excluded.city,
+        postal_code  = excluded.postal_code,
+        country      = excluded.country,
+        iban         = excluded.iban,
+        bank_name    = excluded.bank_name,
+        updated_at   = now()
+RETURNING *;
+
+-- name: SetCompanyLogo :one
+UPDATE company_settings
+SET logo_id    = sqlc.narg(logo_id),
+    updated_at = now()
+WHERE id = 1
+RETURNING *;
+
+-- name: CreateCompanyLogo :one
+INSERT INTO company_logo (content_type, data)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetCompanyLogo :one
+SELECT *
+FROM company_logo
+WHERE id = $1;
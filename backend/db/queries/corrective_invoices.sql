@@ -0,0 +1,80 @@
+-- name: CreateCorrectiveInvoice :one
+INSERT INTO corrective_invoice (invoice_id, reason, stock_movement_id, net_amount, vat_amount, total_amount, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: CreateCorrectiveInvoiceLine :exec
+INSERT INTO corrective_invoice_line (corrective_invoice_id, order_item_id, vat_rate, quantity_before, quantity_after,
+                                     unit_price_before, unit_price_after, net_change, vat_change, gross_change)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: CorrectiveInvoiceExistsForMovement :one
+SELECT EXISTS (SELECT 1
+               FROM corrective_invoice
+               WHERE stock_movement_id = $1);
+
+-- name: GetCorrectiveInvoiceById :one
+SELECT ci.id,
+       ci.invoice_id,
+       ci.correction_number,
+       ci.issue_date,
+       ci.reason,
+       ci.stock_movement_id,
+       ci.net_amount::text   AS net_amount,
+       ci.vat_amount::text   AS vat_amount,
+       ci.total_amount::text AS total_amount,
+       i.invoice_number,
+       i.issue_date          AS invoice_issue_date,
+       o.customer_id
+FROM corrective_invoice ci
+         JOIN invoice i ON i.id = ci.invoice_id
+         JOIN orders o ON o.id = i.order_id
+WHERE ci.id = $1;
+
+-- name: ListCorrectiveInvoicesForInvoice :many
+SELECT id,
+       correction_number,
+       issue_date,
+       reason,
+       stock_movement_id,
+       net_amount::text   AS net_amount,
+       vat_amount::text   AS vat_amount,
+       total_amount::text AS total_amount
+FROM corrective_invoice
+WHERE invoice_id = $1
+ORDER BY id;
+
+-- name: ListCorrectiveInvoiceLines :many
+SELECT l.order_item_id,
+       p.name                    AS product_name,
+       p.unit,
+       l.vat_rate,
+       l.quantity_before,
+       l.quantity_after,
+       l.unit_price_before::text AS unit_price_before,
+       l.unit_price_after::text  AS unit_price_after,
+       l.net_change::text        AS net_change,
+       l.vat_change::text        AS vat_change,
+       l.gross_change::text      AS gross_change
+FROM corrective_invoice_line l
+         JOIN order_item oi ON oi.id = l.order_item_id
+         JOIN product p ON p.id = oi.product_id
+WHERE l.corrective_invoice_id = $1
+ORDER BY l.id;
+
+-- name: ListCorrectableInvoiceLines :many
+-- Lines of an invoice as they stand after every correction issued so far.
+SELECT oi.id                                             AS order_item_id,
+       oi.product_id,
+       oi.vat_rate,
+       coalesce(c.quantity_after, oi.quantity)           AS quantity,
+       coalesce(c.unit_price_after, oi.unit_price)::text AS unit_price
+FROM invoice i
+         JOIN order_item oi ON oi.order_id = i.order_id
+         LEFT JOIN LATERAL (SELECT l.quantity_after, l.unit_price_after
+                            FROM corrective_invoice_line l
+                            WHERE l.order_item_id = oi.id
+                            ORDER BY l.corrective_invoice_id DESC
+                            LIMIT 1) c ON TRUE
+WHERE i.id = $1
+ORDER BY oi.id;
@@ -22,9 +22,33 @@ RETURNING *;
 
 -- name: UpdateCompany :one
 UPDATE customer_company
-SET name       = coalesce(sqlc.narg(name), name),
-    tax_id     = coalesce(sqlc.narg(tax_id), tax_id),
-    main_email = coalesce(sqlc.narg(main_email), main_email),
-    phone      = coalesce(sqlc.narg(phone), phone)
+SET name                = coalesce(sqlc.narg(name), name),
+    tax_id              = coalesce(sqlc.narg(tax_id), tax_id),
+    main_email          = coalesce(sqlc.narg(main_email), main_email),
+    phone               = coalesce(sqlc.narg(phone), phone),
+    requires_prepayment = coalesce(sqlc.narg(requires_prepayment), requires_prepayment)
 WHERE id = sqlc.arg(id)
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: SetCompanyCustomerGroup :one
+UPDATE customer_company
+SET customer_group_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: GetCompanyCreditForUpdate :one
+-- Locking the company serialises its orders, so two of them cannot both fit
+-- under the limit the other one uses up.
+SELECT credit_limit::text AS credit_limit,
+       credit_limit_action
+FROM customer_company
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: SetCompanyCreditPolicy :one
+UPDATE customer_company
+SET credit_limit        = $2,
+    payment_terms_days  = $3,
+    credit_limit_action = $4
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,25 @@
+-- name: ListCustomerGroups :many
+SELECT g.id,
+       g.name,
+       g.created_at,
+       count(c.id) AS member_count
+FROM customer_group g
+         LEFT JOIN customer_company c ON c.customer_group_id = g.id
+GROUP BY g.id
+ORDER BY g.name;
+
+-- name: GetCustomerGroupById :one
+SELECT *
+FROM customer_group
+WHERE id = $1;
+
+-- name: CreateCustomerGroup :one
+INSERT INTO customer_group (name)
+VALUES ($1)
+RETURNING *;
+
+-- name: UpdateCustomerGroup :one
+UPDATE customer_group
+SET name = $2
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,61 @@
+-- name: ListDiscountRules :many
+SELECT d.id,
+       d.name,
+       d.product_id,
+       p.name          AS product_name,
+       d.category,
+       d.discount_type,
+       d.value::text   AS value_text,
+       d.min_quantity,
+       d.valid_from,
+       d.valid_to,
+       d.is_active,
+       d.created_at
+FROM discount_rule d
+         LEFT JOIN product p ON p.id = d.product_id
+ORDER BY d.created_at DESC, d.id DESC;
+
+-- name: GetDiscountRuleById :one
+SELECT d.id,
+       d.name,
+       d.product_id,
+       p.name          AS product_name,
+       d.category,
+       d.discount_type,
+       d.value::text   AS value_text,
+       d.min_quantity,
+       d.valid_from,
+       d.valid_to,
+       d.is_active,
+       d.created_at
+FROM discount_rule d
+         LEFT JOIN product p ON p.id = d.product_id
+WHERE d.id = $1;
+
+-- name: CreateDiscountRule :one
+INSERT INTO discount_rule (name, product_id, category, discount_type, value, min_quantity, valid_from, valid_to)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateDiscountRule :one
+UPDATE discount_rule
+SET name         = COALESCE(sqlc.narg(name), name),
+    value        = COALESCE(sqlc.narg(value), value),
+    min_quantity = COALESCE(sqlc.narg(min_quantity), min_quantity),
+    valid_from   = COALESCE(sqlc.narg(valid_from), valid_from),
+    valid_to     = COALESCE(sqlc.narg(valid_to), valid_to),
+    is_active    = COALESCE(sqlc.narg(is_active), is_active)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListApplicableDiscountRules :many
+SELECT id,
+       discount_type,
+       value::text AS value_text
+FROM discount_rule
+WHERE is_active
+  AND (product_id = sqlc.arg(product_id)::int4 OR category = sqlc.arg(category)::text)
+  AND min_quantity <= sqlc.arg(quantity)::int4
+  AND (valid_from IS NULL OR valid_from <= sqlc.arg(on_date)::date)
+  AND (valid_to IS NULL OR valid_to >= sqlc.arg(on_date)::date)
+ORDER BY id;
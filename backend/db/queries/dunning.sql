@@ -0,0 +1,37 @@
+-- name: CreateDunningReminder :exec
+INSERT INTO dunning_reminder (invoice_id, stage, days_overdue, recipient, amount_outstanding)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (invoice_id, stage) DO NOTHING;
+
+-- name: ListDunningReminders :many
+SELECT stage,
+       days_overdue,
+       recipient,
+       amount_outstanding::text AS amount_outstanding,
+       sent_at
+FROM dunning_reminder
+WHERE invoice_id = $1
+ORDER BY stage;
+
+-- name: ListOverdueInvoicesForDunning :many
+SELECT i.id,
+       i.invoice_number,
+       c.main_email,
+       (now()::date - i.due_date::date)::int4      AS days_overdue,
+       (b.amount_due - b.amount_paid)::text        AS amount_outstanding,
+       coalesce(max(r.stage), 0)::int4             AS last_stage
+FROM invoice i
+         JOIN orders o ON o.id = i.order_id
+         JOIN customer_company c ON c.id = o.customer_id
+         JOIN invoice_balance b ON b.invoice_id = i.id
+         LEFT JOIN dunning_reminder r ON r.invoice_id = i.id
+WHERE i.status = 'OVERDUE'
+GROUP BY i.id, c.main_email, b.amount_due, b.amount_paid
+ORDER BY i.due_date;
+
+-- name: MarkOverdueInvoices :many
+UPDATE invoice
+SET status = 'OVERDUE'
+WHERE status IN ('UNPAID', 'PARTIALLY_PAID')
+  AND due_date < now()
+RETURNING id, invoice_number;
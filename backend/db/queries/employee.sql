@@ -1,16 +1,16 @@
 -- name: ListEmployees :many
-SELECT id, first_name, last_name, position, is_active, hire_date
+SELECT id, first_name, last_name, position, is_active, hire_date, warehouse_id
 FROM employee
 ORDER BY last_name, first_name;
 
 -- name: GetEmployeeById :one
-SELECT id, first_name, last_name, position, is_active, hire_date
+SELECT id, first_name, last_name, position, is_active, hire_date, warehouse_id
 FROM employee
 WHERE id = $1;
 
 -- name: CreateEmployee :one
-INSERT INTO employee (first_name, last_name, position, hire_date)
-VALUES ($1, $2, $3, $4)
+INSERT INTO employee (first_name, last_name, position, hire_date, warehouse_id)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: UpdateEmployee :one
@@ -19,6 +19,7 @@ SET first_name = coalesce(sqlc.narg(first_name), first_name),
     last_name  = coalesce(sqlc.narg(last_name), last_name),
     position   = coalesce(sqlc.narg(position), position),
     is_active  = coalesce(sqlc.narg(is_active), is_active),
-    hire_date  = coalesce(sqlc.narg(hire_date), hire_date)
+    hire_date  = coalesce(sqlc.narg(hire_date), hire_date),
+    warehouse_id = coalesce(sqlc.narg(warehouse_id), warehouse_id)
 WHERE id = sqlc.arg(id)
 RETURNING *;
@@ -6,10 +6,9 @@ SELECT i.id,
        i.total_amount::text AS total_amount,
        i.status,
        o.id                 AS order_id,
-       c.name               AS company_name
+       i.buyer_name         AS company_name
 FROM invoice i
          JOIN orders o ON o.id = i.order_id
-         JOIN customer_company c ON c.id = o.customer_id
 ORDER BY i.issue_date DESC;
 
 -- name: ListInvoicesForCompany :many
@@ -33,10 +32,10 @@ WHERE id = $1;
 -- name: GetInvoiceWithOrder :one
 SELECT i.*,
        i.total_amount::text AS total_amount_str,
+       i.net_amount::text   AS net_amount_str,
+       i.vat_amount::text   AS vat_amount_str,
        o.order_number,
        o.order_date,
-       c.name               AS company_name,
-       c.tax_id,
        c.main_email,
        c.id                 AS customer_id
 FROM invoice i
@@ -46,10 +45,13 @@ WHERE i.id = $1;
 
 -- name: GetInvoiceItems :many
 SELECT oi.quantity,
-       oi.unit_price::text AS unit_price,
-       oi.line_total::text AS line_total,
-       p.name              AS product_name,
-       p.unit
+       oi.unit_price::text  AS unit_price,
+       oi.line_total::text  AS line_total,
+       p.name               AS product_name,
+       p.unit,
+       oi.vat_rate,
+       oi.vat_amount::text  AS vat_amount,
+       oi.gross_total::text AS gross_total
 FROM order_item oi
          JOIN product p ON p.id = oi.product_id
 WHERE oi.order_id = $1;
@@ -59,8 +61,17 @@ INSERT INTO invoice (order_id,
                      issue_date,
                      due_date,
                      total_amount,
-                     status)
-VALUES ($1, $2,  $3, $4, 'UNPAID')
+                     status,
+                     net_amount,
+                     vat_amount,
+                     buyer_name,
+                     buyer_tax_id,
+                     billing_address_id,
+                     billing_address_line,
+                     billing_city,
+                     billing_postal_code,
+                     billing_country)
+VALUES ($1, $2,  $3, $4, 'UNPAID', $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING *;
 
 -- name: UpdateInvoiceStatus :one
@@ -73,3 +84,51 @@ RETURNING *;
 SELECT EXISTS (SELECT 1
                FROM invoice
                WHERE order_id = $1);
+
+
+-- name: CreateInvoiceVatSummary :exec
+INSERT INTO invoice_vat_summary (invoice_id, vat_rate, net_amount, vat_amount, gross_amount)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListInvoiceVatSummary :many
+SELECT vat_rate,
+       net_amount::text   AS net_amount,
+       vat_amount::text   AS vat_amount,
+       gross_amount::text AS gross_amount
+FROM invoice_vat_summary
+WHERE invoice_id = $1
+ORDER BY vat_rate;
+
+-- name: SnapshotInvoiceSeller :exec
+UPDATE invoice i
+SET seller_name         = cs.legal_name,
+    seller_tax_id       = cs.tax_id,
+    seller_regon        = cs.regon,
+    seller_address_line = cs.address_line,
+    seller_city         = cs.city,
+    seller_postal_code  = cs.postal_code,
+    seller_country      = cs.country,
+    seller_iban         = cs.iban,
+    seller_bank_name    = cs.bank_name,
+    seller_logo_id      = cs.logo_id
+FROM company_settings cs
+WHERE i.id = $1
+  AND cs.id = 1;
+
+-- name: GetInvoiceByOrderId :one
+SELECT *
+FROM invoice
+WHERE order_id = $1;
+
+-- name: GetInvoiceForUpdate :one
+SELECT *
+FROM invoice
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: GetInvoiceByNumber :one
+SELECT i.id,
+       o.customer_id
+FROM invoice i
+         JOIN orders o ON o.id = i.order_id
+WHERE i.invoice_number = $1;
@@ -1,4 +1,5 @@
 -- name: InsertOrderItem :one
-INSERT INTO order_item(order_id, product_id, quantity, unit_price, line_total)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO order_item(order_id, product_id, quantity, unit_price, line_total, price_source, price_list_id, list_price,
+                       discount_amount, discount_rule_id, vat_rate, vat_amount, gross_total)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING *;
@@ -1,6 +1,7 @@
 -- name: CreateOrder :one
-INSERT INTO orders(customer_id)
-VALUES ($1)
+INSERT INTO orders(customer_id, warehouse_id, shipping_address_id, shipping_address_line, shipping_city,
+                   shipping_postal_code, shipping_country)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: SetOrderTotalAmount :one
@@ -21,17 +22,35 @@ WHERE customer_id = $1
 ORDER BY order_date DESC;
 
 -- name: GetOrderById :one
-SELECT id, order_number, customer_id, status, total_amount::text, order_date
+SELECT id,
+       order_number,
+       customer_id,
+       status,
+       total_amount::text,
+       order_date,
+       warehouse_id,
+       shipping_address_line,
+       shipping_city,
+       shipping_postal_code,
+       shipping_country
 FROM orders
 WHERE id = $1;
 
 -- name: GetOrderItems :many
 SELECT oi.id,
        oi.product_id,
-       p.name              AS product_name,
+       p.name                   AS product_name,
        oi.quantity,
-       oi.unit_price::text AS unit_price,
-       oi.line_total::text AS line_total
+       oi.unit_price::text      AS unit_price,
+       oi.line_total::text      AS line_total,
+       oi.price_source,
+       oi.price_list_id,
+       oi.list_price::text      AS list_price,
+       oi.discount_amount::text AS discount_amount,
+       oi.discount_rule_id,
+       oi.vat_rate,
+       oi.vat_amount::text      AS vat_amount,
+       oi.gross_total::text     AS gross_total
 FROM order_item oi
          JOIN product p ON oi.product_id = p.id
 WHERE oi.order_id = $1;
@@ -40,3 +59,58 @@ WHERE oi.order_id = $1;
 UPDATE orders
 SET status = $2
 WHERE id = $1;
+
+-- name: CreateOrderCreditHold :exec
+INSERT INTO order_credit_hold(order_id, exposure, credit_limit)
+VALUES ($1, $2, $3);
+
+-- name: ReleaseOrderCreditHold :execrows
+UPDATE order_credit_hold
+SET released_by   = $2,
+    released_at   = now(),
+    justification = $3
+WHERE order_id = $1
+  AND released_at IS NULL;
+
+-- name: DeleteOrderItems :exec
+DELETE
+FROM order_item
+WHERE order_id = $1;
+
+-- name: CreateOrderRevision :one
+INSERT INTO order_revision (order_id, revision, edited_by, total_before, total_after)
+SELECT sqlc.arg(order_id)::int,
+       coalesce(max(revision), 0) + 1,
+       sqlc.arg(edited_by)::int,
+       sqlc.arg(total_before)::numeric,
+       sqlc.arg(total_after)::numeric
+FROM order_revision
+WHERE order_id = sqlc.arg(order_id)::int
+RETURNING id;
+
+-- name: CreateOrderRevisionLine :exec
+INSERT INTO order_revision_line (revision_id, product_id, quantity_before, quantity_after)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListOrderRevisions :many
+SELECT id,
+       revision,
+       edited_by,
+       edited_at,
+       total_before::text AS total_before,
+       total_after::text  AS total_after
+FROM order_revision
+WHERE order_id = $1
+ORDER BY revision DESC;
+
+-- name: ListOrderRevisionLines :many
+SELECT l.revision_id,
+       l.product_id,
+       p.name AS product_name,
+       l.quantity_before,
+       l.quantity_after
+FROM order_revision_line l
+         JOIN order_revision r ON r.id = l.revision_id
+         JOIN product p ON p.id = l.product_id
+WHERE r.order_id = $1
+ORDER BY l.revision_id, p.name;
@@ -0,0 +1,107 @@
+-- name: CreatePayment :one
+INSERT INTO payment (customer_id, amount, payment_date, method, reference, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: CreatePaymentAllocation :exec
+INSERT INTO payment_allocation (payment_id, invoice_id, amount)
+VALUES ($1, $2, $3);
+
+-- name: DeletePaymentAllocation :exec
+DELETE
+FROM payment_allocation
+WHERE id = $1;
+
+-- name: GetCustomerCredit :one
+SELECT amount::text AS amount
+FROM customer_credit
+WHERE customer_id = $1;
+
+-- name: GetInvoiceBalance :one
+SELECT amount_due::text  AS amount_due,
+       amount_paid::text AS amount_paid
+FROM invoice_balance
+WHERE invoice_id = $1;
+
+-- name: GetInvoiceBalanceForUpdate :one
+-- Locks the invoice so its status is derived from a balance nobody else is
+-- changing at the same time.
+SELECT i.id,
+       b.customer_id,
+       i.status,
+       b.amount_due::text  AS amount_due,
+       b.amount_paid::text AS amount_paid
+FROM invoice i
+         JOIN invoice_balance b ON b.invoice_id = i.id
+WHERE i.id = $1
+    FOR UPDATE OF i;
+
+-- name: GetPaymentById :one
+SELECT p.id,
+       p.customer_id,
+       c.name                                    AS company_name,
+       p.amount::text                            AS amount,
+       p.payment_date,
+       p.method,
+       p.reference,
+       p.created_at,
+       (p.amount - coalesce(a.allocated, 0))::text AS unallocated
+FROM payment p
+         JOIN customer_company c ON c.id = p.customer_id
+         LEFT JOIN (SELECT payment_id, sum(amount) AS allocated
+                    FROM payment_allocation
+                    GROUP BY payment_id) a ON a.payment_id = p.id
+WHERE p.id = $1;
+
+-- name: GetPaymentForUpdate :one
+SELECT p.id,
+       p.customer_id,
+       (p.amount - coalesce((SELECT sum(a.amount)
+                             FROM payment_allocation a
+                             WHERE a.payment_id = p.id), 0))::text AS unallocated
+FROM payment p
+WHERE p.id = $1
+    FOR UPDATE;
+
+-- name: ListInvoiceAllocationsForUpdate :many
+-- Newest first, the order in which a correction releases them.
+SELECT id,
+       amount::text AS amount
+FROM payment_allocation
+WHERE invoice_id = $1
+ORDER BY id DESC
+    FOR UPDATE;
+
+-- name: ListPaymentAllocations :many
+SELECT a.id,
+       a.invoice_id,
+       i.invoice_number,
+       a.amount::text AS amount,
+       a.created_at
+FROM payment_allocation a
+         JOIN invoice i ON i.id = a.invoice_id
+WHERE a.payment_id = $1
+ORDER BY a.id;
+
+-- name: ListPayments :many
+SELECT p.id,
+       p.customer_id,
+       c.name                                    AS company_name,
+       p.amount::text                            AS amount,
+       p.payment_date,
+       p.method,
+       p.reference,
+       p.created_at,
+       (p.amount - coalesce(a.allocated, 0))::text AS unallocated
+FROM payment p
+         JOIN customer_company c ON c.id = p.customer_id
+         LEFT JOIN (SELECT payment_id, sum(amount) AS allocated
+                    FROM payment_allocation
+                    GROUP BY payment_id) a ON a.payment_id = p.id
+WHERE (sqlc.narg(customer_id)::int4 IS NULL OR p.customer_id = sqlc.narg(customer_id)::int4)
+ORDER BY p.payment_date DESC, p.id DESC;
+
+-- name: UpdatePaymentAllocationAmount :exec
+UPDATE payment_allocation
+SET amount = $2
+WHERE id = $1;
@@ -0,0 +1,39 @@
+-- name: CreatePickListItem :one
+INSERT INTO pick_list_item (order_id, product_id, lot_id, quantity)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: DeletePickListItemsByOrder :exec
+DELETE
+FROM pick_list_item
+WHERE order_id = $1;
+
+-- name: ListPickListItemsByOrder :many
+SELECT pi.id,
+       pi.product_id,
+       p.name AS product_name,
+       pi.lot_id,
+       l.batch_number,
+       l.best_before,
+       pi.quantity
+FROM pick_list_item pi
+         JOIN product p ON p.id = pi.product_id
+         JOIN stock_lot l ON l.id = pi.lot_id
+WHERE pi.order_id = $1
+ORDER BY pi.product_id, l.best_before, pi.id;
+
+-- name: ListPickedQuantitiesByProduct :many
+SELECT pi.lot_id,
+       sum(pi.quantity)::int4 AS quantity
+FROM pick_list_item pi
+         JOIN orders o ON o.id = pi.order_id
+WHERE pi.product_id = $1
+  AND o.status = 'IN_PREPARATION'
+GROUP BY pi.lot_id;
+
+-- name: IsLotOnOpenPickList :one
+SELECT EXISTS (SELECT 1
+               FROM pick_list_item pi
+                        JOIN orders o ON o.id = pi.order_id
+               WHERE pi.lot_id = $1
+                 AND o.status = 'IN_PREPARATION');
@@ -0,0 +1,98 @@
+-- name: ListPriceLists :many
+SELECT pl.id,
+       pl.name,
+       pl.customer_company_id,
+       c.name AS customer_company_name,
+       pl.customer_group_id,
+       g.name AS customer_group_name,
+       pl.valid_from,
+       pl.valid_to,
+       pl.is_active,
+       pl.created_at
+FROM price_list pl
+         LEFT JOIN customer_company c ON c.id = pl.customer_company_id
+         LEFT JOIN customer_group g ON g.id = pl.customer_group_id
+WHERE (sqlc.narg(customer_company_id)::int4 IS NULL OR pl.customer_company_id = sqlc.narg(customer_company_id)::int4)
+  AND (sqlc.narg(customer_group_id)::int4 IS NULL OR pl.customer_group_id = sqlc.narg(customer_group_id)::int4)
+ORDER BY pl.valid_from DESC, pl.id DESC;
+
+-- name: GetPriceListById :one
+SELECT pl.id,
+       pl.name,
+       pl.customer_company_id,
+       c.name AS customer_company_name,
+       pl.customer_group_id,
+       g.name AS customer_group_name,
+       pl.valid_from,
+       pl.valid_to,
+       pl.is_active,
+       pl.created_at
+FROM price_list pl
+         LEFT JOIN customer_company c ON c.id = pl.customer_company_id
+         LEFT JOIN customer_group g ON g.id = pl.customer_group_id
+WHERE pl.id = $1;
+
+-- name: CreatePriceList :one
+INSERT INTO price_list (name, customer_company_id, customer_group_id, valid_from, valid_to)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdatePriceList :one
+UPDATE price_list
+SET name       = COALESCE(sqlc.narg(name), name),
+    valid_from = COALESCE(sqlc.narg(valid_from), valid_from),
+    valid_to   = COALESCE(sqlc.narg(valid_to), valid_to),
+    is_active  = COALESCE(sqlc.narg(is_active), is_active)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetPriceListOwner :one
+UPDATE price_list
+SET customer_company_id = $2,
+    customer_group_id   = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListPriceListItems :many
+SELECT i.product_id,
+       p.name                  AS product_name,
+       p.unit,
+       i.price::text           AS price_text,
+       p.default_price::text   AS default_price_text
+FROM price_list_item i
+         JOIN product p ON p.id = i.product_id
+WHERE i.price_list_id = $1
+ORDER BY p.name;
+
+-- name: UpsertPriceListItem :one
+INSERT INTO price_list_item (price_list_id, product_id, price)
+VALUES ($1, $2, $3)
+ON CONFLICT (price_list_id, product_id) DO UPDATE
+    SET price = excluded.price
+RETURNING *;
+
+-- name: DeletePriceListItem :one
+DELETE
+FROM price_list_item
+WHERE price_list_id = $1
+  AND product_id = $2
+RETURNING *;
+
+-- name: GetEffectivePrice :one
+SELECT pl.id         AS price_list_id,
+       CASE
+           WHEN pl.customer_company_id IS NOT NULL THEN 'CUSTOMER_PRICE_LIST'
+           ELSE 'GROUP_PRICE_LIST'
+           END::price_source AS price_source,
+       i.price,
+       i.price::text AS price_text
+FROM price_list pl
+         JOIN price_list_item i ON i.price_list_id = pl.id
+         JOIN customer_company c ON c.id = sqlc.arg(customer_id)::int4
+WHERE i.product_id = sqlc.arg(product_id)::int4
+  AND pl.is_active
+  AND (pl.customer_company_id = c.id OR pl.customer_group_id = c.customer_group_id)
+  AND pl.valid_from <= sqlc.arg(on_date)::date
+  AND (pl.valid_to IS NULL OR pl.valid_to >= sqlc.arg(on_date)::date)
+ORDER BY pl.customer_company_id IS NULL, pl.valid_from DESC, pl.id DESC
+LIMIT 1;
@@ -26,8 +26,8 @@ GROUP BY p.id, p.name, s.quantity, s.min_quantity
 ORDER BY p.name;
 
 -- name: CreateProduct :one
-INSERT INTO product (name, category, unit, default_price)
-VALUES ($1, $2, $3, $4)
+INSERT INTO product (name, category, unit, default_price, vat_rate)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: UpdateProduct :one
@@ -35,7 +35,8 @@ UPDATE product
 SET name          = COALESCE(sqlc.narg(name), name),
     category      = COALESCE(sqlc.narg(category), category),
     unit          = COALESCE(sqlc.narg(unit), unit),
-    default_price = COALESCE(sqlc.narg(default_price), default_price)
+    default_price = COALESCE(sqlc.narg(default_price), default_price),
+    vat_rate      = COALESCE(sqlc.narg(vat_rate), vat_rate)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
@@ -0,0 +1,28 @@
+-- name: ListSupplierProducts :many
+SELECT ps.product_id,
+       p.name                AS product_name,
+       p.unit,
+       ps.lead_time_days,
+       ps.min_order_quantity,
+       ps.unit_price::text   AS unit_price_text
+FROM product_supplier ps
+         JOIN product p ON p.id = ps.product_id
+WHERE ps.supplier_id = $1
+ORDER BY p.name;
+
+-- name: UpsertProductSupplier :one
+INSERT INTO product_supplier (product_id, supplier_id, lead_time_days, min_order_quantity, unit_price)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (product_id) DO UPDATE
+    SET supplier_id        = excluded.supplier_id,
+        lead_time_days     = excluded.lead_time_days,
+        min_order_quantity = excluded.min_order_quantity,
+        unit_price         = excluded.unit_price
+RETURNING *;
+
+-- name: DeleteProductSupplier :one
+DELETE
+FROM product_supplier
+WHERE product_id = $1
+  AND supplier_id = $2
+RETURNING *;
@@ -0,0 +1,78 @@
+-- name: CancelProformaForOrder :exec
+UPDATE proforma_invoice
+SET status = 'CANCELLED'
+WHERE order_id = $1
+  AND status = 'UNPAID';
+
+-- name: CreateProformaInvoice :one
+INSERT INTO proforma_invoice (order_id, due_date, net_amount, vat_amount, total_amount, buyer_name, buyer_tax_id,
+                              billing_address_line, billing_city, billing_postal_code, billing_country)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetOrderPrepaymentStatus :one
+-- Whether the order's company has to pay up front, and how far its pro-forma
+-- has got if there is one.
+SELECT (c.requires_prepayment OR c.at_risk)::bool AS requires_prepayment,
+       p.status                                   AS proforma_status
+FROM orders o
+         JOIN customer_company c ON c.id = o.customer_id
+         LEFT JOIN proforma_invoice p ON p.order_id = o.id
+WHERE o.id = $1;
+
+-- name: GetProformaByOrderId :one
+SELECT p.id,
+       p.order_id,
+       p.proforma_number,
+       p.issue_date,
+       p.due_date,
+       p.net_amount::text   AS net_amount,
+       p.vat_amount::text   AS vat_amount,
+       p.total_amount::text AS total_amount,
+       p.status,
+       p.buyer_name,
+       p.buyer_tax_id,
+       p.billing_address_line,
+       p.billing_city,
+       p.billing_postal_code,
+       p.billing_country,
+       p.paid_at,
+       p.payment_reference,
+       p.invoice_id,
+       o.order_number,
+       o.customer_id,
+       c.main_email
+FROM proforma_invoice p
+         JOIN orders o ON o.id = p.order_id
+         JOIN customer_company c ON c.id = o.customer_id
+WHERE p.order_id = $1;
+
+-- name: GetProformaForUpdate :one
+SELECT id,
+       status,
+       total_amount::text AS total_amount
+FROM proforma_invoice
+WHERE order_id = $1
+    FOR UPDATE;
+
+-- name: MarkProformaPaid :exec
+UPDATE proforma_invoice
+SET status            = 'PAID',
+    paid_at           = $2,
+    payment_reference = $3,
+    invoice_id        = $4
+WHERE id = $1;
+
+-- name: ProformaExistsForOrder :one
+SELECT EXISTS (SELECT 1
+               FROM proforma_invoice
+               WHERE order_id = $1);
+
+-- name: UpdateProformaAmounts :exec
+-- Only an unpaid pro-forma follows changes to its order.
+UPDATE proforma_invoice
+SET net_amount   = $2,
+    vat_amount   = $3,
+    total_amount = $4
+WHERE order_id = $1
+  AND status = 'UNPAID';
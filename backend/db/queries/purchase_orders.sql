@@ -0,0 +1,89 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_order (supplier_id, warehouse_id, expected_delivery_date, over_delivery_tolerance_pct,
+                            under_delivery_tolerance_pct, note, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: CreatePurchaseOrderLine :one
+INSERT INTO purchase_order_line (purchase_order_id, product_id, ordered_quantity, unit_price, expected_delivery_date)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetPurchaseOrderById :one
+SELECT po.id,
+       po.order_number,
+       po.supplier_id,
+       s.name AS supplier_name,
+       po.warehouse_id,
+       w.name AS warehouse_name,
+       po.status,
+       po.order_date,
+       po.expected_delivery_date,
+       po.over_delivery_tolerance_pct,
+       po.under_delivery_tolerance_pct,
+       po.note,
+       po.created_by
+FROM purchase_order po
+         JOIN supplier s ON s.id = po.supplier_id
+         JOIN warehouse w ON w.id = po.warehouse_id
+WHERE po.id = $1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT *
+FROM purchase_order
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT po.id,
+       po.order_number,
+       po.supplier_id,
+       s.name AS supplier_name,
+       po.warehouse_id,
+       w.name AS warehouse_name,
+       po.status,
+       po.order_date,
+       po.expected_delivery_date,
+       po.over_delivery_tolerance_pct,
+       po.under_delivery_tolerance_pct,
+       po.note,
+       po.created_by
+FROM purchase_order po
+         JOIN supplier s ON s.id = po.supplier_id
+         JOIN warehouse w ON w.id = po.warehouse_id
+WHERE (sqlc.narg(status)::purchase_order_status IS NULL OR po.status = sqlc.narg(status)::purchase_order_status)
+  AND (sqlc.narg(supplier_id)::int4 IS NULL OR po.supplier_id = sqlc.narg(supplier_id)::int4)
+  AND (sqlc.narg(warehouse_id)::int4 IS NULL OR po.warehouse_id = sqlc.narg(warehouse_id)::int4)
+ORDER BY po.order_date DESC, po.id DESC;
+
+-- name: ListPurchaseOrderLines :many
+SELECT l.id,
+       l.product_id,
+       p.name                AS product_name,
+       p.unit,
+       l.ordered_quantity,
+       l.received_quantity,
+       l.unit_price::text    AS unit_price_text,
+       l.expected_delivery_date
+FROM purchase_order_line l
+         JOIN product p ON p.id = l.product_id
+WHERE l.purchase_order_id = $1
+ORDER BY l.id;
+
+-- name: ListPurchaseOrderLinesForUpdate :many
+SELECT *
+FROM purchase_order_line
+WHERE purchase_order_id = $1
+ORDER BY id
+    FOR UPDATE;
+
+-- name: UpdatePurchaseOrderLineReceived :exec
+UPDATE purchase_order_line
+SET received_quantity = $2
+WHERE id = $1;
+
+-- name: UpdatePurchaseOrderStatus :one
+UPDATE purchase_order
+SET status = $2
+WHERE id = $1
+RETURNING *;
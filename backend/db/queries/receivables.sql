@@ -0,0 +1,44 @@
+-- name: GetLedgerBalanceBefore :one
+SELECT coalesce(sum(amount), 0)::text AS balance
+FROM customer_ledger
+WHERE customer_id = sqlc.arg(customer_id)::int
+  AND entry_date < sqlc.arg(before)::date;
+
+-- name: ListLedgerEntries :many
+-- Entries on the same day keep invoices ahead of the corrections and payments
+-- that refer to them.
+SELECT invoice_id,
+       document_type,
+       document_number,
+       entry_date,
+       amount::text AS amount
+FROM customer_ledger
+WHERE customer_id = sqlc.arg(customer_id)::int
+  AND (sqlc.narg(from_date)::date IS NULL OR entry_date >= sqlc.narg(from_date)::date)
+  AND entry_date < sqlc.arg(to_date)::date + 1
+ORDER BY entry_date::date,
+         CASE document_type WHEN 'INVOICE' THEN 0 WHEN 'CORRECTION' THEN 1 ELSE 2 END,
+         entry_date;
+
+-- name: ListReceivablesAging :many
+-- Splits what each company owes on open invoices by how many days past the
+-- due date it is. Companies that owe nothing are left out.
+SELECT c.id,
+       c.name,
+       c.tax_id,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue <= 0), 0)::text                         AS not_due,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue BETWEEN 1 AND 30), 0)::text             AS overdue_1_30,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue BETWEEN 31 AND 60), 0)::text            AS overdue_31_60,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue BETWEEN 61 AND 90), 0)::text            AS overdue_61_90,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue > 90), 0)::text                         AS overdue_90_plus,
+       sum(d.outstanding)::text                                                                        AS total
+FROM customer_company c
+         JOIN (SELECT b.customer_id,
+                      greatest(b.amount_due - b.amount_paid, 0) AS outstanding,
+                      now()::date - i.due_date::date              AS days_overdue
+               FROM invoice_balance b
+                        JOIN invoice i ON i.id = b.invoice_id
+               WHERE i.status IN ('UNPAID', 'PARTIALLY_PAID', 'OVERDUE')) d ON d.customer_id = c.id
+GROUP BY c.id
+HAVING sum(d.outstanding) > 0
+ORDER BY c.name;
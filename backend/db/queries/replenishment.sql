@@ -0,0 +1,36 @@
+-- name: ListReplenishmentCandidates :many
+SELECT s.warehouse_id,
+       w.name                                                            AS warehouse_name,
+       s.product_id,
+       p.name                                                            AS product_name,
+       p.unit,
+       s.quantity,
+       s.reserved_quantity,
+       s.min_quantity,
+       coalesce((SELECT sum(l.ordered_quantity - l.received_quantity)
+                 FROM purchase_order_line l
+                          JOIN purchase_order po ON po.id = l.purchase_order_id
+                 WHERE po.warehouse_id = s.warehouse_id
+                   AND l.product_id = s.product_id
+                   AND po.status IN ('DRAFT', 'SENT', 'PARTIALLY_RECEIVED')
+                   AND l.received_quantity < l.ordered_quantity), 0)::int4 AS on_order_quantity,
+       coalesce((SELECT -sum(m.quantity_change)
+                 FROM stock_movement m
+                 WHERE m.warehouse_id = s.warehouse_id
+                   AND m.product_id = s.product_id
+                   AND m.movement_type = 'DISPATCH'
+                   AND m.created_at >= sqlc.arg(since)::timestamptz), 0)::int4 AS dispatched_quantity,
+       ps.supplier_id,
+       sp.name                                                           AS supplier_name,
+       ps.lead_time_days,
+       ps.min_order_quantity,
+       ps.unit_price::text                                               AS unit_price_text
+FROM stock s
+         JOIN product p ON p.id = s.product_id
+         JOIN warehouse w ON w.id = s.warehouse_id
+         LEFT JOIN product_supplier ps ON ps.product_id = s.product_id
+         LEFT JOIN supplier sp ON sp.id = ps.supplier_id
+WHERE p.is_active
+  AND w.is_active
+  AND (sqlc.narg(warehouse_id)::int4 IS NULL OR s.warehouse_id = sqlc.narg(warehouse_id)::int4)
+ORDER BY w.name, p.name;
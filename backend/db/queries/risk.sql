@@ -0,0 +1,57 @@
+-- name: CreateRiskScoreEntry :exec
+INSERT INTO company_risk_score (customer_id, source, score, at_risk, status_changed, reasons, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListCompanyRiskInputs :many
+-- Everything the risk score is computed from, for one company or for all of
+-- them when no id is given.
+SELECT c.id,
+       c.at_risk,
+       c.at_risk_override,
+       c.risk_score,
+       c.credit_limit::text                                                                         AS credit_limit,
+       count(d.invoice_id)::int4                                                                    AS open_invoices,
+       count(d.invoice_id) FILTER (WHERE d.days_overdue > 0)::int4                                  AS overdue_invoices,
+       coalesce(sum(d.outstanding), 0)::text                                                        AS outstanding,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue BETWEEN 1 AND 30), 0)::text         AS overdue_1_30,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue BETWEEN 31 AND 60), 0)::text        AS overdue_31_60,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue BETWEEN 61 AND 90), 0)::text        AS overdue_61_90,
+       coalesce(sum(d.outstanding) FILTER (WHERE d.days_overdue > 90), 0)::text                     AS overdue_90_plus,
+       coalesce(max(d.days_overdue), 0)::int4                                                       AS max_days_overdue,
+       (SELECT coalesce(round(avg(greatest(p.payment_date - i.due_date::date, 0))), 0)
+        FROM payment_allocation a
+                 JOIN payment p ON p.id = a.payment_id
+                 JOIN invoice i ON i.id = a.invoice_id
+        WHERE p.customer_id = c.id
+          AND p.payment_date >= now()::date - 90)::int4                                             AS average_payment_delay
+FROM customer_company c
+         LEFT JOIN (SELECT b.invoice_id,
+                           b.customer_id,
+                           greatest(b.amount_due - b.amount_paid, 0) AS outstanding,
+                           now()::date - i.due_date::date              AS days_overdue
+                    FROM invoice_balance b
+                             JOIN invoice i ON i.id = b.invoice_id
+                    WHERE i.status IN ('UNPAID', 'PARTIALLY_PAID', 'OVERDUE')) d ON d.customer_id = c.id
+WHERE sqlc.narg(customer_id)::int IS NULL
+   OR c.id = sqlc.narg(customer_id)::int
+GROUP BY c.id
+ORDER BY c.id;
+
+-- name: ListRiskScoreHistory :many
+SELECT *
+FROM company_risk_score
+WHERE customer_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: SetCompanyRiskOverride :exec
+-- A NULL override hands the company back to the scoring job.
+UPDATE customer_company
+SET at_risk_override = sqlc.narg(at_risk_override)::boolean,
+    at_risk          = coalesce(sqlc.narg(at_risk_override)::boolean, at_risk)
+WHERE id = sqlc.arg(id)::int;
+
+-- name: SetCompanyRiskScore :exec
+UPDATE customer_company
+SET risk_score = $2,
+    at_risk    = $3
+WHERE id = $1;
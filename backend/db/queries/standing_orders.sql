@@ -0,0 +1,141 @@
+-- name: CreateStandingOrder :one
+INSERT INTO standing_order (customer_id, name, address_id, warehouse_id, weekdays, start_date, end_date, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateStandingOrder :one
+UPDATE standing_order
+SET name         = $3,
+    address_id   = $4,
+    warehouse_id = $5,
+    weekdays     = $6,
+    start_date   = $7,
+    end_date     = $8
+WHERE id = $1
+  AND customer_id = $2
+RETURNING *;
+
+-- name: SetStandingOrderPaused :execrows
+UPDATE standing_order
+SET is_paused = $3
+WHERE id = $1
+  AND customer_id = $2;
+
+-- name: GetStandingOrder :one
+SELECT *
+FROM standing_order
+WHERE id = $1
+  AND customer_id = $2;
+
+-- name: ListStandingOrders :many
+SELECT *
+FROM standing_order
+WHERE customer_id = $1
+ORDER BY id;
+
+-- name: ListDueStandingOrders :many
+-- Templates that are running and can deliver between the two dates.
+SELECT *
+FROM standing_order
+WHERE NOT is_paused
+  AND start_date <= sqlc.arg(to_date)::date
+  AND (end_date IS NULL OR end_date >= sqlc.arg(from_date)::date)
+ORDER BY id;
+
+-- name: CreateStandingOrderLine :exec
+INSERT INTO standing_order_line (standing_order_id, product_id, quantity)
+VALUES ($1, $2, $3);
+
+-- name: DeleteStandingOrderLines :exec
+DELETE
+FROM standing_order_line
+WHERE standing_order_id = $1;
+
+-- name: ListStandingOrderLines :many
+SELECT l.product_id,
+       p.name AS product_name,
+       l.quantity
+FROM standing_order_line l
+         JOIN product p ON p.id = l.product_id
+WHERE l.standing_order_id = $1
+ORDER BY p.name;
+
+-- name: CreateStandingOrderSkip :exec
+INSERT INTO standing_order_skip (standing_order_id, skip_date)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteStandingOrderSkips :exec
+DELETE
+FROM standing_order_skip
+WHERE standing_order_id = $1;
+
+-- name: ListStandingOrderSkips :many
+SELECT skip_date
+FROM standing_order_skip
+WHERE standing_order_id = $1
+ORDER BY skip_date;
+
+-- name: ListStandingOrderOccurrences :many
+SELECT *
+FROM standing_order_occurrence
+WHERE standing_order_id = sqlc.arg(standing_order_id)::int
+  AND delivery_date BETWEEN sqlc.arg(from_date)::date AND sqlc.arg(to_date)::date
+ORDER BY delivery_date;
+
+-- name: ListStandingOrderOccurrenceLines :many
+SELECT l.occurrence_id,
+       l.product_id,
+       p.name AS product_name,
+       l.quantity
+FROM standing_order_occurrence_line l
+         JOIN standing_order_occurrence o ON o.id = l.occurrence_id
+         JOIN product p ON p.id = l.product_id
+WHERE o.standing_order_id = sqlc.arg(standing_order_id)::int
+  AND o.delivery_date BETWEEN sqlc.arg(from_date)::date AND sqlc.arg(to_date)::date
+ORDER BY l.occurrence_id, p.name;
+
+-- name: SetStandingOrderOccurrence :one
+-- Changes or skips one delivery date. Dates the scheduler has already
+-- processed are left alone and return no row.
+INSERT INTO standing_order_occurrence (standing_order_id, delivery_date, status, custom_lines)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (standing_order_id, delivery_date) DO UPDATE
+    SET status       = excluded.status,
+        custom_lines = excluded.custom_lines
+WHERE standing_order_occurrence.status IN ('PENDING', 'SKIPPED')
+RETURNING id;
+
+-- name: DeleteStandingOrderOccurrence :execrows
+DELETE
+FROM standing_order_occurrence
+WHERE standing_order_id = $1
+  AND delivery_date = $2
+  AND status IN ('PENDING', 'SKIPPED');
+
+-- name: CreateStandingOrderOccurrenceLine :exec
+INSERT INTO standing_order_occurrence_line (occurrence_id, product_id, quantity)
+VALUES ($1, $2, $3);
+
+-- name: DeleteStandingOrderOccurrenceLines :exec
+DELETE
+FROM standing_order_occurrence_line
+WHERE occurrence_id = $1;
+
+-- name: LockStandingOrderOccurrence :one
+-- Returns the delivery date's occurrence, creating it if needed, and locks
+-- it so the date is processed once.
+INSERT INTO standing_order_occurrence (standing_order_id, delivery_date)
+VALUES ($1, $2)
+ON CONFLICT (standing_order_id, delivery_date) DO UPDATE
+    SET status = standing_order_occurrence.status
+RETURNING id, status, custom_lines;
+
+-- name: RecordStandingOrderOccurrence :exec
+INSERT INTO standing_order_occurrence (standing_order_id, delivery_date, status, order_id, failure_reason, processed_at)
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (standing_order_id, delivery_date) DO UPDATE
+    SET status         = excluded.status,
+        order_id       = excluded.order_id,
+        failure_reason = excluded.failure_reason,
+        processed_at   = excluded.processed_at;
@@ -0,0 +1,73 @@
+-- name: CreateStockLot :one
+INSERT INTO stock_lot (warehouse_id, product_id, batch_number, production_date, best_before, quantity)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetStockLotForUpdate :one
+SELECT *
+FROM stock_lot
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: GetStockLotByBatchForUpdate :one
+SELECT *
+FROM stock_lot
+WHERE warehouse_id = $1
+  AND product_id = $2
+  AND batch_number = $3
+    FOR UPDATE;
+
+-- name: UpdateStockLotQuantity :exec
+UPDATE stock_lot
+SET quantity = $2
+WHERE id = $1;
+
+-- name: ListStockLotsByProduct :many
+SELECT *
+FROM stock_lot
+WHERE warehouse_id = $1
+  AND product_id = $2
+  AND quantity > 0
+ORDER BY best_before, id;
+
+-- name: ListStockLotsForAllocation :many
+SELECT *
+FROM stock_lot
+WHERE warehouse_id = $1
+  AND product_id = $2
+  AND quantity > 0
+ORDER BY best_before, id
+    FOR UPDATE;
+
+-- name: CreateStockMovementLot :one
+INSERT INTO stock_movement_lot (movement_id, lot_id, quantity_change)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListExpiredStockLots :many
+SELECT *
+FROM stock_lot
+WHERE quantity > 0
+  AND best_before < current_date
+ORDER BY best_before, id;
+
+-- name: ListExpiringStockLots :many
+SELECT l.id,
+       l.warehouse_id,
+       w.name                               AS warehouse_name,
+       l.product_id,
+       p.name                               AS product_name,
+       p.category,
+       l.batch_number,
+       l.production_date,
+       l.best_before,
+       l.quantity,
+       (l.best_before - current_date)::int4 AS days_left
+FROM stock_lot l
+         JOIN product p ON p.id = l.product_id
+         JOIN warehouse w ON w.id = l.warehouse_id
+WHERE l.quantity > 0
+  AND l.best_before >= current_date
+  AND l.best_before <= current_date + sqlc.arg(days)::int4
+  AND (sqlc.narg(warehouse_id)::int4 IS NULL OR l.warehouse_id = sqlc.narg(warehouse_id)::int4)
+ORDER BY l.best_before, p.name;
@@ -1,27 +1,69 @@
 -- name: ListStockMovements :many
 SELECT *
 FROM stock_movement
+WHERE sqlc.narg(warehouse_id)::int4 IS NULL
+   OR warehouse_id = sqlc.narg(warehouse_id)::int4
 ORDER BY created_at DESC;
 
+-- name: GetStockMovementById :one
+SELECT *
+FROM stock_movement
+WHERE id = $1;
+
 -- name: GetStockForUpdate :one
 SELECT *
 FROM stock
-WHERE product_id = $1
+WHERE warehouse_id = $1
+  AND product_id = $2
     FOR UPDATE;
 
 -- name: UpdateStockQuantity :exec
 UPDATE stock
 SET quantity = $2
-WHERE product_id = $1;
+WHERE id = $1;
+
+-- name: UpdateStockReservedQuantity :exec
+UPDATE stock
+SET reserved_quantity = $2
+WHERE id = $1;
 
 -- name: CreateStockMovement :one
 INSERT INTO stock_movement (
+    warehouse_id,
     product_id,
     quantity_change,
     movement_type,
     related_order_id,
+    related_movement_id,
     reason,
-    employee_id
+    employee_id,
+    override_reason,
+    document_id,
+    purchase_order_line_id
 )
-VALUES ($1, $2, $3, $4, $5, $6)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 RETURNING *;
+
+-- name: GetOrderReturnableQuantity :one
+-- Locks the order and sums how much of the product it ordered, had
+-- dispatched and has had returned so far.
+WITH locked_order AS (SELECT id
+                      FROM orders
+                      WHERE id = $1
+                          FOR UPDATE)
+SELECT o.id AS order_id,
+       COALESCE((SELECT SUM(oi.quantity)
+                 FROM order_item oi
+                 WHERE oi.order_id = o.id
+                   AND oi.product_id = $2), 0)::int AS ordered,
+       COALESCE((SELECT SUM(-sm.quantity_change)
+                 FROM stock_movement sm
+                 WHERE sm.related_order_id = o.id
+                   AND sm.product_id = $2
+                   AND sm.movement_type = 'DISPATCH'), 0)::int AS dispatched,
+       COALESCE((SELECT SUM(sm.quantity_change)
+                 FROM stock_movement sm
+                 WHERE sm.related_order_id = o.id
+                   AND sm.product_id = $2
+                   AND sm.movement_type = 'RETURN'), 0)::int AS returned
+FROM locked_order o;
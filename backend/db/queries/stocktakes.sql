@@ -0,0 +1,80 @@
+-- name: CreateStocktake :one
+INSERT INTO stocktake (warehouse_id, note, opened_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetStocktakeById :one
+SELECT *
+FROM stocktake
+WHERE id = $1;
+
+-- name: GetStocktakeForUpdate :one
+SELECT *
+FROM stocktake
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: GetOpenStocktakeByWarehouse :one
+SELECT *
+FROM stocktake
+WHERE warehouse_id = $1
+  AND status = 'OPEN';
+
+-- name: ListStocktakes :many
+SELECT *
+FROM stocktake
+WHERE sqlc.narg(warehouse_id)::int4 IS NULL
+   OR warehouse_id = sqlc.narg(warehouse_id)::int4
+ORDER BY opened_at DESC;
+
+-- name: FinishStocktake :one
+UPDATE stocktake
+SET status    = $2,
+    closed_by = $3,
+    closed_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateStocktakeCount :one
+INSERT INTO stocktake_count (stocktake_id, product_id, lot_id, counted_quantity, counted_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateStocktakeCount :exec
+UPDATE stocktake_count
+SET counted_quantity = $2,
+    counted_by       = $3,
+    counted_at       = now()
+WHERE id = $1;
+
+-- name: SetStocktakeCountExpected :exec
+UPDATE stocktake_count
+SET expected_quantity = $2
+WHERE id = $1;
+
+-- name: ListStocktakeCountsByProduct :many
+SELECT *
+FROM stocktake_count
+WHERE stocktake_id = $1
+  AND product_id = $2;
+
+-- name: ListStocktakeCounts :many
+SELECT c.id,
+       c.product_id,
+       p.name                              AS product_name,
+       c.lot_id,
+       l.batch_number,
+       l.best_before,
+       c.counted_quantity,
+       coalesce(c.expected_quantity, CASE
+                                         WHEN c.lot_id IS NULL THEN coalesce(s.quantity, 0)
+                                         ELSE l.quantity END)::int4 AS expected_quantity,
+       c.counted_by,
+       c.counted_at
+FROM stocktake_count c
+         JOIN stocktake t ON t.id = c.stocktake_id
+         JOIN product p ON p.id = c.product_id
+         LEFT JOIN stock_lot l ON l.id = c.lot_id
+         LEFT JOIN stock s ON s.warehouse_id = t.warehouse_id AND s.product_id = c.product_id
+WHERE c.stocktake_id = $1
+ORDER BY p.name, l.best_before NULLS FIRST, c.id;
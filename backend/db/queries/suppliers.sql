@@ -0,0 +1,38 @@
+-- name: ListSuppliers :many
+SELECT *
+FROM supplier
+ORDER BY name;
+
+-- name: GetSupplierById :one
+SELECT *
+FROM supplier
+WHERE id = $1;
+
+-- name: CreateSupplier :one
+INSERT INTO supplier (name, tax_id, email, phone, address, over_delivery_tolerance_pct, under_delivery_tolerance_pct)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: UpdateSupplier :one
+UPDATE supplier
+SET name                         = COALESCE(sqlc.narg(name), name),
+    tax_id                       = COALESCE(sqlc.narg(tax_id), tax_id),
+    email                        = COALESCE(sqlc.narg(email), email),
+    phone                        = COALESCE(sqlc.narg(phone), phone),
+    address                      = COALESCE(sqlc.narg(address), address),
+    over_delivery_tolerance_pct  = COALESCE(sqlc.narg(over_delivery_tolerance_pct), over_delivery_tolerance_pct),
+    under_delivery_tolerance_pct = COALESCE(sqlc.narg(under_delivery_tolerance_pct), under_delivery_tolerance_pct)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ActivateSupplier :one
+UPDATE supplier
+SET is_active = true
+WHERE id = $1
+RETURNING *;
+
+-- name: DeactivateSupplier :one
+UPDATE supplier
+SET is_active = false
+WHERE id = $1
+RETURNING *;
@@ -1,38 +1,50 @@
 -- name: ListStock :many
-SELECT s.product_id,
+SELECT s.warehouse_id,
+       w.name                                                                   AS warehouse_name,
+       s.product_id,
        p.name                                                                   AS product_name,
        p.category,
        s.quantity,
+       s.reserved_quantity,
        s.min_quantity,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'LOSS'), 0)::int4   AS damaged_count,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'RETURN'), 0)::int4 AS return_count
 FROM stock s
          JOIN product p ON p.id = s.product_id
-         LEFT JOIN stock_movement m ON m.product_id = p.id
-GROUP BY s.product_id, p.name, p.category, s.quantity, s.min_quantity
-ORDER BY p.name;
+         JOIN warehouse w ON w.id = s.warehouse_id
+         LEFT JOIN stock_movement m ON m.product_id = s.product_id AND m.warehouse_id = s.warehouse_id
+WHERE sqlc.narg(warehouse_id)::int4 IS NULL
+   OR s.warehouse_id = sqlc.narg(warehouse_id)::int4
+GROUP BY s.warehouse_id, w.name, s.product_id, p.name, p.category, s.quantity, s.reserved_quantity, s.min_quantity
+ORDER BY p.name, w.name;
 
 -- name: GetStockByProductId :one
-SELECT s.product_id,
+SELECT s.warehouse_id,
+       w.name                                                                   AS warehouse_name,
+       s.product_id,
        p.name                                                                   AS product_name,
        s.quantity,
+       s.reserved_quantity,
        p.category,
        s.min_quantity,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'LOSS'), 0)::int4   AS damaged_count,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'RETURN'), 0)::int4 AS return_count
 FROM stock s
          JOIN product p ON p.id = s.product_id
-         LEFT JOIN stock_movement m ON m.product_id = p.id
-WHERE s.product_id = $1
-GROUP BY s.product_id, p.name, p.category, s.quantity, s.min_quantity;
+         JOIN warehouse w ON w.id = s.warehouse_id
+         LEFT JOIN stock_movement m ON m.product_id = s.product_id AND m.warehouse_id = s.warehouse_id
+WHERE s.warehouse_id = $1
+  AND s.product_id = $2
+GROUP BY s.warehouse_id, w.name, s.product_id, p.name, p.category, s.quantity, s.reserved_quantity, s.min_quantity;
 
 -- name: CreateStock :one
-INSERT INTO stock (product_id, quantity, min_quantity)
-VALUES ($1, $2, $3)
+INSERT INTO stock (warehouse_id, product_id, quantity, min_quantity)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: UpdateStockByProductId :one
 UPDATE stock
 SET min_quantity = $1
-WHERE product_id = $2
+WHERE warehouse_id = $2
+  AND product_id = $3
 RETURNING *;
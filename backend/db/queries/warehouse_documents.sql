@@ -0,0 +1,70 @@
+-- name: CreateWarehouseDocument :one
+INSERT INTO warehouse_document (document_type, warehouse_id, supplier_name, related_order_id, note, employee_id,
+                                purchase_order_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetWarehouseDocumentById :one
+SELECT d.id,
+       d.document_type,
+       d.document_number,
+       d.warehouse_id,
+       w.name AS warehouse_name,
+       d.issue_date,
+       d.supplier_name,
+       d.related_order_id,
+       o.order_number,
+       d.note,
+       d.employee_id,
+       d.purchase_order_id,
+       po.order_number AS purchase_order_number
+FROM warehouse_document d
+         JOIN warehouse w ON w.id = d.warehouse_id
+         LEFT JOIN orders o ON o.id = d.related_order_id
+         LEFT JOIN purchase_order po ON po.id = d.purchase_order_id
+WHERE d.id = $1;
+
+-- name: ListWarehouseDocuments :many
+SELECT d.id,
+       d.document_type,
+       d.document_number,
+       d.warehouse_id,
+       w.name AS warehouse_name,
+       d.issue_date,
+       d.supplier_name,
+       d.related_order_id,
+       o.order_number,
+       d.note,
+       d.employee_id,
+       d.purchase_order_id,
+       po.order_number AS purchase_order_number
+FROM warehouse_document d
+         JOIN warehouse w ON w.id = d.warehouse_id
+         LEFT JOIN orders o ON o.id = d.related_order_id
+         LEFT JOIN purchase_order po ON po.id = d.purchase_order_id
+WHERE (sqlc.narg(document_type)::warehouse_document_type IS NULL OR d.document_type = sqlc.narg(document_type)::warehouse_document_type)
+  AND (sqlc.narg(warehouse_id)::int4 IS NULL OR d.warehouse_id = sqlc.narg(warehouse_id)::int4)
+ORDER BY d.issue_date DESC, d.id DESC;
+
+-- name: ListWarehouseDocumentLines :many
+SELECT m.id                           AS movement_id,
+       m.product_id,
+       p.name                         AS product_name,
+       p.unit,
+       l.id                           AS lot_id,
+       l.batch_number,
+       l.best_before,
+       abs(ml.quantity_change)::int4 AS quantity
+FROM stock_movement m
+         JOIN product p ON p.id = m.product_id
+         JOIN stock_movement_lot ml ON ml.movement_id = m.id
+         JOIN stock_lot l ON l.id = ml.lot_id
+WHERE m.document_id = $1
+ORDER BY m.id, l.best_before, l.id;
+
+-- name: AttachOrderDispatchesToDocument :exec
+UPDATE stock_movement
+SET document_id = $1
+WHERE related_order_id = $2
+  AND movement_type = 'DISPATCH'
+  AND document_id IS NULL;
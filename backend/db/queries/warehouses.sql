@@ -0,0 +1,29 @@
+-- name: ListWarehouses :many
+SELECT *
+FROM warehouse
+ORDER BY name;
+
+-- name: GetWarehouseById :one
+SELECT *
+FROM warehouse
+WHERE id = $1;
+
+-- name: CreateWarehouse :one
+INSERT INTO warehouse (code, name, address)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateWarehouse :one
+UPDATE warehouse
+SET code      = coalesce(sqlc.narg(code), code),
+    name      = coalesce(sqlc.narg(name), name),
+    address   = coalesce(sqlc.narg(address), address),
+    is_active = coalesce(sqlc.narg(is_active), is_active)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: GetWarehouseIdForUserId :one
+SELECT e.warehouse_id
+FROM user_account u
+         JOIN employee e ON e.id = u.employee_id
+WHERE u.id = $1;
@@ -26,6 +26,26 @@ type CompanyWithDetails struct {
 	RegistrationDate time.Time          `json:"registrationDate"`
 	TotalOrdersValue decimal.Decimal    `json:"totalOrdersValue"`
 	CompletedOrders  int64              `json:"completedOrders"`
+	// RequiresPrepayment means orders wait for a paid pro-forma before they
+	// are prepared.
+	RequiresPrepayment bool `json:"requiresPrepayment"`
+	// Balance is what the company still owes on its invoices, corrections
+	// and partial payments included.
+	Balance decimal.Decimal `json:"balance"`
+	// Credit is the part of the company's payments not allocated to any
+	// invoice, which can still settle its other invoices.
+	Credit decimal.Decimal `json:"credit"`
+	// CreditLimit caps Exposure; without one the company can order any
+	// amount.
+	CreditLimit       *decimal.Decimal       `json:"creditLimit"`
+	PaymentTermsDays  int32                  `json:"paymentTermsDays"`
+	CreditLimitAction sqlc.CreditLimitAction `json:"creditLimitAction"`
+	// Exposure is the balance plus the value of orders not invoiced yet.
+	Exposure decimal.Decimal `json:"exposure"`
+	// RiskScore is the latest nightly score out of 100. AtRiskOverride is
+	// set when an admin has pinned the status regardless of the score.
+	RiskScore      *int32 `json:"riskScore"`
+	AtRiskOverride *bool  `json:"atRiskOverride"`
 }
 
 type Company struct {
@@ -44,8 +64,38 @@ type ListCompaniesResponse struct {
 }
 
 type UpdateCompanyRequest struct {
-	Name        *string `json:"name" validate:"max=200"`
-	TaxId       *string `json:"taxId" validate:"max=20"`
-	MainEmail   *string `json:"mainEmail" validate:"email,max=200"`
-	PhoneNumber *string `json:"phoneNumber" validate:"e164"`
+	Name               *string `json:"name" validate:"max=200"`
+	TaxId              *string `json:"taxId" validate:"max=20"`
+	MainEmail          *string `json:"mainEmail" validate:"email,max=200"`
+	PhoneNumber        *string `json:"phoneNumber" validate:"e164"`
+	RequiresPrepayment *bool   `json:"requiresPrepayment"`
+}
+
+// UpdateCreditPolicyRequest replaces the whole credit policy; a null
+// CreditLimit removes the limit.
+type UpdateCreditPolicyRequest struct {
+	CreditLimit       *decimal.Decimal       `json:"creditLimit"`
+	PaymentTermsDays  int32                  `json:"paymentTermsDays" validate:"gte=0,lte=365"`
+	CreditLimitAction sqlc.CreditLimitAction `json:"creditLimitAction" validate:"required,oneof=REJECT HOLD"`
+}
+
+// SetRiskOverrideRequest pins the at-risk status; a null AtRisk hands the
+// company back to the nightly scoring.
+type SetRiskOverrideRequest struct {
+	AtRisk *bool  `json:"atRisk"`
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type RiskScoreEntry struct {
+	Source        sqlc.RiskScoreSource `json:"source"`
+	Score         *int32               `json:"score"`
+	AtRisk        bool                 `json:"atRisk"`
+	StatusChanged bool                 `json:"statusChanged"`
+	Reasons       []string             `json:"reasons"`
+	CreatedBy     *int32               `json:"createdBy"`
+	CreatedAt     time.Time            `json:"createdAt"`
+}
+
+type RiskHistoryResponse struct {
+	Entries []RiskScoreEntry `json:"entries"`
 }
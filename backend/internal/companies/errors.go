@@ -6,4 +6,5 @@ var (
 	ErrCompanyIdRequired = errors.New("company id is required")
 	ErrInvalidCompanyId  = errors.New("invalid company id")
 	ErrCompanyNotFound   = errors.New("company not found")
+	ErrNegativeLimit     = errors.New("credit limit cannot be negative")
 )
@@ -2,7 +2,9 @@ package companies
 
 import (
 	"errors"
+	app "mleczarnia/internal/http"
 	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
 	"net/http"
 	"strconv"
 
@@ -64,6 +66,66 @@ func (handler *Handler) UpdateCompany(writer http.ResponseWriter, request *http.
 	writer.WriteHeader(http.StatusNoContent)
 }
 
+func (handler *Handler) UpdateCreditPolicy(writer http.ResponseWriter, request *http.Request) {
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body UpdateCreditPolicyRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.UpdateCreditPolicy(request.Context(), companyId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) SetRiskOverride(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body SetRiskOverrideRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.SetRiskOverride(request.Context(), companyId, int32(claims.UserId), body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) GetRiskHistory(writer http.ResponseWriter, request *http.Request) {
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	entries, err := handler.service.GetRiskHistory(request.Context(), companyId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, RiskHistoryResponse{Entries: entries})
+}
+
 func (handler *Handler) ActivateCompany(writer http.ResponseWriter, request *http.Request) {
 	companyId, err := handler.extractCompanyId(request)
 	if err != nil {
@@ -120,6 +182,8 @@ func (handler *Handler) mapErrorToResponse(err error) (int, string) {
 		return http.StatusBadRequest, ErrCompanyIdRequired.Error()
 	case errors.Is(err, ErrInvalidCompanyId):
 		return http.StatusBadRequest, ErrInvalidCompanyId.Error()
+	case errors.Is(err, ErrNegativeLimit):
+		return http.StatusBadRequest, ErrNegativeLimit.Error()
 
 	case errors.Is(err, ErrCompanyNotFound):
 		return http.StatusNotFound, ErrCompanyNotFound.Error()
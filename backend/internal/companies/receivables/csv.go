@@ -0,0 +1,66 @@
+package receivables
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+)
+
+func StatementCSV(statement Statement) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{
+		{"date", "document_type", "document_number", "amount", "balance"},
+		{"", "OPENING_BALANCE", "", "", statement.OpeningBalance.StringFixed(2)},
+	}
+	for _, entry := range statement.Entries {
+		records = append(records, []string{
+			entry.Date.Format("2006-01-02"),
+			entry.DocumentType,
+			entry.DocumentNumber,
+			entry.Amount.StringFixed(2),
+			entry.Balance.StringFixed(2),
+		})
+	}
+	records = append(records, []string{"", "CLOSING_BALANCE", "", "", statement.ClosingBalance.StringFixed(2)})
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func AgingCSV(report AgingReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{
+		{"company_id", "company_name", "tax_id", "current", "1-30", "31-60", "61-90", "90+", "total"},
+	}
+	for _, company := range report.Companies {
+		records = append(records, append(
+			[]string{strconv.Itoa(int(company.CompanyId)), company.CompanyName, company.TaxId},
+			bucketValues(company.Buckets)...,
+		))
+	}
+	records = append(records, append([]string{"", "TOTAL", ""}, bucketValues(report.Totals)...))
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func bucketValues(buckets AgingBuckets) []string {
+	values := []decimal.Decimal{buckets.Current, buckets.Days1To30, buckets.Days31To60, buckets.Days61To90, buckets.Over90, buckets.Total}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = value.StringFixed(2)
+	}
+	return result
+}
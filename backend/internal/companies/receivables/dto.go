@@ -0,0 +1,53 @@
+package receivables
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Statement struct {
+	CompanyId   int32  `json:"companyId"`
+	CompanyName string `json:"companyName"`
+	TaxId       string `json:"taxId"`
+	// From is empty when the statement starts with the first entry.
+	From           *time.Time       `json:"from"`
+	To             time.Time        `json:"to"`
+	OpeningBalance decimal.Decimal  `json:"openingBalance"`
+	ClosingBalance decimal.Decimal  `json:"closingBalance"`
+	Entries        []StatementEntry `json:"entries"`
+}
+
+// StatementEntry is an invoice or correction, which adds to the balance, or
+// a payment, which is negative. Balance is the running balance after it.
+type StatementEntry struct {
+	Date           time.Time       `json:"date"`
+	DocumentType   string          `json:"documentType"`
+	DocumentNumber string          `json:"documentNumber"`
+	InvoiceId      *int32          `json:"invoiceId"`
+	Amount         decimal.Decimal `json:"amount"`
+	Balance        decimal.Decimal `json:"balance"`
+}
+
+type AgingReport struct {
+	AsOf      time.Time      `json:"asOf"`
+	Companies []CompanyAging `json:"companies"`
+	Totals    AgingBuckets   `json:"totals"`
+}
+
+type CompanyAging struct {
+	CompanyId   int32        `json:"companyId"`
+	CompanyName string       `json:"companyName"`
+	TaxId       string       `json:"taxId"`
+	Buckets     AgingBuckets `json:"buckets"`
+}
+
+// AgingBuckets splits outstanding amounts by days past the due date.
+type AgingBuckets struct {
+	Current    decimal.Decimal `json:"current"`
+	Days1To30  decimal.Decimal `json:"days1To30"`
+	Days31To60 decimal.Decimal `json:"days31To60"`
+	Days61To90 decimal.Decimal `json:"days61To90"`
+	Over90     decimal.Decimal `json:"over90"`
+	Total      decimal.Decimal `json:"total"`
+}
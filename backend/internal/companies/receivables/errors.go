@@ -0,0 +1,12 @@
+package receivables
+
+import "errors"
+
+var (
+	ErrCompanyIdRequired = errors.New("company id is required")
+	ErrInvalidCompanyId  = errors.New("invalid company id")
+	ErrCompanyNotFound   = errors.New("company not found")
+	ErrInvalidDate       = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidDateRange  = errors.New("from date is after to date")
+	ErrInvalidFormat     = errors.New("format must be json, csv or pdf")
+)
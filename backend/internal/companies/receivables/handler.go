@@ -0,0 +1,179 @@
+package receivables
+
+import (
+	"errors"
+	"fmt"
+	"mleczarnia/internal/httputil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetStatement serves the statement for ?from= and ?to= (YYYY-MM-DD, to
+// defaults to today) as JSON, or as a file with ?format=csv or ?format=pdf.
+func (handler *Handler) GetStatement(writer http.ResponseWriter, request *http.Request) {
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	format, err := extractFormat(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	from, err := parseDate(request.URL.Query().Get("from"))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	to := time.Now()
+	if toDate, err := parseDate(request.URL.Query().Get("to")); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	} else if toDate != nil {
+		to = *toDate
+	}
+
+	statement, err := handler.service.GetStatement(request.Context(), companyId, from, to)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%d-%s", companyId, to.Format("2006-01-02"))
+	switch format {
+	case "csv":
+		data, err := StatementCSV(*statement)
+		if err != nil {
+			handler.handleServiceError(writer, err)
+			return
+		}
+		writeFile(writer, "text/csv; charset=utf-8", "attachment; filename="+filename+".csv", data)
+	case "pdf":
+		data, err := GenerateStatementPDF(*statement)
+		if err != nil {
+			handler.handleServiceError(writer, err)
+			return
+		}
+		writeFile(writer, "application/pdf", "inline; filename="+filename+".pdf", data)
+	default:
+		httputil.WriteJSON(writer, http.StatusOK, statement)
+	}
+}
+
+func (handler *Handler) GetAgingReport(writer http.ResponseWriter, request *http.Request) {
+	format, err := extractFormat(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	report, err := handler.service.GetAgingReport(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	filename := "aging-" + report.AsOf.Format("2006-01-02")
+	switch format {
+	case "csv":
+		data, err := AgingCSV(*report)
+		if err != nil {
+			handler.handleServiceError(writer, err)
+			return
+		}
+		writeFile(writer, "text/csv; charset=utf-8", "attachment; filename="+filename+".csv", data)
+	case "pdf":
+		data, err := GenerateAgingPDF(*report)
+		if err != nil {
+			handler.handleServiceError(writer, err)
+			return
+		}
+		writeFile(writer, "application/pdf", "inline; filename="+filename+".pdf", data)
+	default:
+		httputil.WriteJSON(writer, http.StatusOK, report)
+	}
+}
+
+func writeFile(writer http.ResponseWriter, contentType, disposition string, data []byte) {
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Disposition", disposition)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(data)
+}
+
+func extractFormat(request *http.Request) (string, error) {
+	switch format := request.URL.Query().Get("format"); format {
+	case "", "json":
+		return "json", nil
+	case "csv", "pdf":
+		return format, nil
+	default:
+		return "", ErrInvalidFormat
+	}
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	return &date, nil
+}
+
+func (handler *Handler) extractCompanyId(request *http.Request) (int32, error) {
+	companyIdStr := chi.URLParam(request, "companyId")
+	if companyIdStr == "" {
+		return 0, ErrCompanyIdRequired
+	}
+
+	companyId, err := strconv.Atoi(companyIdStr)
+	if err != nil {
+		return 0, ErrInvalidCompanyId
+	}
+
+	return int32(companyId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrCompanyIdRequired),
+		errors.Is(err, ErrInvalidCompanyId),
+		errors.Is(err, ErrInvalidDate),
+		errors.Is(err, ErrInvalidDateRange),
+		errors.Is(err, ErrInvalidFormat):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, ErrCompanyNotFound):
+		return http.StatusNotFound, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
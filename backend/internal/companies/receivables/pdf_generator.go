@@ -0,0 +1,113 @@
+package receivables
+
+import (
+	"fmt"
+	"mleczarnia/internal/pdfutil"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+var documentTypeLabels = map[string]string{
+	"INVOICE":    "Faktura",
+	"CORRECTION": "Korekta",
+	"PAYMENT":    "Wpłata",
+}
+
+func GenerateStatementPDF(statement Statement) ([]byte, error) {
+	pdf := pdfutil.New()
+
+	pdf.SetFont("JuliaMono", "B", 18)
+	pdf.Cell(0, 10, "Wyciąg z konta klienta")
+	pdf.Ln(12)
+
+	pdf.SetFont("JuliaMono", "", 10)
+	pdf.Cell(0, 6, fmt.Sprintf("%s, NIP: %s", statement.CompanyName, statement.TaxId))
+	pdf.Ln(5)
+
+	period := fmt.Sprintf("Okres: do %s", statement.To.Format("2006-01-02"))
+	if statement.From != nil {
+		period = fmt.Sprintf("Okres: %s – %s", statement.From.Format("2006-01-02"), statement.To.Format("2006-01-02"))
+	}
+	pdf.Cell(0, 6, period)
+	pdf.Ln(10)
+
+	addStatementTable(pdf, statement)
+
+	return pdfutil.Output(pdf)
+}
+
+func addStatementTable(pdf *gofpdf.Fpdf, statement Statement) {
+	headers := []string{"Data", "Dokument", "Numer", "Kwota", "Saldo"}
+	widths := []float64{25, 25, 60, 35, 35}
+
+	pdf.SetFont("JuliaMono", "B", 8)
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("JuliaMono", "", 8)
+	pdf.CellFormat(widths[0]+widths[1]+widths[2]+widths[3], 6, "Saldo początkowe", "1", 0, "", false, 0, "")
+	pdf.CellFormat(widths[4], 6, statement.OpeningBalance.StringFixed(2), "1", 0, "R", false, 0, "")
+	pdf.Ln(-1)
+
+	for _, entry := range statement.Entries {
+		label, ok := documentTypeLabels[entry.DocumentType]
+		if !ok {
+			label = entry.DocumentType
+		}
+
+		pdf.CellFormat(widths[0], 6, entry.Date.Format("2006-01-02"), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 6, label, "1", 0, "", false, 0, "")
+		pdf.CellFormat(widths[2], 6, entry.DocumentNumber, "1", 0, "", false, 0, "")
+		pdf.CellFormat(widths[3], 6, entry.Amount.StringFixed(2), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 6, entry.Balance.StringFixed(2), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	pdf.SetFont("JuliaMono", "B", 8)
+	pdf.CellFormat(widths[0]+widths[1]+widths[2]+widths[3], 6, "Saldo końcowe", "1", 0, "", false, 0, "")
+	pdf.CellFormat(widths[4], 6, statement.ClosingBalance.StringFixed(2), "1", 0, "R", false, 0, "")
+	pdf.Ln(-1)
+}
+
+func GenerateAgingPDF(report AgingReport) ([]byte, error) {
+	pdf := pdfutil.New()
+
+	pdf.SetFont("JuliaMono", "B", 18)
+	pdf.Cell(0, 10, "Wiekowanie należności")
+	pdf.Ln(12)
+
+	pdf.SetFont("JuliaMono", "", 10)
+	pdf.Cell(0, 6, fmt.Sprintf("Stan na dzień: %s", report.AsOf.Format("2006-01-02")))
+	pdf.Ln(10)
+
+	headers := []string{"Kontrahent", "NIP", "Bieżące", "1–30", "31–60", "61–90", "90+", "Razem"}
+	widths := []float64{44, 22, 19, 19, 19, 19, 19, 19}
+
+	pdf.SetFont("JuliaMono", "B", 7)
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("JuliaMono", "", 7)
+	for _, company := range report.Companies {
+		pdf.CellFormat(widths[0], 6, company.CompanyName, "1", 0, "", false, 0, "")
+		pdf.CellFormat(widths[1], 6, company.TaxId, "1", 0, "C", false, 0, "")
+		addBucketCells(pdf, widths[2:], company.Buckets)
+	}
+
+	pdf.SetFont("JuliaMono", "B", 7)
+	pdf.CellFormat(widths[0]+widths[1], 6, "Razem", "1", 0, "", false, 0, "")
+	addBucketCells(pdf, widths[2:], report.Totals)
+
+	return pdfutil.Output(pdf)
+}
+
+func addBucketCells(pdf *gofpdf.Fpdf, widths []float64, buckets AgingBuckets) {
+	for i, value := range bucketValues(buckets) {
+		pdf.CellFormat(widths[i], 6, value, "1", 0, "R", false, 0, "")
+	}
+	pdf.Ln(-1)
+}
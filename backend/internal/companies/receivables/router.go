@@ -0,0 +1,29 @@
+package receivables
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StatementRouter is mounted under a company, so clients can read their own
+// statement.
+func StatementRouter(handler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+	router.Use(middleware.RequireRoleOrCompanyOwner("companyId", sqlc.RoleADMIN, sqlc.RoleSTAFF))
+
+	router.Get("/", handler.GetStatement)
+
+	return router
+}
+
+func AgingRouter(handler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+	router.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+
+	router.Get("/", handler.GetAgingReport)
+
+	return router
+}
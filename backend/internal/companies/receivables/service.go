@@ -0,0 +1,161 @@
+package receivables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type Service struct {
+	query *sqlc.Queries
+	pool  *pgxpool.Pool
+}
+
+func NewService(query *sqlc.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{query: query, pool: pool}
+}
+
+// GetStatement lists a company's ledger between two dates, both inclusive,
+// with a running balance. Without a from date the statement starts at the
+// first entry and the opening balance is zero.
+func (service *Service) GetStatement(ctx context.Context, companyId int32, from *time.Time, to time.Time) (*Statement, error) {
+	if from != nil && from.After(to) {
+		return nil, ErrInvalidDateRange
+	}
+
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*Statement, error) {
+		qtx := service.query.WithTx(tx)
+
+		company, err := qtx.GetCustomerCompanyById(ctx, companyId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrCompanyNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		statement := &Statement{
+			CompanyId:   company.ID,
+			CompanyName: company.Name,
+			TaxId:       company.TaxID,
+			From:        from,
+			To:          to,
+		}
+
+		if from != nil {
+			opening, err := qtx.GetLedgerBalanceBefore(ctx, sqlc.GetLedgerBalanceBeforeParams{
+				CustomerID: companyId,
+				Before:     pgtype.Date{Time: *from, Valid: true},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+
+			statement.OpeningBalance, err = decimal.NewFromString(opening)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rows, err := qtx.ListLedgerEntries(ctx, sqlc.ListLedgerEntriesParams{
+			CustomerID: companyId,
+			FromDate:   db.ConvertToDate(from),
+			ToDate:     pgtype.Date{Time: to, Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		balance := statement.OpeningBalance
+		statement.Entries = make([]StatementEntry, len(rows))
+		for i, row := range rows {
+			amount, err := decimal.NewFromString(row.Amount)
+			if err != nil {
+				return nil, err
+			}
+
+			balance = balance.Add(amount)
+			statement.Entries[i] = StatementEntry{
+				Date:           row.EntryDate.Time,
+				DocumentType:   row.DocumentType,
+				DocumentNumber: row.DocumentNumber,
+				Amount:         amount,
+				Balance:        balance,
+			}
+			if row.InvoiceID.Valid {
+				statement.Entries[i].InvoiceId = &row.InvoiceID.Int32
+			}
+		}
+		statement.ClosingBalance = balance
+
+		return statement, nil
+	})
+}
+
+// GetAgingReport splits what every company owes on open invoices into
+// buckets by days overdue, as of today.
+func (service *Service) GetAgingReport(ctx context.Context) (*AgingReport, error) {
+	rows, err := service.query.ListReceivablesAging(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	report := &AgingReport{
+		AsOf:      time.Now(),
+		Companies: make([]CompanyAging, len(rows)),
+	}
+
+	for i, row := range rows {
+		buckets, err := parseBuckets(row)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Companies[i] = CompanyAging{
+			CompanyId:   row.ID,
+			CompanyName: row.Name,
+			TaxId:       row.TaxID,
+			Buckets:     buckets,
+		}
+
+		report.Totals = AgingBuckets{
+			Current:    report.Totals.Current.Add(buckets.Current),
+			Days1To30:  report.Totals.Days1To30.Add(buckets.Days1To30),
+			Days31To60: report.Totals.Days31To60.Add(buckets.Days31To60),
+			Days61To90: report.Totals.Days61To90.Add(buckets.Days61To90),
+			Over90:     report.Totals.Over90.Add(buckets.Over90),
+			Total:      report.Totals.Total.Add(buckets.Total),
+		}
+	}
+
+	return report, nil
+}
+
+func parseBuckets(row sqlc.ListReceivablesAgingRow) (AgingBuckets, error) {
+	values := []string{row.NotDue, row.Overdue130, row.Overdue3160, row.Overdue6190, row.Overdue90Plus, row.Total}
+	parsed := make([]decimal.Decimal, len(values))
+	for i, value := range values {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return AgingBuckets{}, err
+		}
+		parsed[i] = amount
+	}
+
+	return AgingBuckets{
+		Current:    parsed[0],
+		Days1To30:  parsed[1],
+		Days31To60: parsed[2],
+		Days61To90: parsed[3],
+		Over90:     parsed[4],
+		Total:      parsed[5],
+	}, nil
+}
@@ -0,0 +1,296 @@
+package companies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// RiskPolicy decides when a company's risk score puts it at risk.
+type RiskPolicy struct {
+	// Threshold is the score, out of 100, from which a company is at risk.
+	Threshold int32
+	// OverdueDays puts a company at risk whatever its score once one of its
+	// invoices is more than this many days overdue.
+	OverdueDays int32
+}
+
+type riskAssessment struct {
+	score   int32
+	atRisk  bool
+	reasons []string
+}
+
+var (
+	hundred = decimal.NewFromInt(100)
+	half    = decimal.NewFromFloat(0.5)
+)
+
+// assess scores a company out of 100: up to 40 points for how far past due
+// its outstanding amount is, 20 for the share of open invoices that are
+// overdue, 20 for how late its payments of the last 90 days arrived and 20
+// for using more than half of its credit limit. creditUsage is nil for
+// companies without a limit.
+func (policy RiskPolicy) assess(row sqlc.ListCompanyRiskInputsRow, creditUsage *decimal.Decimal) (riskAssessment, error) {
+	values := []string{row.Outstanding, row.Overdue130, row.Overdue3160, row.Overdue6190, row.Overdue90Plus}
+	amounts := make([]decimal.Decimal, len(values))
+	for i, value := range values {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return riskAssessment{}, err
+		}
+		amounts[i] = amount
+	}
+	outstanding := amounts[0]
+
+	score := decimal.Zero
+	reasons := []string{}
+
+	overdue := amounts[1].Add(amounts[2]).Add(amounts[3]).Add(amounts[4])
+	if overdue.IsPositive() && outstanding.IsPositive() {
+		weighted := amounts[1].
+			Add(amounts[2].Mul(decimal.NewFromInt(2))).
+			Add(amounts[3].Mul(decimal.NewFromInt(3))).
+			Add(amounts[4].Mul(decimal.NewFromInt(4)))
+		score = score.Add(weighted.Div(outstanding.Mul(decimal.NewFromInt(4))).Mul(decimal.NewFromInt(40)))
+
+		overSixty := amounts[3].Add(amounts[4])
+		reasons = append(reasons, fmt.Sprintf("%s%% of the outstanding amount is overdue, %s%% by more than 60 days",
+			overdue.Div(outstanding).Mul(hundred).StringFixed(0),
+			overSixty.Div(outstanding).Mul(hundred).StringFixed(0)))
+	}
+
+	if row.OverdueInvoices > 0 && row.OpenInvoices > 0 {
+		share := decimal.NewFromInt32(row.OverdueInvoices).Div(decimal.NewFromInt32(row.OpenInvoices))
+		score = score.Add(share.Mul(decimal.NewFromInt(20)))
+		reasons = append(reasons, fmt.Sprintf("%d of %d open invoices are overdue", row.OverdueInvoices, row.OpenInvoices))
+	}
+
+	if row.AveragePaymentDelay > 0 {
+		delay := decimal.NewFromInt32(min(row.AveragePaymentDelay, 30))
+		score = score.Add(delay.Div(decimal.NewFromInt(30)).Mul(decimal.NewFromInt(20)))
+		reasons = append(reasons, fmt.Sprintf("payments of the last 90 days arrived %d days late on average", row.AveragePaymentDelay))
+	}
+
+	if creditUsage != nil && creditUsage.GreaterThan(half) {
+		usage := decimal.Min(*creditUsage, decimal.NewFromInt(1))
+		score = score.Add(usage.Sub(half).Mul(decimal.NewFromInt(40)))
+		reasons = append(reasons, fmt.Sprintf("%s%% of the credit limit is used", creditUsage.Mul(hundred).StringFixed(0)))
+	}
+
+	assessment := riskAssessment{
+		score:   int32(score.Round(0).IntPart()),
+		reasons: reasons,
+	}
+	assessment.atRisk = assessment.score >= policy.Threshold
+
+	if row.MaxDaysOverdue > policy.OverdueDays {
+		assessment.atRisk = true
+		assessment.reasons = append(assessment.reasons,
+			fmt.Sprintf("an invoice is %d days overdue, more than the %d allowed", row.MaxDaysOverdue, policy.OverdueDays))
+	}
+
+	return assessment, nil
+}
+
+// ScoreRisk recomputes the risk score of every company. at_risk follows the
+// score unless an admin has pinned it. The history gets a row whenever a
+// company's score or status changes. A company that fails is logged and does
+// not stop the others.
+func (service *Service) ScoreRisk(ctx context.Context) error {
+	rows, err := service.query.ListCompanyRiskInputs(ctx, pgtype.Int4{})
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	var errs []error
+	for _, row := range rows {
+		if err := db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+			return service.scoreCompany(ctx, service.query.WithTx(tx), row)
+		}); err != nil {
+			logrus.WithError(err).WithField("companyId", row.ID).Warn("Failed to score company risk")
+			errs = append(errs, fmt.Errorf("company %d: %w", row.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (service *Service) scoreCompany(ctx context.Context, qtx *sqlc.Queries, row sqlc.ListCompanyRiskInputsRow) error {
+	creditUsage, err := companyCreditUsage(ctx, qtx, row)
+	if err != nil {
+		return err
+	}
+
+	assessment, err := service.risk.assess(row, creditUsage)
+	if err != nil {
+		return err
+	}
+
+	atRisk := assessment.atRisk
+	reasons := assessment.reasons
+	if row.AtRiskOverride.Valid {
+		atRisk = row.AtRiskOverride.Bool
+		if atRisk != assessment.atRisk {
+			reasons = append(reasons, "status pinned by a manual override")
+		}
+	}
+
+	statusChanged := atRisk != row.AtRisk
+	if !statusChanged && row.RiskScore.Valid && row.RiskScore.Int32 == assessment.score {
+		return nil
+	}
+
+	score := pgtype.Int4{Int32: assessment.score, Valid: true}
+	if err := qtx.SetCompanyRiskScore(ctx, sqlc.SetCompanyRiskScoreParams{
+		ID:        row.ID,
+		RiskScore: score,
+		AtRisk:    atRisk,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if err := qtx.CreateRiskScoreEntry(ctx, sqlc.CreateRiskScoreEntryParams{
+		CustomerID:    row.ID,
+		Source:        sqlc.RiskScoreSourceSCORING,
+		Score:         score,
+		AtRisk:        atRisk,
+		StatusChanged: statusChanged,
+		Reasons:       reasons,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if statusChanged {
+		logrus.WithFields(logrus.Fields{
+			"companyId": row.ID,
+			"score":     assessment.score,
+			"atRisk":    atRisk,
+		}).Info("Company risk status changed")
+	}
+
+	return nil
+}
+
+// companyCreditUsage is the company's exposure as a share of its credit
+// limit, or nil when it has no limit.
+func companyCreditUsage(ctx context.Context, qtx *sqlc.Queries, row sqlc.ListCompanyRiskInputsRow) (*decimal.Decimal, error) {
+	if !row.CreditLimit.Valid {
+		return nil, nil
+	}
+
+	limit, err := decimal.NewFromString(row.CreditLimit.String)
+	if err != nil {
+		return nil, err
+	}
+
+	exposureText, err := qtx.GetCompanyExposure(ctx, sqlc.GetCompanyExposureParams{CustomerID: row.ID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	exposure, err := decimal.NewFromString(exposureText)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := decimal.Zero
+	switch {
+	case limit.IsPositive():
+		usage = exposure.Div(limit)
+	case exposure.IsPositive():
+		usage = decimal.NewFromInt(1)
+	}
+
+	return &usage, nil
+}
+
+// SetRiskOverride pins a company's at-risk status. A nil AtRisk clears the
+// override and rescores the company straight away.
+func (service *Service) SetRiskOverride(ctx context.Context, companyId, userId int32, request SetRiskOverrideRequest) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		rows, err := qtx.ListCompanyRiskInputs(ctx, pgtype.Int4{Int32: companyId, Valid: true})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if len(rows) == 0 {
+			return ErrCompanyNotFound
+		}
+		row := rows[0]
+
+		if err := qtx.SetCompanyRiskOverride(ctx, sqlc.SetCompanyRiskOverrideParams{
+			AtRiskOverride: db.ConvertToBool(request.AtRisk),
+			ID:             companyId,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		atRisk := row.AtRisk
+		reason := "override cleared: " + request.Reason
+		if request.AtRisk != nil {
+			atRisk = *request.AtRisk
+			reason = request.Reason
+		}
+
+		if err := qtx.CreateRiskScoreEntry(ctx, sqlc.CreateRiskScoreEntryParams{
+			CustomerID:    companyId,
+			Source:        sqlc.RiskScoreSourceOVERRIDE,
+			Score:         row.RiskScore,
+			AtRisk:        atRisk,
+			StatusChanged: atRisk != row.AtRisk,
+			Reasons:       []string{reason},
+			CreatedBy:     pgtype.Int4{Int32: userId, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if request.AtRisk != nil {
+			return nil
+		}
+
+		row.AtRiskOverride = pgtype.Bool{}
+		return service.scoreCompany(ctx, qtx, row)
+	})
+}
+
+func (service *Service) GetRiskHistory(ctx context.Context, companyId int32) ([]RiskScoreEntry, error) {
+	if _, err := service.query.GetCustomerCompanyById(ctx, companyId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	rows, err := service.query.ListRiskScoreHistory(ctx, companyId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	entries := make([]RiskScoreEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = RiskScoreEntry{
+			Source:        row.Source,
+			AtRisk:        row.AtRisk,
+			StatusChanged: row.StatusChanged,
+			Reasons:       row.Reasons,
+			CreatedAt:     row.CreatedAt.Time,
+		}
+		if row.Score.Valid {
+			entries[i].Score = &row.Score.Int32
+		}
+		if row.CreatedBy.Valid {
+			entries[i].CreatedBy = &row.CreatedBy.Int32
+		}
+	}
+
+	return entries, nil
+}
@@ -10,13 +10,18 @@ import (
 
 func Router(companiesHandler *Handler,
 	middleware *app.Middleware,
-	addressesRouter http.Handler) http.Handler {
+	addressesRouter http.Handler,
+	statementRouter http.Handler,
+	agingRouter http.Handler,
+	standingOrdersRouter http.Handler) http.Handler {
 	router := chi.NewRouter()
 
 	router.Group(func(r chi.Router) {
 		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
 		r.Get("/", companiesHandler.ListCompanies)
 		r.Patch("/{companyId}", companiesHandler.UpdateCompany)
+		r.Put("/{companyId}/credit", companiesHandler.UpdateCreditPolicy)
+		r.Get("/{companyId}/risk-history", companiesHandler.GetRiskHistory)
 	})
 
 	router.Group(func(r chi.Router) {
@@ -29,9 +34,13 @@ func Router(companiesHandler *Handler,
 
 		r.Patch("/{companyId}/activate", companiesHandler.ActivateCompany)
 		r.Patch("/{companyId}/deactivate", companiesHandler.DeactivateCompany)
+		r.Put("/{companyId}/risk-override", companiesHandler.SetRiskOverride)
 	})
 
+	router.Mount("/aging", agingRouter)
 	router.Mount("/{companyId}/addresses", addressesRouter)
+	router.Mount("/{companyId}/statement", statementRouter)
+	router.Mount("/{companyId}/standing-orders", standingOrdersRouter)
 
 	return router
 }
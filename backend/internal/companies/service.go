@@ -16,10 +16,11 @@ import (
 type Service struct {
 	query *sqlc.Queries
 	pool  *pgxpool.Pool
+	risk  RiskPolicy
 }
 
-func NewService(query *sqlc.Queries, pool *pgxpool.Pool) *Service {
-	return &Service{query: query, pool: pool}
+func NewService(query *sqlc.Queries, pool *pgxpool.Pool, riskPolicy RiskPolicy) *Service {
+	return &Service{query: query, pool: pool, risk: riskPolicy}
 }
 
 func (service *Service) ListCompanies(ctx context.Context) ([]Company, error) {
@@ -49,13 +50,14 @@ func (service *Service) GetCompany(ctx context.Context, companyId int32) (*Compa
 		}
 
 		response := &CompanyWithDetails{
-			Name:             company.Name,
-			TaxId:            company.TaxID,
-			Email:            company.MainEmail,
-			Status:           company.Status,
-			OrderCount:       company.OrderCount,
-			RegistrationDate: company.CreatedAt.Time,
-			CompletedOrders:  company.CompletedOrders,
+			Name:               company.Name,
+			TaxId:              company.TaxID,
+			Email:              company.MainEmail,
+			Status:             company.Status,
+			OrderCount:         company.OrderCount,
+			RegistrationDate:   company.CreatedAt.Time,
+			CompletedOrders:    company.CompletedOrders,
+			RequiresPrepayment: company.RequiresPrepayment,
 		}
 
 		if company.Phone.Valid {
@@ -69,6 +71,48 @@ func (service *Service) GetCompany(ctx context.Context, companyId int32) (*Compa
 
 		response.TotalOrdersValue = total
 
+		balance, err := decimal.NewFromString(company.Balance)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Balance = balance
+
+		credit, err := decimal.NewFromString(company.Credit)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Credit = credit
+		response.PaymentTermsDays = company.PaymentTermsDays
+		response.CreditLimitAction = company.CreditLimitAction
+
+		if company.RiskScore.Valid {
+			response.RiskScore = &company.RiskScore.Int32
+		}
+
+		if company.AtRiskOverride.Valid {
+			response.AtRiskOverride = &company.AtRiskOverride.Bool
+		}
+
+		if company.CreditLimit.Valid {
+			limit, err := decimal.NewFromString(company.CreditLimit.String)
+			if err != nil {
+				return nil, err
+			}
+			response.CreditLimit = &limit
+		}
+
+		exposure, err := qtx.GetCompanyExposure(ctx, sqlc.GetCompanyExposureParams{CustomerID: companyId})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		response.Exposure, err = decimal.NewFromString(exposure)
+		if err != nil {
+			return nil, err
+		}
+
 		response.Addresses = make([]Address, len(addresses))
 		for i, address := range addresses {
 			response.Addresses[i] = Address{
@@ -94,6 +138,35 @@ func (service *Service) UpdateCompany(ctx context.Context, companyId int32, requ
 	return nil
 }
 
+func (service *Service) UpdateCreditPolicy(ctx context.Context, companyId int32, request UpdateCreditPolicyRequest) error {
+	params := sqlc.SetCompanyCreditPolicyParams{
+		ID:                companyId,
+		PaymentTermsDays:  request.PaymentTermsDays,
+		CreditLimitAction: request.CreditLimitAction,
+	}
+
+	if request.CreditLimit != nil {
+		if request.CreditLimit.IsNegative() {
+			return ErrNegativeLimit
+		}
+
+		limit, err := db.DecimalToNumeric(*request.CreditLimit)
+		if err != nil {
+			return err
+		}
+		params.CreditLimit = limit
+	}
+
+	if _, err := service.query.SetCompanyCreditPolicy(ctx, params); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCompanyNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
 func (service *Service) ActivateCompany(ctx context.Context, companyId int32) error {
 	return service.updateCompanyStatus(ctx, companyId, service.query.ActivateCompany, "activate")
 }
@@ -169,5 +242,12 @@ func buildUpdateCompanyParams(companyId int32, request UpdateCompanyRequest) sql
 		}
 	}
 
+	if request.RequiresPrepayment != nil {
+		params.RequiresPrepayment = pgtype.Bool{
+			Bool:  *request.RequiresPrepayment,
+			Valid: true,
+		}
+	}
+
 	return params
 }
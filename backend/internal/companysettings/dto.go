@@ -0,0 +1,35 @@
+package companysettings
+
+import "time"
+
+type CompanySettings struct {
+	LegalName   string    `json:"legalName"`
+	TaxId       string    `json:"taxId"`
+	Regon       *string   `json:"regon,omitempty"`
+	AddressLine string    `json:"addressLine"`
+	City        string    `json:"city"`
+	PostalCode  string    `json:"postalCode"`
+	Country     string    `json:"country"`
+	Iban        string    `json:"iban"`
+	BankName    *string   `json:"bankName,omitempty"`
+	HasLogo     bool      `json:"hasLogo"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// UpdateCompanySettingsRequest replaces the seller details as a whole.
+type UpdateCompanySettingsRequest struct {
+	LegalName   string  `json:"legalName" validate:"required,max=255"`
+	TaxId       string  `json:"taxId" validate:"required,numeric,len=10"`
+	Regon       *string `json:"regon" validate:"omitempty,numeric,min=9,max=14"`
+	AddressLine string  `json:"addressLine" validate:"required,max=255"`
+	City        string  `json:"city" validate:"required,max=100"`
+	PostalCode  string  `json:"postalCode" validate:"required,max=20"`
+	Country     string  `json:"country" validate:"required,max=100"`
+	Iban        string  `json:"iban" validate:"required,max=42"`
+	BankName    *string `json:"bankName" validate:"omitempty,max=100"`
+}
+
+type Logo struct {
+	ContentType string
+	Data        []byte
+}
@@ -0,0 +1,11 @@
+package companysettings
+
+import "errors"
+
+var (
+	ErrSettingsNotConfigured = errors.New("company settings are not configured")
+	ErrInvalidIban           = errors.New("invalid IBAN")
+	ErrLogoNotFound          = errors.New("logo not found")
+	ErrUnsupportedLogo       = errors.New("logo must be a PNG or JPEG image")
+	ErrLogoTooLarge          = errors.New("logo is too large")
+)
@@ -0,0 +1,116 @@
+package companysettings
+
+import (
+	"errors"
+	"io"
+	"mleczarnia/internal/httputil"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+const maxLogoSize = 1 << 20
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) GetSettings(writer http.ResponseWriter, request *http.Request) {
+	settings, err := handler.service.GetSettings(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, settings)
+}
+
+func (handler *Handler) UpdateSettings(writer http.ResponseWriter, request *http.Request) {
+	var body UpdateCompanySettingsRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	settings, err := handler.service.UpdateSettings(request.Context(), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, settings)
+}
+
+func (handler *Handler) GetLogo(writer http.ResponseWriter, request *http.Request) {
+	logo, err := handler.service.GetLogo(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", logo.ContentType)
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(logo.Data)
+}
+
+// UploadLogo takes the raw image as the request body.
+func (handler *Handler) UploadLogo(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	data, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxLogoSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handler.handleServiceError(writer, ErrLogoTooLarge)
+			return
+		}
+		httputil.WriteError(writer, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := handler.service.SetLogo(request.Context(), data); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) RemoveLogo(writer http.ResponseWriter, request *http.Request) {
+	if err := handler.service.RemoveLogo(request.Context()); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrInvalidIban):
+		return http.StatusBadRequest, ErrInvalidIban.Error()
+	case errors.Is(err, ErrUnsupportedLogo):
+		return http.StatusBadRequest, ErrUnsupportedLogo.Error()
+
+	case errors.Is(err, ErrSettingsNotConfigured):
+		return http.StatusNotFound, ErrSettingsNotConfigured.Error()
+	case errors.Is(err, ErrLogoNotFound):
+		return http.StatusNotFound, ErrLogoNotFound.Error()
+
+	case errors.Is(err, ErrLogoTooLarge):
+		return http.StatusRequestEntityTooLarge, ErrLogoTooLarge.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,47 @@
+package companysettings
+
+import (
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// NormalizeIban strips spaces and upper-cases the account number, then checks
+// its length and mod-97 check digits.
+func NormalizeIban(iban string) (string, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+	if len(normalized) < 15 || len(normalized) > 34 {
+		return "", ErrInvalidIban
+	}
+
+	var digits strings.Builder
+	for _, r := range normalized[4:] + normalized[:4] {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			digits.WriteString(big.NewInt(int64(r - 'A' + 10)).String())
+		default:
+			return "", ErrInvalidIban
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok || new(big.Int).Mod(n, big.NewInt(97)).Int64() != 1 {
+		return "", ErrInvalidIban
+	}
+
+	return normalized, nil
+}
+
+// FormatIban prints the account number in groups of four, as on invoices.
+func FormatIban(iban string) string {
+	var formatted strings.Builder
+	for i, r := range iban {
+		if i > 0 && i%4 == 0 {
+			formatted.WriteByte(' ')
+		}
+		formatted.WriteRune(r)
+	}
+	return formatted.String()
+}
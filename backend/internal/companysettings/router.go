@@ -0,0 +1,28 @@
+package companysettings
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(companySettingsHandler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Get("/", companySettingsHandler.GetSettings)
+		r.Get("/logo", companySettingsHandler.GetLogo)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN))
+		r.Put("/", companySettingsHandler.UpdateSettings)
+		r.Put("/logo", companySettingsHandler.UploadLogo)
+		r.Delete("/logo", companySettingsHandler.RemoveLogo)
+	})
+
+	return router
+}
@@ -0,0 +1,190 @@
+package companysettings
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	query *sqlc.Queries
+	pool  *pgxpool.Pool
+}
+
+func NewService(query *sqlc.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{query: query, pool: pool}
+}
+
+func (service *Service) GetSettings(ctx context.Context) (*CompanySettings, error) {
+	row, err := service.query.GetCompanySettings(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSettingsNotConfigured
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return mapSettings(row), nil
+}
+
+func (service *Service) UpdateSettings(ctx context.Context, request UpdateCompanySettingsRequest) (*CompanySettings, error) {
+	iban, err := NormalizeIban(request.Iban)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := service.query.UpsertCompanySettings(ctx, sqlc.UpsertCompanySettingsParams{
+		LegalName:   request.LegalName,
+		TaxID:       request.TaxId,
+		Regon:       db.ConvertToText(request.Regon),
+		AddressLine: request.AddressLine,
+		City:        request.City,
+		PostalCode:  request.PostalCode,
+		Country:     request.Country,
+		Iban:        iban,
+		BankName:    db.ConvertToText(request.BankName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return mapSettings(row), nil
+}
+
+func (service *Service) GetLogo(ctx context.Context) (*Logo, error) {
+	settings, err := service.query.GetCompanySettings(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSettingsNotConfigured
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !settings.LogoID.Valid {
+		return nil, ErrLogoNotFound
+	}
+
+	return service.LoadLogo(ctx, settings.LogoID.Int32)
+}
+
+// LoadLogo returns a logo by id, including ones since replaced, which older
+// invoices still print.
+func (service *Service) LoadLogo(ctx context.Context, logoId int32) (*Logo, error) {
+	row, err := service.query.GetCompanyLogo(ctx, logoId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLogoNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return &Logo{ContentType: row.ContentType, Data: row.Data}, nil
+}
+
+// SetLogo stores a new logo and makes it current. The image is decoded up
+// front so a broken file cannot end up on invoices.
+func (service *Service) SetLogo(ctx context.Context, data []byte) error {
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ErrUnsupportedLogo
+	}
+
+	var contentType string
+	switch format {
+	case "png":
+		contentType = "image/png"
+	case "jpeg":
+		contentType = "image/jpeg"
+	default:
+		return ErrUnsupportedLogo
+	}
+
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		if _, err := qtx.GetCompanySettings(ctx); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrSettingsNotConfigured
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		logo, err := qtx.CreateCompanyLogo(ctx, sqlc.CreateCompanyLogoParams{
+			ContentType: contentType,
+			Data:        data,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if _, err := qtx.SetCompanyLogo(ctx, pgtype.Int4{Int32: logo.ID, Valid: true}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+// RemoveLogo stops printing a logo on new invoices. The image itself is kept
+// for invoices already issued with it.
+func (service *Service) RemoveLogo(ctx context.Context) error {
+	if _, err := service.query.SetCompanyLogo(ctx, pgtype.Int4{}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSettingsNotConfigured
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// SnapshotSeller copies the current seller details onto an invoice, so later
+// changes to the settings do not alter invoices already issued.
+func (service *Service) SnapshotSeller(ctx context.Context, qtx *sqlc.Queries, invoiceId int32) error {
+	if _, err := qtx.GetCompanySettings(ctx); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSettingsNotConfigured
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if err := qtx.SnapshotInvoiceSeller(ctx, invoiceId); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func mapSettings(row sqlc.CompanySetting) *CompanySettings {
+	settings := &CompanySettings{
+		LegalName:   row.LegalName,
+		TaxId:       row.TaxID,
+		AddressLine: row.AddressLine,
+		City:        row.City,
+		PostalCode:  row.PostalCode,
+		Country:     row.Country,
+		Iban:        row.Iban,
+		HasLogo:     row.LogoID.Valid,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+
+	if row.Regon.Valid {
+		settings.Regon = &row.Regon.String
+	}
+
+	if row.BankName.Valid {
+		settings.BankName = &row.BankName.String
+	}
+
+	return settings
+}
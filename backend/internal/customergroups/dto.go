@@ -0,0 +1,22 @@
+package customergroups
+
+import "time"
+
+type CustomerGroup struct {
+	Id          int32     `json:"id"`
+	Name        string    `json:"name"`
+	MemberCount int64     `json:"memberCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ListCustomerGroupsResponse struct {
+	CustomerGroups []CustomerGroup `json:"customerGroups"`
+}
+
+type CreateCustomerGroupRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type UpdateCustomerGroupRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
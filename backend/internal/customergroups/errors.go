@@ -0,0 +1,10 @@
+package customergroups
+
+import "errors"
+
+var (
+	ErrCustomerGroupIdRequired = errors.New("customer group id is required")
+	ErrInvalidCustomerGroupId  = errors.New("invalid customer group id")
+	ErrCustomerGroupNotFound   = errors.New("customer group not found")
+	ErrCompanyNotInGroup       = errors.New("company does not belong to this customer group")
+)
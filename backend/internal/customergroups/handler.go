@@ -0,0 +1,151 @@
+package customergroups
+
+import (
+	"errors"
+	"mleczarnia/internal/companies"
+	"mleczarnia/internal/httputil"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListCustomerGroups(writer http.ResponseWriter, request *http.Request) {
+	groups, err := handler.service.ListCustomerGroups(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListCustomerGroupsResponse{CustomerGroups: groups})
+}
+
+func (handler *Handler) CreateCustomerGroup(writer http.ResponseWriter, request *http.Request) {
+	var body CreateCustomerGroupRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	group, err := handler.service.CreateCustomerGroup(request.Context(), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, group)
+}
+
+func (handler *Handler) UpdateCustomerGroup(writer http.ResponseWriter, request *http.Request) {
+	groupId, err := handler.extractCustomerGroupId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body UpdateCustomerGroupRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.UpdateCustomerGroup(request.Context(), groupId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) AddMember(writer http.ResponseWriter, request *http.Request) {
+	groupId, companyId, err := handler.extractMemberIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.AddMember(request.Context(), groupId, companyId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) RemoveMember(writer http.ResponseWriter, request *http.Request) {
+	groupId, companyId, err := handler.extractMemberIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.RemoveMember(request.Context(), groupId, companyId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractMemberIds(request *http.Request) (int32, int32, error) {
+	groupId, err := handler.extractCustomerGroupId(request)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	companyIdStr := chi.URLParam(request, "companyId")
+	if companyIdStr == "" {
+		return 0, 0, companies.ErrCompanyIdRequired
+	}
+
+	companyId, err := strconv.Atoi(companyIdStr)
+	if err != nil {
+		return 0, 0, companies.ErrInvalidCompanyId
+	}
+
+	return groupId, int32(companyId), nil
+}
+
+func (handler *Handler) extractCustomerGroupId(request *http.Request) (int32, error) {
+	groupIdStr := chi.URLParam(request, "customerGroupId")
+	if groupIdStr == "" {
+		return 0, ErrCustomerGroupIdRequired
+	}
+
+	groupId, err := strconv.Atoi(groupIdStr)
+	if err != nil {
+		return 0, ErrInvalidCustomerGroupId
+	}
+
+	return int32(groupId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrCustomerGroupIdRequired), errors.Is(err, ErrInvalidCustomerGroupId),
+		errors.Is(err, companies.ErrCompanyIdRequired), errors.Is(err, companies.ErrInvalidCompanyId):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, ErrCustomerGroupNotFound), errors.Is(err, companies.ErrCompanyNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrCompanyNotInGroup):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
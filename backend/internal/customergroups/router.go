@@ -0,0 +1,24 @@
+package customergroups
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(customerGroupsHandler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Get("/", customerGroupsHandler.ListCustomerGroups)
+		r.Post("/", customerGroupsHandler.CreateCustomerGroup)
+		r.Patch("/{customerGroupId}", customerGroupsHandler.UpdateCustomerGroup)
+		r.Put("/{customerGroupId}/members/{companyId}", customerGroupsHandler.AddMember)
+		r.Delete("/{customerGroupId}/members/{companyId}", customerGroupsHandler.RemoveMember)
+	})
+
+	return router
+}
@@ -0,0 +1,116 @@
+package customergroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/companies"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	query *sqlc.Queries
+	pool  *pgxpool.Pool
+}
+
+func NewService(query *sqlc.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{query: query, pool: pool}
+}
+
+func (service *Service) ListCustomerGroups(ctx context.Context) ([]CustomerGroup, error) {
+	rows, err := service.query.ListCustomerGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]CustomerGroup, len(rows))
+	for i, row := range rows {
+		result[i] = CustomerGroup{
+			Id:          row.ID,
+			Name:        row.Name,
+			MemberCount: row.MemberCount,
+			CreatedAt:   row.CreatedAt.Time,
+		}
+	}
+
+	return result, nil
+}
+
+func (service *Service) CreateCustomerGroup(ctx context.Context, request CreateCustomerGroupRequest) (*CustomerGroup, error) {
+	row, err := service.query.CreateCustomerGroup(ctx, request.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return &CustomerGroup{Id: row.ID, Name: row.Name, CreatedAt: row.CreatedAt.Time}, nil
+}
+
+func (service *Service) UpdateCustomerGroup(ctx context.Context, groupId int32, request UpdateCustomerGroupRequest) error {
+	if _, err := service.query.UpdateCustomerGroup(ctx, sqlc.UpdateCustomerGroupParams{
+		ID:   groupId,
+		Name: request.Name,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCustomerGroupNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// AddMember moves the company into the group. A company belongs to at most
+// one group, so this replaces any previous membership.
+func (service *Service) AddMember(ctx context.Context, groupId int32, companyId int32) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		if _, err := qtx.GetCustomerGroupById(ctx, groupId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrCustomerGroupNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if _, err := qtx.SetCompanyCustomerGroup(ctx, sqlc.SetCompanyCustomerGroupParams{
+			ID:              companyId,
+			CustomerGroupID: pgtype.Int4{Int32: groupId, Valid: true},
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return companies.ErrCompanyNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+func (service *Service) RemoveMember(ctx context.Context, groupId int32, companyId int32) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		company, err := qtx.GetCustomerCompanyById(ctx, companyId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return companies.ErrCompanyNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if !company.CustomerGroupID.Valid || company.CustomerGroupID.Int32 != groupId {
+			return ErrCompanyNotInGroup
+		}
+
+		if _, err := qtx.SetCompanyCustomerGroup(ctx, sqlc.SetCompanyCustomerGroupParams{ID: companyId}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
@@ -109,6 +109,19 @@ func ConvertToTimestamptz(data *time.Time) pgtype.Timestamptz {
 	}
 }
 
+func ConvertToDate(data *time.Time) pgtype.Date {
+	if data != nil {
+		return pgtype.Date{
+			Time:  *data,
+			Valid: true,
+		}
+	}
+
+	return pgtype.Date{
+		Valid: false,
+	}
+}
+
 func DecimalToNumeric(data decimal.Decimal) (pgtype.Numeric, error) {
 	n := pgtype.Numeric{}
 	err := n.Scan(data.String())
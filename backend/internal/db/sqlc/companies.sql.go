@@ -23,26 +23,45 @@ SELECT c.id,
            ELSE 'INACTIVE'
            END::company_status                                                          AS status,
        c.created_at,
+       c.requires_prepayment,
        count(o.id) FILTER (WHERE o.status != 'CANCELLED')                               AS order_count,
        coalesce(sum(o.total_amount) FILTER (WHERE o.status != 'CANCELLED'), 0)::text AS total_orders_value,
-       count(o.id) FILTER (WHERE o.status = 'SHIPPED')                                  AS completed_orders
+       count(o.id) FILTER (WHERE o.status = 'SHIPPED')                                  AS completed_orders,
+       (SELECT coalesce(sum(greatest(b.amount_due - b.amount_paid, 0)), 0)
+        FROM invoice_balance b
+        WHERE b.customer_id = c.id)::text                                               AS balance,
+       cc.amount::text                                                                  AS credit,
+       c.credit_limit::text                                                             AS credit_limit,
+       c.payment_terms_days,
+       c.credit_limit_action,
+       c.risk_score,
+       c.at_risk_override
 FROM customer_company AS c
+         JOIN customer_credit AS cc ON (cc.customer_id = c.id)
          LEFT JOIN orders AS o ON (o.customer_id = c.id)
 WHERE c.id = $1
-GROUP BY c.id
+GROUP BY c.id, cc.amount
 `
 
 type GetCompanyDetailsByIdRow struct {
-	ID               int32
-	Name             string
-	TaxID            string
-	MainEmail        string
-	Phone            pgtype.Text
-	Status           CompanyStatus
-	CreatedAt        pgtype.Timestamptz
-	OrderCount       int64
-	TotalOrdersValue string
-	CompletedOrders  int64
+	ID                 int32
+	Name               string
+	TaxID              string
+	MainEmail          string
+	Phone              pgtype.Text
+	Status             CompanyStatus
+	CreatedAt          pgtype.Timestamptz
+	RequiresPrepayment bool
+	OrderCount         int64
+	TotalOrdersValue   string
+	CompletedOrders    int64
+	Balance            string
+	Credit             string
+	CreditLimit        pgtype.Text
+	PaymentTermsDays   int32
+	CreditLimitAction  CreditLimitAction
+	RiskScore          pgtype.Int4
+	AtRiskOverride     pgtype.Bool
 }
 
 func (q *Queries) GetCompanyDetailsById(ctx context.Context, id int32) (GetCompanyDetailsByIdRow, error) {
@@ -56,13 +75,49 @@ func (q *Queries) GetCompanyDetailsById(ctx context.Context, id int32) (GetCompa
 		&i.Phone,
 		&i.Status,
 		&i.CreatedAt,
+		&i.RequiresPrepayment,
 		&i.OrderCount,
 		&i.TotalOrdersValue,
 		&i.CompletedOrders,
+		&i.Balance,
+		&i.Credit,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
 	)
 	return i, err
 }
 
+const getCompanyExposure = `-- name: GetCompanyExposure :one
+SELECT ((SELECT coalesce(sum(greatest(b.amount_due - b.amount_paid, 0)), 0)
+         FROM invoice_balance b
+                  JOIN invoice i ON i.id = b.invoice_id
+         WHERE b.customer_id = $1::int
+           AND i.status IN ('UNPAID', 'PARTIALLY_PAID', 'OVERDUE')) +
+        (SELECT coalesce(sum(o.total_amount), 0)
+         FROM orders o
+         WHERE o.customer_id = $1::int
+           AND o.id != $2::int
+           AND o.status != 'CANCELLED'
+           AND NOT EXISTS (SELECT 1 FROM invoice i WHERE i.order_id = o.id)))::text AS exposure
+`
+
+type GetCompanyExposureParams struct {
+	CustomerID     int32
+	ExcludeOrderID int32
+}
+
+// Exposure is what the company owes on open invoices plus the value of its
+// orders that have not been invoiced yet. The order being checked is left out.
+func (q *Queries) GetCompanyExposure(ctx context.Context, arg GetCompanyExposureParams) (string, error) {
+	row := q.db.QueryRow(ctx, getCompanyExposure, arg.CustomerID, arg.ExcludeOrderID)
+	var exposure string
+	err := row.Scan(&exposure)
+	return exposure, err
+}
+
 const listCompanies = `-- name: ListCompanies :many
 SELECT c.id,
        c.name,
@@ -48,6 +48,81 @@ func (q *Queries) CreateCompanyAddress(ctx context.Context, arg CreateCompanyAdd
 	return i, err
 }
 
+const getCompanyAddress = `-- name: GetCompanyAddress :one
+SELECT id, customer_company_id, address_line, city, postal_code, country, type
+FROM company_address
+WHERE id = $1
+  AND customer_company_id = $2
+`
+
+type GetCompanyAddressParams struct {
+	ID                int32
+	CustomerCompanyID int32
+}
+
+func (q *Queries) GetCompanyAddress(ctx context.Context, arg GetCompanyAddressParams) (CompanyAddress, error) {
+	row := q.db.QueryRow(ctx, getCompanyAddress, arg.ID, arg.CustomerCompanyID)
+	var i CompanyAddress
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerCompanyID,
+		&i.AddressLine,
+		&i.City,
+		&i.PostalCode,
+		&i.Country,
+		&i.Type,
+	)
+	return i, err
+}
+
+const getCompanyBillingAddress = `-- name: GetCompanyBillingAddress :one
+SELECT id, customer_company_id, address_line, city, postal_code, country, type
+FROM company_address
+WHERE customer_company_id = $1
+  AND type = 'BILLING'
+ORDER BY id
+LIMIT 1
+`
+
+func (q *Queries) GetCompanyBillingAddress(ctx context.Context, customerCompanyID int32) (CompanyAddress, error) {
+	row := q.db.QueryRow(ctx, getCompanyBillingAddress, customerCompanyID)
+	var i CompanyAddress
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerCompanyID,
+		&i.AddressLine,
+		&i.City,
+		&i.PostalCode,
+		&i.Country,
+		&i.Type,
+	)
+	return i, err
+}
+
+const getCompanyShippingAddress = `-- name: GetCompanyShippingAddress :one
+SELECT id, customer_company_id, address_line, city, postal_code, country, type
+FROM company_address
+WHERE customer_company_id = $1
+  AND type = 'SHIPPING'
+ORDER BY id
+LIMIT 1
+`
+
+func (q *Queries) GetCompanyShippingAddress(ctx context.Context, customerCompanyID int32) (CompanyAddress, error) {
+	row := q.db.QueryRow(ctx, getCompanyShippingAddress, customerCompanyID)
+	var i CompanyAddress
+	err := row.Scan(
+		&i.ID,
+		&i.CustomerCompanyID,
+		&i.AddressLine,
+		&i.City,
+		&i.PostalCode,
+		&i.Country,
+		&i.Type,
+	)
+	return i, err
+}
+
 const listCompanyAddresses = `-- name: ListCompanyAddresses :many
 SELECT id, customer_company_id, address_line, city, postal_code, country, type
 FROM company_address
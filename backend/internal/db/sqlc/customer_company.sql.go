@@ -15,7 +15,7 @@ const activateCompany = `-- name: ActivateCompany :one
 UPDATE customer_company
 SET is_active = true
 WHERE id = $1
-RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at
+RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
 `
 
 func (q *Queries) ActivateCompany(ctx context.Context, id int32) (CustomerCompany, error) {
@@ -30,6 +30,13 @@ func (q *Queries) ActivateCompany(ctx context.Context, id int32) (CustomerCompan
 		&i.IsActive,
 		&i.AtRisk,
 		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
 	)
 	return i, err
 }
@@ -37,7 +44,7 @@ func (q *Queries) ActivateCompany(ctx context.Context, id int32) (CustomerCompan
 const createCustomerCompany = `-- name: CreateCustomerCompany :one
 INSERT INTO customer_company (name, tax_id, main_email, phone)
 VALUES ($1, $2, $3, $4)
-RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at
+RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
 `
 
 type CreateCustomerCompanyParams struct {
@@ -64,6 +71,13 @@ func (q *Queries) CreateCustomerCompany(ctx context.Context, arg CreateCustomerC
 		&i.IsActive,
 		&i.AtRisk,
 		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
 	)
 	return i, err
 }
@@ -72,7 +86,7 @@ const deactivateCompany = `-- name: DeactivateCompany :one
 UPDATE customer_company
 SET is_active = false
 WHERE id = $1
-RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at
+RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
 `
 
 func (q *Queries) DeactivateCompany(ctx context.Context, id int32) (CustomerCompany, error) {
@@ -87,12 +101,41 @@ func (q *Queries) DeactivateCompany(ctx context.Context, id int32) (CustomerComp
 		&i.IsActive,
 		&i.AtRisk,
 		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
 	)
 	return i, err
 }
 
+const getCompanyCreditForUpdate = `-- name: GetCompanyCreditForUpdate :one
+SELECT credit_limit::text AS credit_limit,
+       credit_limit_action
+FROM customer_company
+WHERE id = $1
+    FOR UPDATE
+`
+
+type GetCompanyCreditForUpdateRow struct {
+	CreditLimit       pgtype.Text
+	CreditLimitAction CreditLimitAction
+}
+
+// Locking the company serialises its orders, so two of them cannot both fit
+// under the limit the other one uses up.
+func (q *Queries) GetCompanyCreditForUpdate(ctx context.Context, id int32) (GetCompanyCreditForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getCompanyCreditForUpdate, id)
+	var i GetCompanyCreditForUpdateRow
+	err := row.Scan(&i.CreditLimit, &i.CreditLimitAction)
+	return i, err
+}
+
 const getCustomerCompanyById = `-- name: GetCustomerCompanyById :one
-SELECT id, name, tax_id, main_email, phone, is_active, at_risk, created_at
+SELECT id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
 FROM customer_company
 WHERE id = $1
 `
@@ -109,26 +152,114 @@ func (q *Queries) GetCustomerCompanyById(ctx context.Context, id int32) (Custome
 		&i.IsActive,
 		&i.AtRisk,
 		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
+	)
+	return i, err
+}
+
+const setCompanyCreditPolicy = `-- name: SetCompanyCreditPolicy :one
+UPDATE customer_company
+SET credit_limit        = $2,
+    payment_terms_days  = $3,
+    credit_limit_action = $4
+WHERE id = $1
+RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
+`
+
+type SetCompanyCreditPolicyParams struct {
+	ID                int32
+	CreditLimit       pgtype.Numeric
+	PaymentTermsDays  int32
+	CreditLimitAction CreditLimitAction
+}
+
+func (q *Queries) SetCompanyCreditPolicy(ctx context.Context, arg SetCompanyCreditPolicyParams) (CustomerCompany, error) {
+	row := q.db.QueryRow(ctx, setCompanyCreditPolicy,
+		arg.ID,
+		arg.CreditLimit,
+		arg.PaymentTermsDays,
+		arg.CreditLimitAction,
+	)
+	var i CustomerCompany
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TaxID,
+		&i.MainEmail,
+		&i.Phone,
+		&i.IsActive,
+		&i.AtRisk,
+		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
+	)
+	return i, err
+}
+
+const setCompanyCustomerGroup = `-- name: SetCompanyCustomerGroup :one
+UPDATE customer_company
+SET customer_group_id = $2
+WHERE id = $1
+RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
+`
+
+type SetCompanyCustomerGroupParams struct {
+	ID              int32
+	CustomerGroupID pgtype.Int4
+}
+
+func (q *Queries) SetCompanyCustomerGroup(ctx context.Context, arg SetCompanyCustomerGroupParams) (CustomerCompany, error) {
+	row := q.db.QueryRow(ctx, setCompanyCustomerGroup, arg.ID, arg.CustomerGroupID)
+	var i CustomerCompany
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TaxID,
+		&i.MainEmail,
+		&i.Phone,
+		&i.IsActive,
+		&i.AtRisk,
+		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
 	)
 	return i, err
 }
 
 const updateCompany = `-- name: UpdateCompany :one
 UPDATE customer_company
-SET name       = coalesce($1, name),
-    tax_id     = coalesce($2, tax_id),
-    main_email = coalesce($3, main_email),
-    phone      = coalesce($4, phone)
-WHERE id = $5
-RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at
+SET name                = coalesce($1, name),
+    tax_id              = coalesce($2, tax_id),
+    main_email          = coalesce($3, main_email),
+    phone               = coalesce($4, phone),
+    requires_prepayment = coalesce($5, requires_prepayment)
+WHERE id = $6
+RETURNING id, name, tax_id, main_email, phone, is_active, at_risk, created_at, customer_group_id, requires_prepayment, credit_limit, payment_terms_days, credit_limit_action, risk_score, at_risk_override
 `
 
 type UpdateCompanyParams struct {
-	Name      pgtype.Text
-	TaxID     pgtype.Text
-	MainEmail pgtype.Text
-	Phone     pgtype.Text
-	ID        int32
+	Name               pgtype.Text
+	TaxID              pgtype.Text
+	MainEmail          pgtype.Text
+	Phone              pgtype.Text
+	RequiresPrepayment pgtype.Bool
+	ID                 int32
 }
 
 func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (CustomerCompany, error) {
@@ -137,6 +268,7 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (C
 		arg.TaxID,
 		arg.MainEmail,
 		arg.Phone,
+		arg.RequiresPrepayment,
 		arg.ID,
 	)
 	var i CustomerCompany
@@ -149,6 +281,13 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) (C
 		&i.IsActive,
 		&i.AtRisk,
 		&i.CreatedAt,
+		&i.CustomerGroupID,
+		&i.RequiresPrepayment,
+		&i.CreditLimit,
+		&i.PaymentTermsDays,
+		&i.CreditLimitAction,
+		&i.RiskScore,
+		&i.AtRiskOverride,
 	)
 	return i, err
 }
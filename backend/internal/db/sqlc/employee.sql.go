@@ -12,16 +12,17 @@ import (
 )
 
 const createEmployee = `-- name: CreateEmployee :one
-INSERT INTO employee (first_name, last_name, position, hire_date)
-VALUES ($1, $2, $3, $4)
-RETURNING id, first_name, last_name, position, is_active, hire_date
+INSERT INTO employee (first_name, last_name, position, hire_date, warehouse_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, first_name, last_name, position, is_active, hire_date, warehouse_id
 `
 
 type CreateEmployeeParams struct {
-	FirstName string
-	LastName  string
-	Position  string
-	HireDate  pgtype.Timestamptz
+	FirstName   string
+	LastName    string
+	Position    string
+	HireDate    pgtype.Timestamptz
+	WarehouseID pgtype.Int4
 }
 
 func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams) (Employee, error) {
@@ -30,6 +31,7 @@ func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams)
 		arg.LastName,
 		arg.Position,
 		arg.HireDate,
+		arg.WarehouseID,
 	)
 	var i Employee
 	err := row.Scan(
@@ -39,12 +41,13 @@ func (q *Queries) CreateEmployee(ctx context.Context, arg CreateEmployeeParams)
 		&i.Position,
 		&i.IsActive,
 		&i.HireDate,
+		&i.WarehouseID,
 	)
 	return i, err
 }
 
 const getEmployeeById = `-- name: GetEmployeeById :one
-SELECT id, first_name, last_name, position, is_active, hire_date
+SELECT id, first_name, last_name, position, is_active, hire_date, warehouse_id
 FROM employee
 WHERE id = $1
 `
@@ -59,12 +62,13 @@ func (q *Queries) GetEmployeeById(ctx context.Context, id int32) (Employee, erro
 		&i.Position,
 		&i.IsActive,
 		&i.HireDate,
+		&i.WarehouseID,
 	)
 	return i, err
 }
 
 const listEmployees = `-- name: ListEmployees :many
-SELECT id, first_name, last_name, position, is_active, hire_date
+SELECT id, first_name, last_name, position, is_active, hire_date, warehouse_id
 FROM employee
 ORDER BY last_name, first_name
 `
@@ -85,6 +89,7 @@ func (q *Queries) ListEmployees(ctx context.Context) ([]Employee, error) {
 			&i.Position,
 			&i.IsActive,
 			&i.HireDate,
+			&i.WarehouseID,
 		); err != nil {
 			return nil, err
 		}
@@ -102,18 +107,20 @@ SET first_name = coalesce($1, first_name),
     last_name  = coalesce($2, last_name),
     position   = coalesce($3, position),
     is_active  = coalesce($4, is_active),
-    hire_date  = coalesce($5, hire_date)
-WHERE id = $6
-RETURNING id, first_name, last_name, position, is_active, hire_date
+    hire_date  = coalesce($5, hire_date),
+    warehouse_id = coalesce($6, warehouse_id)
+WHERE id = $7
+RETURNING id, first_name, last_name, position, is_active, hire_date, warehouse_id
 `
 
 type UpdateEmployeeParams struct {
-	FirstName pgtype.Text
-	LastName  pgtype.Text
-	Position  pgtype.Text
-	IsActive  pgtype.Bool
-	HireDate  pgtype.Timestamptz
-	ID        int32
+	FirstName   pgtype.Text
+	LastName    pgtype.Text
+	Position    pgtype.Text
+	IsActive    pgtype.Bool
+	HireDate    pgtype.Timestamptz
+	WarehouseID pgtype.Int4
+	ID          int32
 }
 
 func (q *Queries) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams) (Employee, error) {
@@ -123,6 +130,7 @@ func (q *Queries) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams)
 		arg.Position,
 		arg.IsActive,
 		arg.HireDate,
+		arg.WarehouseID,
 		arg.ID,
 	)
 	var i Employee
@@ -133,6 +141,7 @@ func (q *Queries) UpdateEmployee(ctx context.Context, arg UpdateEmployeeParams)
 		&i.Position,
 		&i.IsActive,
 		&i.HireDate,
+		&i.WarehouseID,
 	)
 	return i, err
 }
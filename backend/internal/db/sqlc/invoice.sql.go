@@ -16,16 +16,34 @@ INSERT INTO invoice (order_id,
                      issue_date,
                      due_date,
                      total_amount,
-                     status)
-VALUES ($1, $2,  $3, $4, 'UNPAID')
-RETURNING id, order_id, invoice_number, issue_date, due_date, total_amount, status
+                     status,
+                     net_amount,
+                     vat_amount,
+                     buyer_name,
+                     buyer_tax_id,
+                     billing_address_id,
+                     billing_address_line,
+                     billing_city,
+                     billing_postal_code,
+                     billing_country)
+VALUES ($1, $2,  $3, $4, 'UNPAID', $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, order_id, invoice_number, issue_date, due_date, total_amount, status, net_amount, vat_amount, seller_name, seller_tax_id, seller_regon, seller_address_line, seller_city, seller_postal_code, seller_country, seller_iban, seller_bank_name, seller_logo_id, buyer_name, buyer_tax_id, billing_address_id, billing_address_line, billing_city, billing_postal_code, billing_country
 `
 
 type CreateInvoiceParams struct {
-	OrderID     int32
-	IssueDate   pgtype.Timestamptz
-	DueDate     pgtype.Timestamptz
-	TotalAmount pgtype.Numeric
+	OrderID            int32
+	IssueDate          pgtype.Timestamptz
+	DueDate            pgtype.Timestamptz
+	TotalAmount        pgtype.Numeric
+	NetAmount          pgtype.Numeric
+	VatAmount          pgtype.Numeric
+	BuyerName          string
+	BuyerTaxID         string
+	BillingAddressID   pgtype.Int4
+	BillingAddressLine pgtype.Text
+	BillingCity        pgtype.Text
+	BillingPostalCode  pgtype.Text
+	BillingCountry     pgtype.Text
 }
 
 func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
@@ -34,6 +52,15 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		arg.IssueDate,
 		arg.DueDate,
 		arg.TotalAmount,
+		arg.NetAmount,
+		arg.VatAmount,
+		arg.BuyerName,
+		arg.BuyerTaxID,
+		arg.BillingAddressID,
+		arg.BillingAddressLine,
+		arg.BillingCity,
+		arg.BillingPostalCode,
+		arg.BillingCountry,
 	)
 	var i Invoice
 	err := row.Scan(
@@ -44,12 +71,55 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		&i.DueDate,
 		&i.TotalAmount,
 		&i.Status,
+		&i.NetAmount,
+		&i.VatAmount,
+		&i.SellerName,
+		&i.SellerTaxID,
+		&i.SellerRegon,
+		&i.SellerAddressLine,
+		&i.SellerCity,
+		&i.SellerPostalCode,
+		&i.SellerCountry,
+		&i.SellerIban,
+		&i.SellerBankName,
+		&i.SellerLogoID,
+		&i.BuyerName,
+		&i.BuyerTaxID,
+		&i.BillingAddressID,
+		&i.BillingAddressLine,
+		&i.BillingCity,
+		&i.BillingPostalCode,
+		&i.BillingCountry,
 	)
 	return i, err
 }
 
+const createInvoiceVatSummary = `-- name: CreateInvoiceVatSummary :exec
+INSERT INTO invoice_vat_summary (invoice_id, vat_rate, net_amount, vat_amount, gross_amount)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateInvoiceVatSummaryParams struct {
+	InvoiceID   int32
+	VatRate     VatRate
+	NetAmount   pgtype.Numeric
+	VatAmount   pgtype.Numeric
+	GrossAmount pgtype.Numeric
+}
+
+func (q *Queries) CreateInvoiceVatSummary(ctx context.Context, arg CreateInvoiceVatSummaryParams) error {
+	_, err := q.db.Exec(ctx, createInvoiceVatSummary,
+		arg.InvoiceID,
+		arg.VatRate,
+		arg.NetAmount,
+		arg.VatAmount,
+		arg.GrossAmount,
+	)
+	return err
+}
+
 const getInvoiceById = `-- name: GetInvoiceById :one
-SELECT id, order_id, invoice_number, issue_date, due_date, total_amount, status
+SELECT id, order_id, invoice_number, issue_date, due_date, total_amount, status, net_amount, vat_amount, seller_name, seller_tax_id, seller_regon, seller_address_line, seller_city, seller_postal_code, seller_country, seller_iban, seller_bank_name, seller_logo_id, buyer_name, buyer_tax_id, billing_address_id, billing_address_line, billing_city, billing_postal_code, billing_country
 FROM invoice
 WHERE id = $1
 `
@@ -65,16 +135,139 @@ func (q *Queries) GetInvoiceById(ctx context.Context, id int32) (Invoice, error)
 		&i.DueDate,
 		&i.TotalAmount,
 		&i.Status,
+		&i.NetAmount,
+		&i.VatAmount,
+		&i.SellerName,
+		&i.SellerTaxID,
+		&i.SellerRegon,
+		&i.SellerAddressLine,
+		&i.SellerCity,
+		&i.SellerPostalCode,
+		&i.SellerCountry,
+		&i.SellerIban,
+		&i.SellerBankName,
+		&i.SellerLogoID,
+		&i.BuyerName,
+		&i.BuyerTaxID,
+		&i.BillingAddressID,
+		&i.BillingAddressLine,
+		&i.BillingCity,
+		&i.BillingPostalCode,
+		&i.BillingCountry,
+	)
+	return i, err
+}
+
+const getInvoiceByNumber = `-- name: GetInvoiceByNumber :one
+SELECT i.id,
+       o.customer_id
+FROM invoice i
+         JOIN orders o ON o.id = i.order_id
+WHERE i.invoice_number = $1
+`
+
+type GetInvoiceByNumberRow struct {
+	ID         int32
+	CustomerID int32
+}
+
+func (q *Queries) GetInvoiceByNumber(ctx context.Context, invoiceNumber string) (GetInvoiceByNumberRow, error) {
+	row := q.db.QueryRow(ctx, getInvoiceByNumber, invoiceNumber)
+	var i GetInvoiceByNumberRow
+	err := row.Scan(&i.ID, &i.CustomerID)
+	return i, err
+}
+
+const getInvoiceByOrderId = `-- name: GetInvoiceByOrderId :one
+SELECT id, order_id, invoice_number, issue_date, due_date, total_amount, status, net_amount, vat_amount, seller_name, seller_tax_id, seller_regon, seller_address_line, seller_city, seller_postal_code, seller_country, seller_iban, seller_bank_name, seller_logo_id, buyer_name, buyer_tax_id, billing_address_id, billing_address_line, billing_city, billing_postal_code, billing_country
+FROM invoice
+WHERE order_id = $1
+`
+
+func (q *Queries) GetInvoiceByOrderId(ctx context.Context, orderID int32) (Invoice, error) {
+	row := q.db.QueryRow(ctx, getInvoiceByOrderId, orderID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.InvoiceNumber,
+		&i.IssueDate,
+		&i.DueDate,
+		&i.TotalAmount,
+		&i.Status,
+		&i.NetAmount,
+		&i.VatAmount,
+		&i.SellerName,
+		&i.SellerTaxID,
+		&i.SellerRegon,
+		&i.SellerAddressLine,
+		&i.SellerCity,
+		&i.SellerPostalCode,
+		&i.SellerCountry,
+		&i.SellerIban,
+		&i.SellerBankName,
+		&i.SellerLogoID,
+		&i.BuyerName,
+		&i.BuyerTaxID,
+		&i.BillingAddressID,
+		&i.BillingAddressLine,
+		&i.BillingCity,
+		&i.BillingPostalCode,
+		&i.BillingCountry,
+	)
+	return i, err
+}
+
+const getInvoiceForUpdate = `-- name: GetInvoiceForUpdate :one
+SELECT id, order_id, invoice_number, issue_date, due_date, total_amount, status, net_amount, vat_amount, seller_name, seller_tax_id, seller_regon, seller_address_line, seller_city, seller_postal_code, seller_country, seller_iban, seller_bank_name, seller_logo_id, buyer_name, buyer_tax_id, billing_address_id, billing_address_line, billing_city, billing_postal_code, billing_country
+FROM invoice
+WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetInvoiceForUpdate(ctx context.Context, id int32) (Invoice, error) {
+	row := q.db.QueryRow(ctx, getInvoiceForUpdate, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.InvoiceNumber,
+		&i.IssueDate,
+		&i.DueDate,
+		&i.TotalAmount,
+		&i.Status,
+		&i.NetAmount,
+		&i.VatAmount,
+		&i.SellerName,
+		&i.SellerTaxID,
+		&i.SellerRegon,
+		&i.SellerAddressLine,
+		&i.SellerCity,
+		&i.SellerPostalCode,
+		&i.SellerCountry,
+		&i.SellerIban,
+		&i.SellerBankName,
+		&i.SellerLogoID,
+		&i.BuyerName,
+		&i.BuyerTaxID,
+		&i.BillingAddressID,
+		&i.BillingAddressLine,
+		&i.BillingCity,
+		&i.BillingPostalCode,
+		&i.BillingCountry,
 	)
 	return i, err
 }
 
 const getInvoiceItems = `-- name: GetInvoiceItems :many
 SELECT oi.quantity,
-       oi.unit_price::text AS unit_price,
-       oi.line_total::text AS line_total,
-       p.name              AS product_name,
-       p.unit
+       oi.unit_price::text  AS unit_price,
+       oi.line_total::text  AS line_total,
+       p.name               AS product_name,
+       p.unit,
+       oi.vat_rate,
+       oi.vat_amount::text  AS vat_amount,
+       oi.gross_total::text AS gross_total
 FROM order_item oi
          JOIN product p ON p.id = oi.product_id
 WHERE oi.order_id = $1
@@ -86,6 +279,9 @@ type GetInvoiceItemsRow struct {
 	LineTotal   string
 	ProductName string
 	Unit        string
+	VatRate     VatRate
+	VatAmount   string
+	GrossTotal  string
 }
 
 func (q *Queries) GetInvoiceItems(ctx context.Context, orderID int32) ([]GetInvoiceItemsRow, error) {
@@ -103,6 +299,9 @@ func (q *Queries) GetInvoiceItems(ctx context.Context, orderID int32) ([]GetInvo
 			&i.LineTotal,
 			&i.ProductName,
 			&i.Unit,
+			&i.VatRate,
+			&i.VatAmount,
+			&i.GrossTotal,
 		); err != nil {
 			return nil, err
 		}
@@ -115,12 +314,12 @@ func (q *Queries) GetInvoiceItems(ctx context.Context, orderID int32) ([]GetInvo
 }
 
 const getInvoiceWithOrder = `-- name: GetInvoiceWithOrder :one
-SELECT i.id, i.order_id, i.invoice_number, i.issue_date, i.due_date, i.total_amount, i.status,
+SELECT i.id, i.order_id, i.invoice_number, i.issue_date, i.due_date, i.total_amount, i.status, i.net_amount, i.vat_amount, i.seller_name, i.seller_tax_id, i.seller_regon, i.seller_address_line, i.seller_city, i.seller_postal_code, i.seller_country, i.seller_iban, i.seller_bank_name, i.seller_logo_id, i.buyer_name, i.buyer_tax_id, i.billing_address_id, i.billing_address_line, i.billing_city, i.billing_postal_code, i.billing_country,
        i.total_amount::text AS total_amount_str,
+       i.net_amount::text   AS net_amount_str,
+       i.vat_amount::text   AS vat_amount_str,
        o.order_number,
        o.order_date,
-       c.name               AS company_name,
-       c.tax_id,
        c.main_email,
        c.id                 AS customer_id
 FROM invoice i
@@ -130,20 +329,39 @@ WHERE i.id = $1
 `
 
 type GetInvoiceWithOrderRow struct {
-	ID             int32
-	OrderID        int32
-	InvoiceNumber  string
-	IssueDate      pgtype.Timestamptz
-	DueDate        pgtype.Timestamptz
-	TotalAmount    pgtype.Numeric
-	Status         InvoiceStatus
-	TotalAmountStr string
-	OrderNumber    string
-	OrderDate      pgtype.Timestamptz
-	CompanyName    string
-	TaxID          string
-	MainEmail      string
-	CustomerID     int32
+	ID                 int32
+	OrderID            int32
+	InvoiceNumber      string
+	IssueDate          pgtype.Timestamptz
+	DueDate            pgtype.Timestamptz
+	TotalAmount        pgtype.Numeric
+	Status             InvoiceStatus
+	NetAmount          pgtype.Numeric
+	VatAmount          pgtype.Numeric
+	SellerName         pgtype.Text
+	SellerTaxID        pgtype.Text
+	SellerRegon        pgtype.Text
+	SellerAddressLine  pgtype.Text
+	SellerCity         pgtype.Text
+	SellerPostalCode   pgtype.Text
+	SellerCountry      pgtype.Text
+	SellerIban         pgtype.Text
+	SellerBankName     pgtype.Text
+	SellerLogoID       pgtype.Int4
+	BuyerName          string
+	BuyerTaxID         string
+	BillingAddressID   pgtype.Int4
+	BillingAddressLine pgtype.Text
+	BillingCity        pgtype.Text
+	BillingPostalCode  pgtype.Text
+	BillingCountry     pgtype.Text
+	TotalAmountStr     string
+	NetAmountStr       string
+	VatAmountStr       string
+	OrderNumber        string
+	OrderDate          pgtype.Timestamptz
+	MainEmail          string
+	CustomerID         int32
 }
 
 func (q *Queries) GetInvoiceWithOrder(ctx context.Context, id int32) (GetInvoiceWithOrderRow, error) {
@@ -157,11 +375,30 @@ func (q *Queries) GetInvoiceWithOrder(ctx context.Context, id int32) (GetInvoice
 		&i.DueDate,
 		&i.TotalAmount,
 		&i.Status,
+		&i.NetAmount,
+		&i.VatAmount,
+		&i.SellerName,
+		&i.SellerTaxID,
+		&i.SellerRegon,
+		&i.SellerAddressLine,
+		&i.SellerCity,
+		&i.SellerPostalCode,
+		&i.SellerCountry,
+		&i.SellerIban,
+		&i.SellerBankName,
+		&i.SellerLogoID,
+		&i.BuyerName,
+		&i.BuyerTaxID,
+		&i.BillingAddressID,
+		&i.BillingAddressLine,
+		&i.BillingCity,
+		&i.BillingPostalCode,
+		&i.BillingCountry,
 		&i.TotalAmountStr,
+		&i.NetAmountStr,
+		&i.VatAmountStr,
 		&i.OrderNumber,
 		&i.OrderDate,
-		&i.CompanyName,
-		&i.TaxID,
 		&i.MainEmail,
 		&i.CustomerID,
 	)
@@ -181,6 +418,48 @@ func (q *Queries) InvoiceExistsForOrder(ctx context.Context, orderID int32) (boo
 	return exists, err
 }
 
+const listInvoiceVatSummary = `-- name: ListInvoiceVatSummary :many
+SELECT vat_rate,
+       net_amount::text   AS net_amount,
+       vat_amount::text   AS vat_amount,
+       gross_amount::text AS gross_amount
+FROM invoice_vat_summary
+WHERE invoice_id = $1
+ORDER BY vat_rate
+`
+
+type ListInvoiceVatSummaryRow struct {
+	VatRate     VatRate
+	NetAmount   string
+	VatAmount   string
+	GrossAmount string
+}
+
+func (q *Queries) ListInvoiceVatSummary(ctx context.Context, invoiceID int32) ([]ListInvoiceVatSummaryRow, error) {
+	rows, err := q.db.Query(ctx, listInvoiceVatSummary, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListInvoiceVatSummaryRow
+	for rows.Next() {
+		var i ListInvoiceVatSummaryRow
+		if err := rows.Scan(
+			&i.VatRate,
+			&i.NetAmount,
+			&i.VatAmount,
+			&i.GrossAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listInvoices = `-- name: ListInvoices :many
 SELECT i.id,
        i.invoice_number,
@@ -189,10 +468,9 @@ SELECT i.id,
        i.total_amount::text AS total_amount,
        i.status,
        o.id                 AS order_id,
-       c.name               AS company_name
+       i.buyer_name         AS company_name
 FROM invoice i
          JOIN orders o ON o.id = i.order_id
-         JOIN customer_company c ON c.id = o.customer_id
 ORDER BY i.issue_date DESC
 `
 
@@ -288,11 +566,33 @@ func (q *Queries) ListInvoicesForCompany(ctx context.Context, customerID int32)
 	return items, nil
 }
 
+const snapshotInvoiceSeller = `-- name: SnapshotInvoiceSeller :exec
+UPDATE invoice i
+SET seller_name         = cs.legal_name,
+    seller_tax_id       = cs.tax_id,
+    seller_regon        = cs.regon,
+    seller_address_line = cs.address_line,
+    seller_city         = cs.city,
+    seller_postal_code  = cs.postal_code,
+    seller_country      = cs.country,
+    seller_iban         = cs.iban,
+    seller_bank_name    = cs.bank_name,
+    seller_logo_id      = cs.logo_id
+FROM company_settings cs
+WHERE i.id = $1
+  AND cs.id = 1
+`
+
+func (q *Queries) SnapshotInvoiceSeller(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, snapshotInvoiceSeller, id)
+	return err
+}
+
 const updateInvoiceStatus = `-- name: UpdateInvoiceStatus :one
 UPDATE invoice
 SET status = $2
 WHERE id = $1
-RETURNING id, order_id, invoice_number, issue_date, due_date, total_amount, status
+RETURNING id, order_id, invoice_number, issue_date, due_date, total_amount, status, net_amount, vat_amount, seller_name, seller_tax_id, seller_regon, seller_address_line, seller_city, seller_postal_code, seller_country, seller_iban, seller_bank_name, seller_logo_id, buyer_name, buyer_tax_id, billing_address_id, billing_address_line, billing_city, billing_postal_code, billing_country
 `
 
 type UpdateInvoiceStatusParams struct {
@@ -311,6 +611,25 @@ func (q *Queries) UpdateInvoiceStatus(ctx context.Context, arg UpdateInvoiceStat
 		&i.DueDate,
 		&i.TotalAmount,
 		&i.Status,
+		&i.NetAmount,
+		&i.VatAmount,
+		&i.SellerName,
+		&i.SellerTaxID,
+		&i.SellerRegon,
+		&i.SellerAddressLine,
+		&i.SellerCity,
+		&i.SellerPostalCode,
+		&i.SellerCountry,
+		&i.SellerIban,
+		&i.SellerBankName,
+		&i.SellerLogoID,
+		&i.BuyerName,
+		&i.BuyerTaxID,
+		&i.BillingAddressID,
+		&i.BillingAddressLine,
+		&i.BillingCity,
+		&i.BillingPostalCode,
+		&i.BillingCountry,
 	)
 	return i, err
 }
@@ -96,6 +96,92 @@ func (ns NullAddressType) Value() (driver.Value, error) {
 	return string(ns.AddressType), nil
 }
 
+type BankStatementFormat string
+
+const (
+	BankStatementFormatMT940 BankStatementFormat = "MT940"
+	BankStatementFormatCSV   BankStatementFormat = "CSV"
+)
+
+func (e *BankStatementFormat) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BankStatementFormat(s)
+	case string:
+		*e = BankStatementFormat(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BankStatementFormat: %T", src)
+	}
+	return nil
+}
+
+type NullBankStatementFormat struct {
+	BankStatementFormat BankStatementFormat
+	Valid               bool // Valid is true if BankStatementFormat is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBankStatementFormat) Scan(value interface{}) error {
+	if value == nil {
+		ns.BankStatementFormat, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BankStatementFormat.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBankStatementFormat) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BankStatementFormat), nil
+}
+
+type BankTransactionStatus string
+
+const (
+	BankTransactionStatusMATCHED   BankTransactionStatus = "MATCHED"
+	BankTransactionStatusUNMATCHED BankTransactionStatus = "UNMATCHED"
+	BankTransactionStatusASSIGNED  BankTransactionStatus = "ASSIGNED"
+	BankTransactionStatusIGNORED   BankTransactionStatus = "IGNORED"
+)
+
+func (e *BankTransactionStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BankTransactionStatus(s)
+	case string:
+		*e = BankTransactionStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BankTransactionStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBankTransactionStatus struct {
+	BankTransactionStatus BankTransactionStatus
+	Valid                 bool // Valid is true if BankTransactionStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBankTransactionStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BankTransactionStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BankTransactionStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBankTransactionStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BankTransactionStatus), nil
+}
+
 type CompanyStatus string
 
 const (
@@ -139,12 +225,97 @@ func (ns NullCompanyStatus) Value() (driver.Value, error) {
 	return string(ns.CompanyStatus), nil
 }
 
+type CreditLimitAction string
+
+const (
+	CreditLimitActionREJECT CreditLimitAction = "REJECT"
+	CreditLimitActionHOLD   CreditLimitAction = "HOLD"
+)
+
+func (e *CreditLimitAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CreditLimitAction(s)
+	case string:
+		*e = CreditLimitAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CreditLimitAction: %T", src)
+	}
+	return nil
+}
+
+type NullCreditLimitAction struct {
+	CreditLimitAction CreditLimitAction
+	Valid             bool // Valid is true if CreditLimitAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCreditLimitAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.CreditLimitAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CreditLimitAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCreditLimitAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CreditLimitAction), nil
+}
+
+type DiscountType string
+
+const (
+	DiscountTypePERCENTAGE  DiscountType = "PERCENTAGE"
+	DiscountTypeFIXEDAMOUNT DiscountType = "FIXED_AMOUNT"
+)
+
+func (e *DiscountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DiscountType(s)
+	case string:
+		*e = DiscountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DiscountType: %T", src)
+	}
+	return nil
+}
+
+type NullDiscountType struct {
+	DiscountType DiscountType
+	Valid        bool // Valid is true if DiscountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDiscountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.DiscountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DiscountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDiscountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DiscountType), nil
+}
+
 type InvoiceStatus string
 
 const (
-	InvoiceStatusPAID    InvoiceStatus = "PAID"
-	InvoiceStatusUNPAID  InvoiceStatus = "UNPAID"
-	InvoiceStatusOVERDUE InvoiceStatus = "OVERDUE"
+	InvoiceStatusPAID          InvoiceStatus = "PAID"
+	InvoiceStatusUNPAID        InvoiceStatus = "UNPAID"
+	InvoiceStatusOVERDUE       InvoiceStatus = "OVERDUE"
+	InvoiceStatusPARTIALLYPAID InvoiceStatus = "PARTIALLY_PAID"
 )
 
 func (e *InvoiceStatus) Scan(src interface{}) error {
@@ -190,6 +361,7 @@ const (
 	MovementTypeRETURN     MovementType = "RETURN"
 	MovementTypeDISPATCH   MovementType = "DISPATCH"
 	MovementTypeINBOUND    MovementType = "INBOUND"
+	MovementTypeTRANSFER   MovementType = "TRANSFER"
 )
 
 func (e *MovementType) Scan(src interface{}) error {
@@ -235,6 +407,7 @@ const (
 	OrderStatusINPREPARATION OrderStatus = "IN_PREPARATION"
 	OrderStatusCANCELLED     OrderStatus = "CANCELLED"
 	OrderStatusSHIPPED       OrderStatus = "SHIPPED"
+	OrderStatusONHOLD        OrderStatus = "ON_HOLD"
 )
 
 func (e *OrderStatus) Scan(src interface{}) error {
@@ -272,6 +445,223 @@ func (ns NullOrderStatus) Value() (driver.Value, error) {
 	return string(ns.OrderStatus), nil
 }
 
+type PaymentMethod string
+
+const (
+	PaymentMethodBANKTRANSFER PaymentMethod = "BANK_TRANSFER"
+	PaymentMethodCASH         PaymentMethod = "CASH"
+	PaymentMethodCARD         PaymentMethod = "CARD"
+	PaymentMethodOTHER        PaymentMethod = "OTHER"
+)
+
+func (e *PaymentMethod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentMethod(s)
+	case string:
+		*e = PaymentMethod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentMethod: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentMethod struct {
+	PaymentMethod PaymentMethod
+	Valid         bool // Valid is true if PaymentMethod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentMethod) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentMethod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentMethod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentMethod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentMethod), nil
+}
+
+type PriceSource string
+
+const (
+	PriceSourceCUSTOMERPRICELIST PriceSource = "CUSTOMER_PRICE_LIST"
+	PriceSourceGROUPPRICELIST    PriceSource = "GROUP_PRICE_LIST"
+	PriceSourceDEFAULTPRICE      PriceSource = "DEFAULT_PRICE"
+)
+
+func (e *PriceSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PriceSource(s)
+	case string:
+		*e = PriceSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PriceSource: %T", src)
+	}
+	return nil
+}
+
+type NullPriceSource struct {
+	PriceSource PriceSource
+	Valid       bool // Valid is true if PriceSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPriceSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.PriceSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PriceSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPriceSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PriceSource), nil
+}
+
+type ProformaStatus string
+
+const (
+	ProformaStatusUNPAID    ProformaStatus = "UNPAID"
+	ProformaStatusPAID      ProformaStatus = "PAID"
+	ProformaStatusCANCELLED ProformaStatus = "CANCELLED"
+)
+
+func (e *ProformaStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProformaStatus(s)
+	case string:
+		*e = ProformaStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProformaStatus: %T", src)
+	}
+	return nil
+}
+
+type NullProformaStatus struct {
+	ProformaStatus ProformaStatus
+	Valid          bool // Valid is true if ProformaStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProformaStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProformaStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProformaStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProformaStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProformaStatus), nil
+}
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDRAFT             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusSENT              PurchaseOrderStatus = "SENT"
+	PurchaseOrderStatusPARTIALLYRECEIVED PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusRECEIVED          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusCANCELLED         PurchaseOrderStatus = "CANCELLED"
+)
+
+func (e *PurchaseOrderStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PurchaseOrderStatus(s)
+	case string:
+		*e = PurchaseOrderStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PurchaseOrderStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPurchaseOrderStatus struct {
+	PurchaseOrderStatus PurchaseOrderStatus
+	Valid               bool // Valid is true if PurchaseOrderStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPurchaseOrderStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PurchaseOrderStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PurchaseOrderStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPurchaseOrderStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PurchaseOrderStatus), nil
+}
+
+type RiskScoreSource string
+
+const (
+	RiskScoreSourceSCORING  RiskScoreSource = "SCORING"
+	RiskScoreSourceOVERRIDE RiskScoreSource = "OVERRIDE"
+)
+
+func (e *RiskScoreSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RiskScoreSource(s)
+	case string:
+		*e = RiskScoreSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RiskScoreSource: %T", src)
+	}
+	return nil
+}
+
+type NullRiskScoreSource struct {
+	RiskScoreSource RiskScoreSource
+	Valid           bool // Valid is true if RiskScoreSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRiskScoreSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.RiskScoreSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RiskScoreSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRiskScoreSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RiskScoreSource), nil
+}
+
 type Role string
 
 const (
@@ -316,6 +706,93 @@ func (ns NullRole) Value() (driver.Value, error) {
 	return string(ns.Role), nil
 }
 
+type StandingOrderOccurrenceStatus string
+
+const (
+	StandingOrderOccurrenceStatusPENDING StandingOrderOccurrenceStatus = "PENDING"
+	StandingOrderOccurrenceStatusSKIPPED StandingOrderOccurrenceStatus = "SKIPPED"
+	StandingOrderOccurrenceStatusCREATED StandingOrderOccurrenceStatus = "CREATED"
+	StandingOrderOccurrenceStatusFAILED  StandingOrderOccurrenceStatus = "FAILED"
+)
+
+func (e *StandingOrderOccurrenceStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StandingOrderOccurrenceStatus(s)
+	case string:
+		*e = StandingOrderOccurrenceStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StandingOrderOccurrenceStatus: %T", src)
+	}
+	return nil
+}
+
+type NullStandingOrderOccurrenceStatus struct {
+	StandingOrderOccurrenceStatus StandingOrderOccurrenceStatus
+	Valid                         bool // Valid is true if StandingOrderOccurrenceStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStandingOrderOccurrenceStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.StandingOrderOccurrenceStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StandingOrderOccurrenceStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStandingOrderOccurrenceStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StandingOrderOccurrenceStatus), nil
+}
+
+type StocktakeStatus string
+
+const (
+	StocktakeStatusOPEN      StocktakeStatus = "OPEN"
+	StocktakeStatusCLOSED    StocktakeStatus = "CLOSED"
+	StocktakeStatusCANCELLED StocktakeStatus = "CANCELLED"
+)
+
+func (e *StocktakeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StocktakeStatus(s)
+	case string:
+		*e = StocktakeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StocktakeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullStocktakeStatus struct {
+	StocktakeStatus StocktakeStatus
+	Valid           bool // Valid is true if StocktakeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStocktakeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.StocktakeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StocktakeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStocktakeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StocktakeStatus), nil
+}
+
 type UserStatus string
 
 const (
@@ -359,6 +836,116 @@ func (ns NullUserStatus) Value() (driver.Value, error) {
 	return string(ns.UserStatus), nil
 }
 
+type VatRate string
+
+const (
+	VatRate23 VatRate = "23"
+	VatRate8  VatRate = "8"
+	VatRate5  VatRate = "5"
+	VatRate0  VatRate = "0"
+	VatRateZW VatRate = "ZW"
+)
+
+func (e *VatRate) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = VatRate(s)
+	case string:
+		*e = VatRate(s)
+	default:
+		return fmt.Errorf("unsupported scan type for VatRate: %T", src)
+	}
+	return nil
+}
+
+type NullVatRate struct {
+	VatRate VatRate
+	Valid   bool // Valid is true if VatRate is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullVatRate) Scan(value interface{}) error {
+	if value == nil {
+		ns.VatRate, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.VatRate.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullVatRate) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.VatRate), nil
+}
+
+type WarehouseDocumentType string
+
+const (
+	WarehouseDocumentTypePZ WarehouseDocumentType = "PZ"
+	WarehouseDocumentTypeWZ WarehouseDocumentType = "WZ"
+)
+
+func (e *WarehouseDocumentType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WarehouseDocumentType(s)
+	case string:
+		*e = WarehouseDocumentType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WarehouseDocumentType: %T", src)
+	}
+	return nil
+}
+
+type NullWarehouseDocumentType struct {
+	WarehouseDocumentType WarehouseDocumentType
+	Valid                 bool // Valid is true if WarehouseDocumentType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWarehouseDocumentType) Scan(value interface{}) error {
+	if value == nil {
+		ns.WarehouseDocumentType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WarehouseDocumentType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWarehouseDocumentType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WarehouseDocumentType), nil
+}
+
+type BankStatement struct {
+	ID         int32
+	Format     BankStatementFormat
+	ImportedBy int32
+	ImportedAt pgtype.Timestamptz
+}
+
+type BankTransaction struct {
+	ID                  int32
+	StatementID         int32
+	BookingDate         pgtype.Date
+	Amount              pgtype.Numeric
+	CounterpartyName    pgtype.Text
+	CounterpartyAccount pgtype.Text
+	Title               string
+	BankReference       pgtype.Text
+	Fingerprint         string
+	Status              BankTransactionStatus
+	PaymentID           pgtype.Int4
+	ResolvedBy          pgtype.Int4
+	ResolvedAt          pgtype.Timestamptz
+}
+
 type CompanyAddress struct {
 	ID                int32
 	CustomerCompanyID int32
@@ -369,52 +956,291 @@ type CompanyAddress struct {
 	Type              AddressType
 }
 
+type CompanyLogo struct {
+	ID          int32
+	ContentType string
+	Data        []byte
+	CreatedAt   pgtype.Timestamptz
+}
+
+type CompanySetting struct {
+	ID          int32
+	LegalName   string
+	TaxID       string
+	Regon       pgtype.Text
+	AddressLine string
+	City        string
+	PostalCode  string
+	Country     string
+	Iban        string
+	BankName    pgtype.Text
+	LogoID      pgtype.Int4
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type CompanyRiskScore struct {
+	ID            int32
+	CustomerID    int32
+	Source        RiskScoreSource
+	Score         pgtype.Int4
+	AtRisk        bool
+	StatusChanged bool
+	Reasons       []string
+	CreatedBy     pgtype.Int4
+	CreatedAt     pgtype.Timestamptz
+}
+
+type CorrectiveInvoice struct {
+	ID               int32
+	InvoiceID        int32
+	CorrectionNumber string
+	IssueDate        pgtype.Timestamptz
+	Reason           string
+	StockMovementID  pgtype.Int4
+	NetAmount        pgtype.Numeric
+	VatAmount        pgtype.Numeric
+	TotalAmount      pgtype.Numeric
+	CreatedBy        int32
+}
+
+type CorrectiveInvoiceLine struct {
+	ID                  int32
+	CorrectiveInvoiceID int32
+	OrderItemID         int32
+	VatRate             VatRate
+	QuantityBefore      int32
+	QuantityAfter       int32
+	UnitPriceBefore     pgtype.Numeric
+	UnitPriceAfter      pgtype.Numeric
+	NetChange           pgtype.Numeric
+	VatChange           pgtype.Numeric
+	GrossChange         pgtype.Numeric
+}
+
+type CorrectiveInvoiceSequence struct {
+	Year       int32
+	LastNumber int32
+}
+
 type CustomerCompany struct {
+	ID                 int32
+	Name               string
+	TaxID              string
+	MainEmail          string
+	Phone              pgtype.Text
+	IsActive           bool
+	AtRisk             bool
+	CreatedAt          pgtype.Timestamptz
+	CustomerGroupID    pgtype.Int4
+	RequiresPrepayment bool
+	CreditLimit        pgtype.Numeric
+	PaymentTermsDays   int32
+	CreditLimitAction  CreditLimitAction
+	RiskScore          pgtype.Int4
+	AtRiskOverride     pgtype.Bool
+}
+
+type CustomerCredit struct {
+	CustomerID int32
+	Amount     pgtype.Numeric
+}
+
+type CustomerGroup struct {
 	ID        int32
 	Name      string
-	TaxID     string
-	MainEmail string
-	Phone     pgtype.Text
-	IsActive  bool
-	AtRisk    bool
 	CreatedAt pgtype.Timestamptz
 }
 
+type CustomerLedger struct {
+	CustomerID     int32
+	InvoiceID      pgtype.Int4
+	DocumentType   string
+	DocumentNumber string
+	EntryDate      pgtype.Timestamptz
+	Amount         pgtype.Numeric
+}
+
+type DiscountRule struct {
+	ID           int32
+	Name         string
+	ProductID    pgtype.Int4
+	Category     pgtype.Text
+	DiscountType DiscountType
+	Value        pgtype.Numeric
+	MinQuantity  int32
+	ValidFrom    pgtype.Date
+	ValidTo      pgtype.Date
+	IsActive     bool
+	CreatedAt    pgtype.Timestamptz
+}
+
+type DunningReminder struct {
+	ID                int32
+	InvoiceID         int32
+	Stage             int32
+	DaysOverdue       int32
+	Recipient         string
+	AmountOutstanding pgtype.Numeric
+	SentAt            pgtype.Timestamptz
+}
+
 type Employee struct {
-	ID        int32
-	FirstName string
-	LastName  string
-	Position  string
-	IsActive  bool
-	HireDate  pgtype.Timestamptz
+	ID          int32
+	FirstName   string
+	LastName    string
+	Position    string
+	IsActive    bool
+	HireDate    pgtype.Timestamptz
+	WarehouseID pgtype.Int4
 }
 
 type Invoice struct {
+	ID                 int32
+	OrderID            int32
+	InvoiceNumber      string
+	IssueDate          pgtype.Timestamptz
+	DueDate            pgtype.Timestamptz
+	TotalAmount        pgtype.Numeric
+	Status             InvoiceStatus
+	NetAmount          pgtype.Numeric
+	VatAmount          pgtype.Numeric
+	SellerName         pgtype.Text
+	SellerTaxID        pgtype.Text
+	SellerRegon        pgtype.Text
+	SellerAddressLine  pgtype.Text
+	SellerCity         pgtype.Text
+	SellerPostalCode   pgtype.Text
+	SellerCountry      pgtype.Text
+	SellerIban         pgtype.Text
+	SellerBankName     pgtype.Text
+	SellerLogoID       pgtype.Int4
+	BuyerName          string
+	BuyerTaxID         string
+	BillingAddressID   pgtype.Int4
+	BillingAddressLine pgtype.Text
+	BillingCity        pgtype.Text
+	BillingPostalCode  pgtype.Text
+	BillingCountry     pgtype.Text
+}
+
+type InvoiceBalance struct {
+	InvoiceID  int32
+	CustomerID int32
+	AmountDue  pgtype.Numeric
+	AmountPaid pgtype.Numeric
+}
+
+type InvoiceVatSummary struct {
+	InvoiceID   int32
+	VatRate     VatRate
+	NetAmount   pgtype.Numeric
+	VatAmount   pgtype.Numeric
+	GrossAmount pgtype.Numeric
+}
+
+type Order struct {
+	ID                  int32
+	OrderNumber         string
+	CustomerID          int32
+	OrderDate           pgtype.Timestamptz
+	Status              OrderStatus
+	TotalAmount         pgtype.Numeric
+	WarehouseID         int32
+	ShippingAddressID   pgtype.Int4
+	ShippingAddressLine pgtype.Text
+	ShippingCity        pgtype.Text
+	ShippingPostalCode  pgtype.Text
+	ShippingCountry     pgtype.Text
+}
+
+type OrderCreditHold struct {
 	ID            int32
 	OrderID       int32
-	InvoiceNumber string
-	IssueDate     pgtype.Timestamptz
-	DueDate       pgtype.Timestamptz
-	TotalAmount   pgtype.Numeric
-	Status        InvoiceStatus
+	Exposure      pgtype.Numeric
+	CreditLimit   pgtype.Numeric
+	HeldAt        pgtype.Timestamptz
+	ReleasedBy    pgtype.Int4
+	ReleasedAt    pgtype.Timestamptz
+	Justification pgtype.Text
 }
 
-type Order struct {
+type OrderItem struct {
+	ID             int32
+	OrderID        int32
+	ProductID      int32
+	Quantity       int32
+	UnitPrice      pgtype.Numeric
+	LineTotal      pgtype.Numeric
+	PriceSource    PriceSource
+	PriceListID    pgtype.Int4
+	ListPrice      pgtype.Numeric
+	DiscountAmount pgtype.Numeric
+	DiscountRuleID pgtype.Int4
+	VatRate        VatRate
+	VatAmount      pgtype.Numeric
+	GrossTotal     pgtype.Numeric
+}
+
+type Payment struct {
 	ID          int32
-	OrderNumber string
 	CustomerID  int32
-	OrderDate   pgtype.Timestamptz
-	Status      OrderStatus
-	TotalAmount pgtype.Numeric
+	Amount      pgtype.Numeric
+	PaymentDate pgtype.Date
+	Method      PaymentMethod
+	Reference   pgtype.Text
+	CreatedBy   pgtype.Int4
+	CreatedAt   pgtype.Timestamptz
 }
 
-type OrderItem struct {
+type PaymentAllocation struct {
+	ID        int32
+	PaymentID int32
+	InvoiceID int32
+	Amount    pgtype.Numeric
+	CreatedAt pgtype.Timestamptz
+}
+
+type OrderRevision struct {
+	ID          int32
+	OrderID     int32
+	Revision    int32
+	EditedBy    int32
+	EditedAt    pgtype.Timestamptz
+	TotalBefore pgtype.Numeric
+	TotalAfter  pgtype.Numeric
+}
+
+type OrderRevisionLine struct {
+	RevisionID     int32
+	ProductID      int32
+	QuantityBefore int32
+	QuantityAfter  int32
+}
+
+type PickListItem struct {
 	ID        int32
 	OrderID   int32
 	ProductID int32
+	LotID     int32
 	Quantity  int32
-	UnitPrice pgtype.Numeric
-	LineTotal pgtype.Numeric
+	CreatedAt pgtype.Timestamptz
+}
+
+type PriceList struct {
+	ID                int32
+	Name              string
+	CustomerCompanyID pgtype.Int4
+	CustomerGroupID   pgtype.Int4
+	ValidFrom         pgtype.Date
+	ValidTo           pgtype.Date
+	IsActive          bool
+	CreatedAt         pgtype.Timestamptz
+}
+
+type PriceListItem struct {
+	PriceListID int32
+	ProductID   int32
+	Price       pgtype.Numeric
 }
 
 type Product struct {
@@ -424,6 +1250,65 @@ type Product struct {
 	Unit         string
 	DefaultPrice pgtype.Numeric
 	IsActive     bool
+	VatRate      VatRate
+}
+
+type ProductSupplier struct {
+	ProductID        int32
+	SupplierID       int32
+	LeadTimeDays     int32
+	MinOrderQuantity int32
+	UnitPrice        pgtype.Numeric
+}
+
+type ProformaInvoice struct {
+	ID                 int32
+	OrderID            int32
+	ProformaNumber     string
+	IssueDate          pgtype.Timestamptz
+	DueDate            pgtype.Timestamptz
+	NetAmount          pgtype.Numeric
+	VatAmount          pgtype.Numeric
+	TotalAmount        pgtype.Numeric
+	Status             ProformaStatus
+	BuyerName          string
+	BuyerTaxID         string
+	BillingAddressLine string
+	BillingCity        string
+	BillingPostalCode  string
+	BillingCountry     string
+	PaidAt             pgtype.Timestamptz
+	PaymentReference   pgtype.Text
+	InvoiceID          pgtype.Int4
+}
+
+type ProformaInvoiceSequence struct {
+	Year       int32
+	LastNumber int32
+}
+
+type PurchaseOrder struct {
+	ID                        int32
+	OrderNumber               string
+	SupplierID                int32
+	WarehouseID               int32
+	Status                    PurchaseOrderStatus
+	OrderDate                 pgtype.Timestamptz
+	ExpectedDeliveryDate      pgtype.Date
+	OverDeliveryTolerancePct  int32
+	UnderDeliveryTolerancePct int32
+	Note                      pgtype.Text
+	CreatedBy                 int32
+}
+
+type PurchaseOrderLine struct {
+	ID                   int32
+	PurchaseOrderID      int32
+	ProductID            int32
+	OrderedQuantity      int32
+	ReceivedQuantity     int32
+	UnitPrice            pgtype.Numeric
+	ExpectedDeliveryDate pgtype.Date
 }
 
 type RefreshToken struct {
@@ -435,22 +1320,124 @@ type RefreshToken struct {
 	CreatedAt pgtype.Timestamptz
 }
 
-type Stock struct {
+type StandingOrder struct {
 	ID          int32
-	ProductID   int32
-	Quantity    int32
-	MinQuantity int32
+	CustomerID  int32
+	Name        string
+	AddressID   int32
+	WarehouseID pgtype.Int4
+	Weekdays    []int32
+	StartDate   pgtype.Date
+	EndDate     pgtype.Date
+	IsPaused    bool
+	CreatedBy   int32
+	CreatedAt   pgtype.Timestamptz
 }
 
-type StockMovement struct {
+type StandingOrderLine struct {
+	StandingOrderID int32
+	ProductID       int32
+	Quantity        int32
+}
+
+type StandingOrderOccurrence struct {
+	ID              int32
+	StandingOrderID int32
+	DeliveryDate    pgtype.Date
+	Status          StandingOrderOccurrenceStatus
+	CustomLines     bool
+	OrderID         pgtype.Int4
+	FailureReason   pgtype.Text
+	ProcessedAt     pgtype.Timestamptz
+}
+
+type StandingOrderOccurrenceLine struct {
+	OccurrenceID int32
+	ProductID    int32
+	Quantity     int32
+}
+
+type StandingOrderSkip struct {
+	StandingOrderID int32
+	SkipDate        pgtype.Date
+}
+
+type Stock struct {
+	ID               int32
+	ProductID        int32
+	Quantity         int32
+	MinQuantity      int32
+	ReservedQuantity int32
+	WarehouseID      int32
+}
+
+type StockLot struct {
 	ID             int32
 	ProductID      int32
-	QuantityChange int32
-	MovementType   MovementType
-	RelatedOrderID pgtype.Int4
-	Reason         pgtype.Text
+	BatchNumber    string
+	ProductionDate pgtype.Date
+	BestBefore     pgtype.Date
+	Quantity       int32
 	CreatedAt      pgtype.Timestamptz
-	EmployeeID     pgtype.Int4
+	WarehouseID    int32
+}
+
+type StockMovement struct {
+	ID                  int32
+	ProductID           int32
+	QuantityChange      int32
+	MovementType        MovementType
+	RelatedOrderID      pgtype.Int4
+	Reason              pgtype.Text
+	CreatedAt           pgtype.Timestamptz
+	EmployeeID          pgtype.Int4
+	OverrideReason      pgtype.Text
+	WarehouseID         int32
+	RelatedMovementID   pgtype.Int4
+	DocumentID          pgtype.Int4
+	PurchaseOrderLineID pgtype.Int4
+}
+
+type StockMovementLot struct {
+	ID             int32
+	MovementID     int32
+	LotID          int32
+	QuantityChange int32
+}
+
+type Stocktake struct {
+	ID          int32
+	WarehouseID int32
+	Status      StocktakeStatus
+	Note        pgtype.Text
+	OpenedBy    int32
+	OpenedAt    pgtype.Timestamptz
+	ClosedBy    pgtype.Int4
+	ClosedAt    pgtype.Timestamptz
+}
+
+type StocktakeCount struct {
+	ID               int32
+	StocktakeID      int32
+	ProductID        int32
+	LotID            pgtype.Int4
+	CountedQuantity  int32
+	ExpectedQuantity pgtype.Int4
+	CountedBy        int32
+	CountedAt        pgtype.Timestamptz
+}
+
+type Supplier struct {
+	ID                        int32
+	Name                      string
+	TaxID                     pgtype.Text
+	Email                     pgtype.Text
+	Phone                     pgtype.Text
+	Address                   pgtype.Text
+	OverDeliveryTolerancePct  int32
+	UnderDeliveryTolerancePct int32
+	IsActive                  bool
+	CreatedAt                 pgtype.Timestamptz
 }
 
 type UserAccount struct {
@@ -465,3 +1452,31 @@ type UserAccount struct {
 	EmployeeID        pgtype.Int4
 	PasswordChangedAt pgtype.Timestamptz
 }
+
+type Warehouse struct {
+	ID        int32
+	Code      string
+	Name      string
+	Address   pgtype.Text
+	IsActive  bool
+	CreatedAt pgtype.Timestamptz
+}
+
+type WarehouseDocument struct {
+	ID              int32
+	DocumentType    WarehouseDocumentType
+	DocumentNumber  string
+	WarehouseID     int32
+	IssueDate       pgtype.Timestamptz
+	SupplierName    pgtype.Text
+	RelatedOrderID  pgtype.Int4
+	Note            pgtype.Text
+	EmployeeID      pgtype.Int4
+	PurchaseOrderID pgtype.Int4
+}
+
+type WarehouseDocumentSequence struct {
+	DocumentType WarehouseDocumentType
+	Year         int32
+	LastNumber   int32
+}
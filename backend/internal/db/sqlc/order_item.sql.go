@@ -12,17 +12,26 @@ import (
 )
 
 const insertOrderItem = `-- name: InsertOrderItem :one
-INSERT INTO order_item(order_id, product_id, quantity, unit_price, line_total)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, order_id, product_id, quantity, unit_price, line_total
+INSERT INTO order_item(order_id, product_id, quantity, unit_price, line_total, price_source, price_list_id, list_price,
+                       discount_amount, discount_rule_id, vat_rate, vat_amount, gross_total)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, order_id, product_id, quantity, unit_price, line_total, price_source, price_list_id, list_price, discount_amount, discount_rule_id, vat_rate, vat_amount, gross_total
 `
 
 type InsertOrderItemParams struct {
-	OrderID   int32
-	ProductID int32
-	Quantity  int32
-	UnitPrice pgtype.Numeric
-	LineTotal pgtype.Numeric
+	OrderID        int32
+	ProductID      int32
+	Quantity       int32
+	UnitPrice      pgtype.Numeric
+	LineTotal      pgtype.Numeric
+	PriceSource    PriceSource
+	PriceListID    pgtype.Int4
+	ListPrice      pgtype.Numeric
+	DiscountAmount pgtype.Numeric
+	DiscountRuleID pgtype.Int4
+	VatRate        VatRate
+	VatAmount      pgtype.Numeric
+	GrossTotal     pgtype.Numeric
 }
 
 func (q *Queries) InsertOrderItem(ctx context.Context, arg InsertOrderItemParams) (OrderItem, error) {
@@ -32,6 +41,14 @@ func (q *Queries) InsertOrderItem(ctx context.Context, arg InsertOrderItemParams
 		arg.Quantity,
 		arg.UnitPrice,
 		arg.LineTotal,
+		arg.PriceSource,
+		arg.PriceListID,
+		arg.ListPrice,
+		arg.DiscountAmount,
+		arg.DiscountRuleID,
+		arg.VatRate,
+		arg.VatAmount,
+		arg.GrossTotal,
 	)
 	var i OrderItem
 	err := row.Scan(
@@ -41,6 +58,14 @@ func (q *Queries) InsertOrderItem(ctx context.Context, arg InsertOrderItemParams
 		&i.Quantity,
 		&i.UnitPrice,
 		&i.LineTotal,
+		&i.PriceSource,
+		&i.PriceListID,
+		&i.ListPrice,
+		&i.DiscountAmount,
+		&i.DiscountRuleID,
+		&i.VatRate,
+		&i.VatAmount,
+		&i.GrossTotal,
 	)
 	return i, err
 }
@@ -12,13 +12,32 @@ import (
 )
 
 const createOrder = `-- name: CreateOrder :one
-INSERT INTO orders(customer_id)
-VALUES ($1)
-RETURNING id, order_number, customer_id, order_date, status, total_amount
+INSERT INTO orders(customer_id, warehouse_id, shipping_address_id, shipping_address_line, shipping_city,
+                   shipping_postal_code, shipping_country)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, order_number, customer_id, order_date, status, total_amount, warehouse_id, shipping_address_id, shipping_address_line, shipping_city, shipping_postal_code, shipping_country
 `
 
-func (q *Queries) CreateOrder(ctx context.Context, customerID int32) (Order, error) {
-	row := q.db.QueryRow(ctx, createOrder, customerID)
+type CreateOrderParams struct {
+	CustomerID          int32
+	WarehouseID         int32
+	ShippingAddressID   pgtype.Int4
+	ShippingAddressLine pgtype.Text
+	ShippingCity        pgtype.Text
+	ShippingPostalCode  pgtype.Text
+	ShippingCountry     pgtype.Text
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, createOrder,
+		arg.CustomerID,
+		arg.WarehouseID,
+		arg.ShippingAddressID,
+		arg.ShippingAddressLine,
+		arg.ShippingCity,
+		arg.ShippingPostalCode,
+		arg.ShippingCountry,
+	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
@@ -27,23 +46,124 @@ func (q *Queries) CreateOrder(ctx context.Context, customerID int32) (Order, err
 		&i.OrderDate,
 		&i.Status,
 		&i.TotalAmount,
+		&i.WarehouseID,
+		&i.ShippingAddressID,
+		&i.ShippingAddressLine,
+		&i.ShippingCity,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
 	)
 	return i, err
 }
 
+const createOrderCreditHold = `-- name: CreateOrderCreditHold :exec
+INSERT INTO order_credit_hold(order_id, exposure, credit_limit)
+VALUES ($1, $2, $3)
+`
+
+type CreateOrderCreditHoldParams struct {
+	OrderID     int32
+	Exposure    pgtype.Numeric
+	CreditLimit pgtype.Numeric
+}
+
+func (q *Queries) CreateOrderCreditHold(ctx context.Context, arg CreateOrderCreditHoldParams) error {
+	_, err := q.db.Exec(ctx, createOrderCreditHold, arg.OrderID, arg.Exposure, arg.CreditLimit)
+	return err
+}
+
+const createOrderRevision = `-- name: CreateOrderRevision :one
+INSERT INTO order_revision (order_id, revision, edited_by, total_before, total_after)
+SELECT $1::int,
+       coalesce(max(revision), 0) + 1,
+       $2::int,
+       $3::numeric,
+       $4::numeric
+FROM order_revision
+WHERE order_id = $1::int
+RETURNING id
+`
+
+type CreateOrderRevisionParams struct {
+	OrderID     int32
+	EditedBy    int32
+	TotalBefore pgtype.Numeric
+	TotalAfter  pgtype.Numeric
+}
+
+func (q *Queries) CreateOrderRevision(ctx context.Context, arg CreateOrderRevisionParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createOrderRevision,
+		arg.OrderID,
+		arg.EditedBy,
+		arg.TotalBefore,
+		arg.TotalAfter,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createOrderRevisionLine = `-- name: CreateOrderRevisionLine :exec
+INSERT INTO order_revision_line (revision_id, product_id, quantity_before, quantity_after)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateOrderRevisionLineParams struct {
+	RevisionID     int32
+	ProductID      int32
+	QuantityBefore int32
+	QuantityAfter  int32
+}
+
+func (q *Queries) CreateOrderRevisionLine(ctx context.Context, arg CreateOrderRevisionLineParams) error {
+	_, err := q.db.Exec(ctx, createOrderRevisionLine,
+		arg.RevisionID,
+		arg.ProductID,
+		arg.QuantityBefore,
+		arg.QuantityAfter,
+	)
+	return err
+}
+
+const deleteOrderItems = `-- name: DeleteOrderItems :exec
+DELETE
+FROM order_item
+WHERE order_id = $1
+`
+
+func (q *Queries) DeleteOrderItems(ctx context.Context, orderID int32) error {
+	_, err := q.db.Exec(ctx, deleteOrderItems, orderID)
+	return err
+}
+
 const getOrderById = `-- name: GetOrderById :one
-SELECT id, order_number, customer_id, status, total_amount::text, order_date
+SELECT id,
+       order_number,
+       customer_id,
+       status,
+       total_amount::text,
+       order_date,
+       warehouse_id,
+       shipping_address_line,
+       shipping_city,
+       shipping_postal_code,
+       shipping_country
 FROM orders
 WHERE id = $1
 `
 
 type GetOrderByIdRow struct {
-	ID          int32
-	OrderNumber string
-	CustomerID  int32
-	Status      OrderStatus
-	TotalAmount string
-	OrderDate   pgtype.Timestamptz
+	ID                  int32
+	OrderNumber         string
+	CustomerID          int32
+	Status              OrderStatus
+	TotalAmount         string
+	OrderDate           pgtype.Timestamptz
+	WarehouseID         int32
+	ShippingAddressLine pgtype.Text
+	ShippingCity        pgtype.Text
+	ShippingPostalCode  pgtype.Text
+	ShippingCountry     pgtype.Text
 }
 
 func (q *Queries) GetOrderById(ctx context.Context, id int32) (GetOrderByIdRow, error) {
@@ -56,6 +176,11 @@ func (q *Queries) GetOrderById(ctx context.Context, id int32) (GetOrderByIdRow,
 		&i.Status,
 		&i.TotalAmount,
 		&i.OrderDate,
+		&i.WarehouseID,
+		&i.ShippingAddressLine,
+		&i.ShippingCity,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
 	)
 	return i, err
 }
@@ -63,22 +188,38 @@ func (q *Queries) GetOrderById(ctx context.Context, id int32) (GetOrderByIdRow,
 const getOrderItems = `-- name: GetOrderItems :many
 SELECT oi.id,
        oi.product_id,
-       p.name              AS product_name,
+       p.name                   AS product_name,
        oi.quantity,
-       oi.unit_price::text AS unit_price,
-       oi.line_total::text AS line_total
+       oi.unit_price::text      AS unit_price,
+       oi.line_total::text      AS line_total,
+       oi.price_source,
+       oi.price_list_id,
+       oi.list_price::text      AS list_price,
+       oi.discount_amount::text AS discount_amount,
+       oi.discount_rule_id,
+       oi.vat_rate,
+       oi.vat_amount::text      AS vat_amount,
+       oi.gross_total::text     AS gross_total
 FROM order_item oi
          JOIN product p ON oi.product_id = p.id
 WHERE oi.order_id = $1
 `
 
 type GetOrderItemsRow struct {
-	ID          int32
-	ProductID   int32
-	ProductName string
-	Quantity    int32
-	UnitPrice   string
-	LineTotal   string
+	ID             int32
+	ProductID      int32
+	ProductName    string
+	Quantity       int32
+	UnitPrice      string
+	LineTotal      string
+	PriceSource    PriceSource
+	PriceListID    pgtype.Int4
+	ListPrice      string
+	DiscountAmount string
+	DiscountRuleID pgtype.Int4
+	VatRate        VatRate
+	VatAmount      string
+	GrossTotal     string
 }
 
 func (q *Queries) GetOrderItems(ctx context.Context, orderID int32) ([]GetOrderItemsRow, error) {
@@ -97,6 +238,109 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int32) ([]GetOrderI
 			&i.Quantity,
 			&i.UnitPrice,
 			&i.LineTotal,
+			&i.PriceSource,
+			&i.PriceListID,
+			&i.ListPrice,
+			&i.DiscountAmount,
+			&i.DiscountRuleID,
+			&i.VatRate,
+			&i.VatAmount,
+			&i.GrossTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderRevisionLines = `-- name: ListOrderRevisionLines :many
+SELECT l.revision_id,
+       l.product_id,
+       p.name AS product_name,
+       l.quantity_before,
+       l.quantity_after
+FROM order_revision_line l
+         JOIN order_revision r ON r.id = l.revision_id
+         JOIN product p ON p.id = l.product_id
+WHERE r.order_id = $1
+ORDER BY l.revision_id, p.name
+`
+
+type ListOrderRevisionLinesRow struct {
+	RevisionID     int32
+	ProductID      int32
+	ProductName    string
+	QuantityBefore int32
+	QuantityAfter  int32
+}
+
+func (q *Queries) ListOrderRevisionLines(ctx context.Context, orderID int32) ([]ListOrderRevisionLinesRow, error) {
+	rows, err := q.db.Query(ctx, listOrderRevisionLines, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrderRevisionLinesRow
+	for rows.Next() {
+		var i ListOrderRevisionLinesRow
+		if err := rows.Scan(
+			&i.RevisionID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.QuantityBefore,
+			&i.QuantityAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrderRevisions = `-- name: ListOrderRevisions :many
+SELECT id,
+       revision,
+       edited_by,
+       edited_at,
+       total_before::text AS total_before,
+       total_after::text  AS total_after
+FROM order_revision
+WHERE order_id = $1
+ORDER BY revision DESC
+`
+
+type ListOrderRevisionsRow struct {
+	ID          int32
+	Revision    int32
+	EditedBy    int32
+	EditedAt    pgtype.Timestamptz
+	TotalBefore string
+	TotalAfter  string
+}
+
+func (q *Queries) ListOrderRevisions(ctx context.Context, orderID int32) ([]ListOrderRevisionsRow, error) {
+	rows, err := q.db.Query(ctx, listOrderRevisions, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrderRevisionsRow
+	for rows.Next() {
+		var i ListOrderRevisionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Revision,
+			&i.EditedBy,
+			&i.EditedAt,
+			&i.TotalBefore,
+			&i.TotalAfter,
 		); err != nil {
 			return nil, err
 		}
@@ -189,11 +433,34 @@ func (q *Queries) ListOrdersByCustomer(ctx context.Context, customerID int32) ([
 	return items, nil
 }
 
+const releaseOrderCreditHold = `-- name: ReleaseOrderCreditHold :execrows
+UPDATE order_credit_hold
+SET released_by   = $2,
+    released_at   = now(),
+    justification = $3
+WHERE order_id = $1
+  AND released_at IS NULL
+`
+
+type ReleaseOrderCreditHoldParams struct {
+	OrderID       int32
+	ReleasedBy    pgtype.Int4
+	Justification pgtype.Text
+}
+
+func (q *Queries) ReleaseOrderCreditHold(ctx context.Context, arg ReleaseOrderCreditHoldParams) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseOrderCreditHold, arg.OrderID, arg.ReleasedBy, arg.Justification)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const setOrderTotalAmount = `-- name: SetOrderTotalAmount :one
 UPDATE orders
 SET total_amount = $1
 WHERE id = $2
-RETURNING id, order_number, customer_id, order_date, status, total_amount
+RETURNING id, order_number, customer_id, order_date, status, total_amount, warehouse_id, shipping_address_id, shipping_address_line, shipping_city, shipping_postal_code, shipping_country
 `
 
 type SetOrderTotalAmountParams struct {
@@ -211,6 +478,12 @@ func (q *Queries) SetOrderTotalAmount(ctx context.Context, arg SetOrderTotalAmou
 		&i.OrderDate,
 		&i.Status,
 		&i.TotalAmount,
+		&i.WarehouseID,
+		&i.ShippingAddressID,
+		&i.ShippingAddressLine,
+		&i.ShippingCity,
+		&i.ShippingPostalCode,
+		&i.ShippingCountry,
 	)
 	return i, err
 }
@@ -15,7 +15,7 @@ const activateProduct = `-- name: ActivateProduct :one
 UPDATE product
 SET is_active = true
 WHERE id = $1
-RETURNING id, name, category, unit, default_price, is_active
+RETURNING id, name, category, unit, default_price, is_active, vat_rate
 `
 
 func (q *Queries) ActivateProduct(ctx context.Context, id int32) (Product, error) {
@@ -28,14 +28,15 @@ func (q *Queries) ActivateProduct(ctx context.Context, id int32) (Product, error
 		&i.Unit,
 		&i.DefaultPrice,
 		&i.IsActive,
+		&i.VatRate,
 	)
 	return i, err
 }
 
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO product (name, category, unit, default_price)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, category, unit, default_price, is_active
+INSERT INTO product (name, category, unit, default_price, vat_rate)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, category, unit, default_price, is_active, vat_rate
 `
 
 type CreateProductParams struct {
@@ -43,6 +44,7 @@ type CreateProductParams struct {
 	Category     string
 	Unit         string
 	DefaultPrice pgtype.Numeric
+	VatRate      VatRate
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -51,6 +53,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Category,
 		arg.Unit,
 		arg.DefaultPrice,
+		arg.VatRate,
 	)
 	var i Product
 	err := row.Scan(
@@ -60,6 +63,7 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.Unit,
 		&i.DefaultPrice,
 		&i.IsActive,
+		&i.VatRate,
 	)
 	return i, err
 }
@@ -68,7 +72,7 @@ const deactivateProduct = `-- name: DeactivateProduct :one
 UPDATE product
 SET is_active = false
 WHERE id = $1
-RETURNING id, name, category, unit, default_price, is_active
+RETURNING id, name, category, unit, default_price, is_active, vat_rate
 `
 
 func (q *Queries) DeactivateProduct(ctx context.Context, id int32) (Product, error) {
@@ -81,12 +85,13 @@ func (q *Queries) DeactivateProduct(ctx context.Context, id int32) (Product, err
 		&i.Unit,
 		&i.DefaultPrice,
 		&i.IsActive,
+		&i.VatRate,
 	)
 	return i, err
 }
 
 const getProductById = `-- name: GetProductById :one
-SELECT p.id, p.name, p.category, p.unit, p.default_price, p.is_active,
+SELECT p.id, p.name, p.category, p.unit, p.default_price, p.is_active, p.vat_rate,
        p.default_price::text                                                    AS default_price_text,
        coalesce(s.quantity, 0),
        coalesce(s.min_quantity, 0),
@@ -107,6 +112,7 @@ type GetProductByIdRow struct {
 	Unit             string
 	DefaultPrice     pgtype.Numeric
 	IsActive         bool
+	VatRate          VatRate
 	DefaultPriceText string
 	Quantity         int32
 	MinQuantity      int32
@@ -124,6 +130,7 @@ func (q *Queries) GetProductById(ctx context.Context, id int32) (GetProductByIdR
 		&i.Unit,
 		&i.DefaultPrice,
 		&i.IsActive,
+		&i.VatRate,
 		&i.DefaultPriceText,
 		&i.Quantity,
 		&i.MinQuantity,
@@ -134,7 +141,7 @@ func (q *Queries) GetProductById(ctx context.Context, id int32) (GetProductByIdR
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT p.id, p.name, p.category, p.unit, p.default_price, p.is_active,
+SELECT p.id, p.name, p.category, p.unit, p.default_price, p.is_active, p.vat_rate,
        p.default_price::text                                                    AS default_price_text,
        coalesce(s.quantity, 0),
        coalesce(s.min_quantity, 0),
@@ -154,6 +161,7 @@ type ListProductsRow struct {
 	Unit             string
 	DefaultPrice     pgtype.Numeric
 	IsActive         bool
+	VatRate          VatRate
 	DefaultPriceText string
 	Quantity         int32
 	MinQuantity      int32
@@ -177,6 +185,7 @@ func (q *Queries) ListProducts(ctx context.Context) ([]ListProductsRow, error) {
 			&i.Unit,
 			&i.DefaultPrice,
 			&i.IsActive,
+			&i.VatRate,
 			&i.DefaultPriceText,
 			&i.Quantity,
 			&i.MinQuantity,
@@ -198,9 +207,10 @@ UPDATE product
 SET name          = COALESCE($1, name),
     category      = COALESCE($2, category),
     unit          = COALESCE($3, unit),
-    default_price = COALESCE($4, default_price)
-WHERE id = $5
-RETURNING id, name, category, unit, default_price, is_active
+    default_price = COALESCE($4, default_price),
+    vat_rate      = COALESCE($5, vat_rate)
+WHERE id = $6
+RETURNING id, name, category, unit, default_price, is_active, vat_rate
 `
 
 type UpdateProductParams struct {
@@ -208,6 +218,7 @@ type UpdateProductParams struct {
 	Category     pgtype.Text
 	Unit         pgtype.Text
 	DefaultPrice pgtype.Numeric
+	VatRate      NullVatRate
 	ID           int32
 }
 
@@ -217,6 +228,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.Category,
 		arg.Unit,
 		arg.DefaultPrice,
+		arg.VatRate,
 		arg.ID,
 	)
 	var i Product
@@ -227,6 +239,7 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.Unit,
 		&i.DefaultPrice,
 		&i.IsActive,
+		&i.VatRate,
 	)
 	return i, err
 }
@@ -13,34 +13,49 @@ import (
 
 const createStockMovement = `-- name: CreateStockMovement :one
 INSERT INTO stock_movement (
+    warehouse_id,
     product_id,
     quantity_change,
     movement_type,
     related_order_id,
+    related_movement_id,
     reason,
-    employee_id
+    employee_id,
+    override_reason,
+    document_id,
+    purchase_order_line_id
 )
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, product_id, quantity_change, movement_type, related_order_id, reason, created_at, employee_id
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, product_id, quantity_change, movement_type, related_order_id, reason, created_at, employee_id, override_reason, warehouse_id, related_movement_id, document_id, purchase_order_line_id
 `
 
 type CreateStockMovementParams struct {
-	ProductID      int32
-	QuantityChange int32
-	MovementType   MovementType
-	RelatedOrderID pgtype.Int4
-	Reason         pgtype.Text
-	EmployeeID     pgtype.Int4
+	WarehouseID         int32
+	ProductID           int32
+	QuantityChange      int32
+	MovementType        MovementType
+	RelatedOrderID      pgtype.Int4
+	RelatedMovementID   pgtype.Int4
+	Reason              pgtype.Text
+	EmployeeID          pgtype.Int4
+	OverrideReason      pgtype.Text
+	DocumentID          pgtype.Int4
+	PurchaseOrderLineID pgtype.Int4
 }
 
 func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error) {
 	row := q.db.QueryRow(ctx, createStockMovement,
+		arg.WarehouseID,
 		arg.ProductID,
 		arg.QuantityChange,
 		arg.MovementType,
 		arg.RelatedOrderID,
+		arg.RelatedMovementID,
 		arg.Reason,
 		arg.EmployeeID,
+		arg.OverrideReason,
+		arg.DocumentID,
+		arg.PurchaseOrderLineID,
 	)
 	var i StockMovement
 	err := row.Scan(
@@ -52,37 +67,128 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMoveme
 		&i.Reason,
 		&i.CreatedAt,
 		&i.EmployeeID,
+		&i.OverrideReason,
+		&i.WarehouseID,
+		&i.RelatedMovementID,
+		&i.DocumentID,
+		&i.PurchaseOrderLineID,
+	)
+	return i, err
+}
+
+const getOrderReturnableQuantity = `-- name: GetOrderReturnableQuantity :one
+WITH locked_order AS (SELECT id
+                      FROM orders
+                      WHERE id = $1
+                          FOR UPDATE)
+SELECT o.id AS order_id,
+       COALESCE((SELECT SUM(oi.quantity)
+                 FROM order_item oi
+                 WHERE oi.order_id = o.id
+                   AND oi.product_id = $2), 0)::int AS ordered,
+       COALESCE((SELECT SUM(-sm.quantity_change)
+                 FROM stock_movement sm
+                 WHERE sm.related_order_id = o.id
+                   AND sm.product_id = $2
+                   AND sm.movement_type = 'DISPATCH'), 0)::int AS dispatched,
+       COALESCE((SELECT SUM(sm.quantity_change)
+                 FROM stock_movement sm
+                 WHERE sm.related_order_id = o.id
+                   AND sm.product_id = $2
+                   AND sm.movement_type = 'RETURN'), 0)::int AS returned
+FROM locked_order o
+`
+
+type GetOrderReturnableQuantityParams struct {
+	ID        int32
+	ProductID int32
+}
+
+type GetOrderReturnableQuantityRow struct {
+	OrderID    int32
+	Ordered    int32
+	Dispatched int32
+	Returned   int32
+}
+
+// Locks the order and sums how much of the product it ordered, had
+// dispatched and has had returned so far.
+func (q *Queries) GetOrderReturnableQuantity(ctx context.Context, arg GetOrderReturnableQuantityParams) (GetOrderReturnableQuantityRow, error) {
+	row := q.db.QueryRow(ctx, getOrderReturnableQuantity, arg.ID, arg.ProductID)
+	var i GetOrderReturnableQuantityRow
+	err := row.Scan(
+		&i.OrderID,
+		&i.Ordered,
+		&i.Dispatched,
+		&i.Returned,
+	)
+	return i, err
+}
+
+const getStockMovementById = `-- name: GetStockMovementById :one
+SELECT id, product_id, quantity_change, movement_type, related_order_id, reason, created_at, employee_id, override_reason, warehouse_id, related_movement_id, document_id, purchase_order_line_id
+FROM stock_movement
+WHERE id = $1
+`
+
+func (q *Queries) GetStockMovementById(ctx context.Context, id int32) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, getStockMovementById, id)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.QuantityChange,
+		&i.MovementType,
+		&i.RelatedOrderID,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.EmployeeID,
+		&i.OverrideReason,
+		&i.WarehouseID,
+		&i.RelatedMovementID,
+		&i.DocumentID,
+		&i.PurchaseOrderLineID,
 	)
 	return i, err
 }
 
 const getStockForUpdate = `-- name: GetStockForUpdate :one
-SELECT id, product_id, quantity, min_quantity
+SELECT id, product_id, quantity, min_quantity, reserved_quantity, warehouse_id
 FROM stock
-WHERE product_id = $1
+WHERE warehouse_id = $1
+  AND product_id = $2
     FOR UPDATE
 `
 
-func (q *Queries) GetStockForUpdate(ctx context.Context, productID int32) (Stock, error) {
-	row := q.db.QueryRow(ctx, getStockForUpdate, productID)
+type GetStockForUpdateParams struct {
+	WarehouseID int32
+	ProductID   int32
+}
+
+func (q *Queries) GetStockForUpdate(ctx context.Context, arg GetStockForUpdateParams) (Stock, error) {
+	row := q.db.QueryRow(ctx, getStockForUpdate, arg.WarehouseID, arg.ProductID)
 	var i Stock
 	err := row.Scan(
 		&i.ID,
 		&i.ProductID,
 		&i.Quantity,
 		&i.MinQuantity,
+		&i.ReservedQuantity,
+		&i.WarehouseID,
 	)
 	return i, err
 }
 
 const listStockMovements = `-- name: ListStockMovements :many
-SELECT id, product_id, quantity_change, movement_type, related_order_id, reason, created_at, employee_id
+SELECT id, product_id, quantity_change, movement_type, related_order_id, reason, created_at, employee_id, override_reason, warehouse_id, related_movement_id, document_id, purchase_order_line_id
 FROM stock_movement
+WHERE $1::int4 IS NULL
+   OR warehouse_id = $1::int4
 ORDER BY created_at DESC
 `
 
-func (q *Queries) ListStockMovements(ctx context.Context) ([]StockMovement, error) {
-	rows, err := q.db.Query(ctx, listStockMovements)
+func (q *Queries) ListStockMovements(ctx context.Context, warehouseID pgtype.Int4) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listStockMovements, warehouseID)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +205,11 @@ func (q *Queries) ListStockMovements(ctx context.Context) ([]StockMovement, erro
 			&i.Reason,
 			&i.CreatedAt,
 			&i.EmployeeID,
+			&i.OverrideReason,
+			&i.WarehouseID,
+			&i.RelatedMovementID,
+			&i.DocumentID,
+			&i.PurchaseOrderLineID,
 		); err != nil {
 			return nil, err
 		}
@@ -113,15 +224,31 @@ func (q *Queries) ListStockMovements(ctx context.Context) ([]StockMovement, erro
 const updateStockQuantity = `-- name: UpdateStockQuantity :exec
 UPDATE stock
 SET quantity = $2
-WHERE product_id = $1
+WHERE id = $1
 `
 
 type UpdateStockQuantityParams struct {
-	ProductID int32
-	Quantity  int32
+	ID       int32
+	Quantity int32
 }
 
 func (q *Queries) UpdateStockQuantity(ctx context.Context, arg UpdateStockQuantityParams) error {
-	_, err := q.db.Exec(ctx, updateStockQuantity, arg.ProductID, arg.Quantity)
+	_, err := q.db.Exec(ctx, updateStockQuantity, arg.ID, arg.Quantity)
+	return err
+}
+
+const updateStockReservedQuantity = `-- name: UpdateStockReservedQuantity :exec
+UPDATE stock
+SET reserved_quantity = $2
+WHERE id = $1
+`
+
+type UpdateStockReservedQuantityParams struct {
+	ID               int32
+	ReservedQuantity int32
+}
+
+func (q *Queries) UpdateStockReservedQuantity(ctx context.Context, arg UpdateStockReservedQuantityParams) error {
+	_, err := q.db.Exec(ctx, updateStockReservedQuantity, arg.ID, arg.ReservedQuantity)
 	return err
 }
@@ -7,64 +7,90 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createStock = `-- name: CreateStock :one
-INSERT INTO stock (product_id, quantity, min_quantity)
-VALUES ($1, $2, $3)
-RETURNING id, product_id, quantity, min_quantity
+INSERT INTO stock (warehouse_id, product_id, quantity, min_quantity)
+VALUES ($1, $2, $3, $4)
+RETURNING id, product_id, quantity, min_quantity, reserved_quantity, warehouse_id
 `
 
 type CreateStockParams struct {
+	WarehouseID int32
 	ProductID   int32
 	Quantity    int32
 	MinQuantity int32
 }
 
 func (q *Queries) CreateStock(ctx context.Context, arg CreateStockParams) (Stock, error) {
-	row := q.db.QueryRow(ctx, createStock, arg.ProductID, arg.Quantity, arg.MinQuantity)
+	row := q.db.QueryRow(ctx, createStock,
+		arg.WarehouseID,
+		arg.ProductID,
+		arg.Quantity,
+		arg.MinQuantity,
+	)
 	var i Stock
 	err := row.Scan(
 		&i.ID,
 		&i.ProductID,
 		&i.Quantity,
 		&i.MinQuantity,
+		&i.ReservedQuantity,
+		&i.WarehouseID,
 	)
 	return i, err
 }
 
 const getStockByProductId = `-- name: GetStockByProductId :one
-SELECT s.product_id,
+SELECT s.warehouse_id,
+       w.name                                                                   AS warehouse_name,
+       s.product_id,
        p.name                                                                   AS product_name,
        s.quantity,
+       s.reserved_quantity,
        p.category,
        s.min_quantity,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'LOSS'), 0)::int4   AS damaged_count,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'RETURN'), 0)::int4 AS return_count
 FROM stock s
          JOIN product p ON p.id = s.product_id
-         LEFT JOIN stock_movement m ON m.product_id = p.id
-WHERE s.product_id = $1
-GROUP BY s.product_id, p.name, p.category, s.quantity, s.min_quantity
+         JOIN warehouse w ON w.id = s.warehouse_id
+         LEFT JOIN stock_movement m ON m.product_id = s.product_id AND m.warehouse_id = s.warehouse_id
+WHERE s.warehouse_id = $1
+  AND s.product_id = $2
+GROUP BY s.warehouse_id, w.name, s.product_id, p.name, p.category, s.quantity, s.reserved_quantity, s.min_quantity
 `
 
+type GetStockByProductIdParams struct {
+	WarehouseID int32
+	ProductID   int32
+}
+
 type GetStockByProductIdRow struct {
-	ProductID    int32
-	ProductName  string
-	Quantity     int32
-	Category     string
-	MinQuantity  int32
-	DamagedCount int32
-	ReturnCount  int32
+	WarehouseID      int32
+	WarehouseName    string
+	ProductID        int32
+	ProductName      string
+	Quantity         int32
+	ReservedQuantity int32
+	Category         string
+	MinQuantity      int32
+	DamagedCount     int32
+	ReturnCount      int32
 }
 
-func (q *Queries) GetStockByProductId(ctx context.Context, productID int32) (GetStockByProductIdRow, error) {
-	row := q.db.QueryRow(ctx, getStockByProductId, productID)
+func (q *Queries) GetStockByProductId(ctx context.Context, arg GetStockByProductIdParams) (GetStockByProductIdRow, error) {
+	row := q.db.QueryRow(ctx, getStockByProductId, arg.WarehouseID, arg.ProductID)
 	var i GetStockByProductIdRow
 	err := row.Scan(
+		&i.WarehouseID,
+		&i.WarehouseName,
 		&i.ProductID,
 		&i.ProductName,
 		&i.Quantity,
+		&i.ReservedQuantity,
 		&i.Category,
 		&i.MinQuantity,
 		&i.DamagedCount,
@@ -74,32 +100,41 @@ func (q *Queries) GetStockByProductId(ctx context.Context, productID int32) (Get
 }
 
 const listStock = `-- name: ListStock :many
-SELECT s.product_id,
+SELECT s.warehouse_id,
+       w.name                                                                   AS warehouse_name,
+       s.product_id,
        p.name                                                                   AS product_name,
        p.category,
        s.quantity,
+       s.reserved_quantity,
        s.min_quantity,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'LOSS'), 0)::int4   AS damaged_count,
        coalesce(count(m.id) FILTER (WHERE m.movement_type = 'RETURN'), 0)::int4 AS return_count
 FROM stock s
          JOIN product p ON p.id = s.product_id
-         LEFT JOIN stock_movement m ON m.product_id = p.id
-GROUP BY s.product_id, p.name, p.category, s.quantity, s.min_quantity
-ORDER BY p.name
+         JOIN warehouse w ON w.id = s.warehouse_id
+         LEFT JOIN stock_movement m ON m.product_id = s.product_id AND m.warehouse_id = s.warehouse_id
+WHERE $1::int4 IS NULL
+   OR s.warehouse_id = $1::int4
+GROUP BY s.warehouse_id, w.name, s.product_id, p.name, p.category, s.quantity, s.reserved_quantity, s.min_quantity
+ORDER BY p.name, w.name
 `
 
 type ListStockRow struct {
-	ProductID    int32
-	ProductName  string
-	Category     string
-	Quantity     int32
-	MinQuantity  int32
-	DamagedCount int32
-	ReturnCount  int32
+	WarehouseID      int32
+	WarehouseName    string
+	ProductID        int32
+	ProductName      string
+	Category         string
+	Quantity         int32
+	ReservedQuantity int32
+	MinQuantity      int32
+	DamagedCount     int32
+	ReturnCount      int32
 }
 
-func (q *Queries) ListStock(ctx context.Context) ([]ListStockRow, error) {
-	rows, err := q.db.Query(ctx, listStock)
+func (q *Queries) ListStock(ctx context.Context, warehouseID pgtype.Int4) ([]ListStockRow, error) {
+	rows, err := q.db.Query(ctx, listStock, warehouseID)
 	if err != nil {
 		return nil, err
 	}
@@ -108,10 +143,13 @@ func (q *Queries) ListStock(ctx context.Context) ([]ListStockRow, error) {
 	for rows.Next() {
 		var i ListStockRow
 		if err := rows.Scan(
+			&i.WarehouseID,
+			&i.WarehouseName,
 			&i.ProductID,
 			&i.ProductName,
 			&i.Category,
 			&i.Quantity,
+			&i.ReservedQuantity,
 			&i.MinQuantity,
 			&i.DamagedCount,
 			&i.ReturnCount,
@@ -129,23 +167,27 @@ func (q *Queries) ListStock(ctx context.Context) ([]ListStockRow, error) {
 const updateStockByProductId = `-- name: UpdateStockByProductId :one
 UPDATE stock
 SET min_quantity = $1
-WHERE product_id = $2
-RETURNING id, product_id, quantity, min_quantity
+WHERE warehouse_id = $2
+  AND product_id = $3
+RETURNING id, product_id, quantity, min_quantity, reserved_quantity, warehouse_id
 `
 
 type UpdateStockByProductIdParams struct {
 	MinQuantity int32
+	WarehouseID int32
 	ProductID   int32
 }
 
 func (q *Queries) UpdateStockByProductId(ctx context.Context, arg UpdateStockByProductIdParams) (Stock, error) {
-	row := q.db.QueryRow(ctx, updateStockByProductId, arg.MinQuantity, arg.ProductID)
+	row := q.db.QueryRow(ctx, updateStockByProductId, arg.MinQuantity, arg.WarehouseID, arg.ProductID)
 	var i Stock
 	err := row.Scan(
 		&i.ID,
 		&i.ProductID,
 		&i.Quantity,
 		&i.MinQuantity,
+		&i.ReservedQuantity,
+		&i.WarehouseID,
 	)
 	return i, err
 }
@@ -0,0 +1,56 @@
+package discounts
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type DiscountRule struct {
+	Id           int32             `json:"id"`
+	Name         string            `json:"name"`
+	ProductId    *int32            `json:"productId"`
+	ProductName  *string           `json:"productName"`
+	Category     *string           `json:"category"`
+	DiscountType sqlc.DiscountType `json:"discountType"`
+	Value        decimal.Decimal   `json:"value"`
+	MinQuantity  int32             `json:"minQuantity"`
+	ValidFrom    *time.Time        `json:"validFrom"`
+	ValidTo      *time.Time        `json:"validTo"`
+	IsActive     bool              `json:"isActive"`
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+type ListDiscountRulesResponse struct {
+	DiscountRules []DiscountRule `json:"discountRules"`
+}
+
+// CreateDiscountRuleRequest describes a rule for exactly one of ProductId or
+// Category. A MinQuantity above one makes it a quantity break.
+type CreateDiscountRuleRequest struct {
+	Name         string            `json:"name" validate:"required,max=200"`
+	ProductId    *int32            `json:"productId"`
+	Category     *string           `json:"category" validate:"omitempty,max=100"`
+	DiscountType sqlc.DiscountType `json:"discountType" validate:"required,oneof=PERCENTAGE FIXED_AMOUNT"`
+	Value        decimal.Decimal   `json:"value" validate:"required,decimalpos"`
+	MinQuantity  *int32            `json:"minQuantity" validate:"omitempty,gt=0"`
+	ValidFrom    *time.Time        `json:"validFrom"`
+	ValidTo      *time.Time        `json:"validTo"`
+}
+
+type UpdateDiscountRuleRequest struct {
+	Name        *string          `json:"name" validate:"omitempty,max=200"`
+	Value       *decimal.Decimal `json:"value" validate:"omitempty"`
+	MinQuantity *int32           `json:"minQuantity" validate:"omitempty,gt=0"`
+	ValidFrom   *time.Time       `json:"validFrom"`
+	ValidTo     *time.Time       `json:"validTo"`
+	IsActive    *bool            `json:"isActive"`
+}
+
+// AppliedDiscount is what a rule takes off a single unit. RuleId is nil when
+// no rule applied.
+type AppliedDiscount struct {
+	UnitDiscount decimal.Decimal
+	RuleId       *int32
+}
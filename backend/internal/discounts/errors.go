@@ -0,0 +1,12 @@
+package discounts
+
+import "errors"
+
+var (
+	ErrDiscountRuleIdRequired = errors.New("discount rule id is required")
+	ErrInvalidDiscountRuleId  = errors.New("invalid discount rule id")
+	ErrDiscountRuleNotFound   = errors.New("discount rule not found")
+	ErrInvalidTarget          = errors.New("discount rule must target either a product or a category")
+	ErrInvalidDiscountValue   = errors.New("discount value must be positive and a percentage may not exceed 100")
+	ErrInvalidValidityRange   = errors.New("validTo must not be earlier than validFrom")
+)
@@ -0,0 +1,117 @@
+package discounts
+
+import (
+	"errors"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/products"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListDiscountRules(writer http.ResponseWriter, request *http.Request) {
+	rules, err := handler.service.ListDiscountRules(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListDiscountRulesResponse{DiscountRules: rules})
+}
+
+func (handler *Handler) GetDiscountRule(writer http.ResponseWriter, request *http.Request) {
+	ruleId, err := handler.extractDiscountRuleId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	rule, err := handler.service.GetDiscountRule(request.Context(), ruleId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, rule)
+}
+
+func (handler *Handler) CreateDiscountRule(writer http.ResponseWriter, request *http.Request) {
+	var body CreateDiscountRuleRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := handler.service.CreateDiscountRule(request.Context(), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, rule)
+}
+
+func (handler *Handler) UpdateDiscountRule(writer http.ResponseWriter, request *http.Request) {
+	ruleId, err := handler.extractDiscountRuleId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body UpdateDiscountRuleRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.UpdateDiscountRule(request.Context(), ruleId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractDiscountRuleId(request *http.Request) (int32, error) {
+	ruleIdStr := chi.URLParam(request, "discountRuleId")
+	if ruleIdStr == "" {
+		return 0, ErrDiscountRuleIdRequired
+	}
+
+	ruleId, err := strconv.Atoi(ruleIdStr)
+	if err != nil {
+		return 0, ErrInvalidDiscountRuleId
+	}
+
+	return int32(ruleId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrDiscountRuleIdRequired), errors.Is(err, ErrInvalidDiscountRuleId),
+		errors.Is(err, ErrInvalidTarget), errors.Is(err, ErrInvalidDiscountValue),
+		errors.Is(err, ErrInvalidValidityRange):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, ErrDiscountRuleNotFound), errors.Is(err, products.ErrProductNotFound):
+		return http.StatusNotFound, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,23 @@
+package discounts
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(discountsHandler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Get("/", discountsHandler.ListDiscountRules)
+		r.Get("/{discountRuleId}", discountsHandler.GetDiscountRule)
+		r.Post("/", discountsHandler.CreateDiscountRule)
+		r.Patch("/{discountRuleId}", discountsHandler.UpdateDiscountRule)
+	})
+
+	return router
+}
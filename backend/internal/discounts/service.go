@@ -0,0 +1,265 @@
+package discounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/products"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+type Service struct {
+	query *sqlc.Queries
+	pool  *pgxpool.Pool
+}
+
+func NewService(query *sqlc.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{query: query, pool: pool}
+}
+
+func (service *Service) ListDiscountRules(ctx context.Context) ([]DiscountRule, error) {
+	rows, err := service.query.ListDiscountRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]DiscountRule, len(rows))
+	for i, row := range rows {
+		rule, err := mapDiscountRule(sqlc.GetDiscountRuleByIdRow(row))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *rule
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetDiscountRule(ctx context.Context, ruleId int32) (*DiscountRule, error) {
+	return service.getDiscountRule(ctx, service.query, ruleId)
+}
+
+func (service *Service) CreateDiscountRule(ctx context.Context, request CreateDiscountRuleRequest) (*DiscountRule, error) {
+	if (request.ProductId == nil) == (request.Category == nil) {
+		return nil, ErrInvalidTarget
+	}
+
+	if err := checkValue(request.DiscountType, request.Value); err != nil {
+		return nil, err
+	}
+
+	if err := checkValidity(request.ValidFrom, request.ValidTo); err != nil {
+		return nil, err
+	}
+
+	minQuantity := int32(1)
+	if request.MinQuantity != nil {
+		minQuantity = *request.MinQuantity
+	}
+
+	value, err := db.DecimalToNumeric(request.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*DiscountRule, error) {
+		qtx := service.query.WithTx(tx)
+
+		if request.ProductId != nil {
+			if _, err := qtx.GetProductById(ctx, *request.ProductId); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil, products.ErrProductNotFound
+				}
+				return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+		}
+
+		rule, err := qtx.CreateDiscountRule(ctx, sqlc.CreateDiscountRuleParams{
+			Name:         request.Name,
+			ProductID:    db.ConvertToInt4(request.ProductId),
+			Category:     db.ConvertToText(request.Category),
+			DiscountType: request.DiscountType,
+			Value:        value,
+			MinQuantity:  minQuantity,
+			ValidFrom:    db.ConvertToDate(request.ValidFrom),
+			ValidTo:      db.ConvertToDate(request.ValidTo),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return service.getDiscountRule(ctx, qtx, rule.ID)
+	})
+}
+
+func (service *Service) UpdateDiscountRule(ctx context.Context, ruleId int32, request UpdateDiscountRuleRequest) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		current, err := service.getDiscountRule(ctx, qtx, ruleId)
+		if err != nil {
+			return err
+		}
+
+		params := sqlc.UpdateDiscountRuleParams{
+			Name:        db.ConvertToText(request.Name),
+			MinQuantity: db.ConvertToInt4(request.MinQuantity),
+			ValidFrom:   db.ConvertToDate(request.ValidFrom),
+			ValidTo:     db.ConvertToDate(request.ValidTo),
+			IsActive:    db.ConvertToBool(request.IsActive),
+			ID:          ruleId,
+		}
+
+		if request.Value != nil {
+			if err := checkValue(current.DiscountType, *request.Value); err != nil {
+				return err
+			}
+
+			params.Value, err = db.DecimalToNumeric(*request.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		validFrom := current.ValidFrom
+		if request.ValidFrom != nil {
+			validFrom = request.ValidFrom
+		}
+
+		validTo := current.ValidTo
+		if request.ValidTo != nil {
+			validTo = request.ValidTo
+		}
+
+		if err := checkValidity(validFrom, validTo); err != nil {
+			return err
+		}
+
+		if _, err := qtx.UpdateDiscountRule(ctx, params); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+// Apply picks the discount for an order line. Every active rule for the
+// product or its category that is in force on the given day and whose
+// minimum quantity the line reaches is a candidate; rules do not stack and the
+// one taking the most off the unit price wins. The discount never exceeds the
+// list price.
+func (service *Service) Apply(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	productId int32,
+	category string,
+	quantity int32,
+	listPrice decimal.Decimal,
+	on time.Time,
+) (*AppliedDiscount, error) {
+	rows, err := qtx.ListApplicableDiscountRules(ctx, sqlc.ListApplicableDiscountRulesParams{
+		ProductID: productId,
+		Category:  category,
+		Quantity:  quantity,
+		OnDate:    db.ConvertToDate(&on),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	best := &AppliedDiscount{UnitDiscount: decimal.Zero}
+	for _, row := range rows {
+		value, err := decimal.NewFromString(row.ValueText)
+		if err != nil {
+			return nil, err
+		}
+
+		unitDiscount := value
+		if row.DiscountType == sqlc.DiscountTypePERCENTAGE {
+			unitDiscount = listPrice.Mul(value).Div(hundred).Round(2)
+		}
+		unitDiscount = decimal.Min(unitDiscount, listPrice)
+
+		if unitDiscount.GreaterThan(best.UnitDiscount) {
+			best = &AppliedDiscount{UnitDiscount: unitDiscount, RuleId: &row.ID}
+		}
+	}
+
+	return best, nil
+}
+
+func (service *Service) getDiscountRule(ctx context.Context, qtx *sqlc.Queries, ruleId int32) (*DiscountRule, error) {
+	row, err := qtx.GetDiscountRuleById(ctx, ruleId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDiscountRuleNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return mapDiscountRule(row)
+}
+
+func checkValue(discountType sqlc.DiscountType, value decimal.Decimal) error {
+	if !value.IsPositive() {
+		return ErrInvalidDiscountValue
+	}
+
+	if discountType == sqlc.DiscountTypePERCENTAGE && value.GreaterThan(hundred) {
+		return ErrInvalidDiscountValue
+	}
+
+	return nil
+}
+
+func checkValidity(validFrom *time.Time, validTo *time.Time) error {
+	if validFrom != nil && validTo != nil && validTo.Before(*validFrom) {
+		return ErrInvalidValidityRange
+	}
+
+	return nil
+}
+
+func mapDiscountRule(row sqlc.GetDiscountRuleByIdRow) (*DiscountRule, error) {
+	value, err := decimal.NewFromString(row.ValueText)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &DiscountRule{
+		Id:           row.ID,
+		Name:         row.Name,
+		DiscountType: row.DiscountType,
+		Value:        value,
+		MinQuantity:  row.MinQuantity,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+
+	if row.ProductID.Valid {
+		rule.ProductId = &row.ProductID.Int32
+		rule.ProductName = &row.ProductName.String
+	}
+
+	if row.Category.Valid {
+		rule.Category = &row.Category.String
+	}
+
+	if row.ValidFrom.Valid {
+		rule.ValidFrom = &row.ValidFrom.Time
+	}
+
+	if row.ValidTo.Valid {
+		rule.ValidTo = &row.ValidTo.Time
+	}
+
+	return rule, nil
+}
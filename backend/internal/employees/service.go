@@ -31,14 +31,7 @@ func (service *Service) ListEmployees(ctx context.Context) ([]Employee, error) {
 
 	response := make([]Employee, len(employees))
 	for i, r := range employees {
-		response[i] = Employee{
-			ID:        r.ID,
-			FirstName: r.FirstName,
-			LastName:  r.LastName,
-			Position:  r.Position,
-			IsActive:  r.IsActive,
-			HireDate:  r.HireDate.Time,
-		}
+		response[i] = mapEmployee(r)
 	}
 	return response, nil
 }
@@ -52,14 +45,8 @@ func (service *Service) GetEmployee(ctx context.Context, employeeId int32) (*Emp
 		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
 	}
 
-	return &Employee{
-		ID:        employee.ID,
-		FirstName: employee.FirstName,
-		LastName:  employee.LastName,
-		Position:  employee.Position,
-		IsActive:  employee.IsActive,
-		HireDate:  employee.HireDate.Time,
-	}, nil
+	response := mapEmployee(employee)
+	return &response, nil
 }
 
 func (service *Service) CreateEmployee(ctx context.Context, request CreateEmployeeRequest) (*Employee, error) {
@@ -71,29 +58,25 @@ func (service *Service) CreateEmployee(ctx context.Context, request CreateEmploy
 			Time:  request.HireDate,
 			Valid: true,
 		},
+		WarehouseID: db.ConvertToInt4(request.WarehouseId),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
 	}
 
-	return &Employee{
-		ID:        employee.ID,
-		FirstName: employee.FirstName,
-		LastName:  employee.LastName,
-		Position:  employee.Position,
-		IsActive:  employee.IsActive,
-		HireDate:  employee.HireDate.Time,
-	}, nil
+	response := mapEmployee(employee)
+	return &response, nil
 }
 
 func (service *Service) UpdateEmployee(ctx context.Context, id int32, request UpdateEmployeeRequest) error {
 	if _, err := service.query.UpdateEmployee(ctx, sqlc.UpdateEmployeeParams{
-		ID:        id,
-		FirstName: db.ConvertToText(request.FirstName),
-		LastName:  db.ConvertToText(request.LastName),
-		Position:  db.ConvertToText(request.Position),
-		IsActive:  db.ConvertToBool(request.IsActive),
-		HireDate:  db.ConvertToTimestamptz(request.HireDate),
+		ID:          id,
+		FirstName:   db.ConvertToText(request.FirstName),
+		LastName:    db.ConvertToText(request.LastName),
+		Position:    db.ConvertToText(request.Position),
+		IsActive:    db.ConvertToBool(request.IsActive),
+		HireDate:    db.ConvertToTimestamptz(request.HireDate),
+		WarehouseID: db.ConvertToInt4(request.WarehouseId),
 	}); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrEmployeeNotFound
@@ -103,3 +86,20 @@ func (service *Service) UpdateEmployee(ctx context.Context, id int32, request Up
 
 	return nil
 }
+
+func mapEmployee(row sqlc.Employee) Employee {
+	employee := Employee{
+		ID:        row.ID,
+		FirstName: row.FirstName,
+		LastName:  row.LastName,
+		Position:  row.Position,
+		IsActive:  row.IsActive,
+		HireDate:  row.HireDate.Time,
+	}
+
+	if row.WarehouseID.Valid {
+		employee.WarehouseId = &row.WarehouseID.Int32
+	}
+
+	return employee
+}
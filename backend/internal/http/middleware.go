@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"mleczarnia/internal/db/sqlc"
 	"mleczarnia/internal/jwt"
 	"net/http"
@@ -9,11 +10,15 @@ import (
 	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
 )
 
 type ctxKey string
 
-const UserCtxKey ctxKey = "UserId"
+const (
+	UserCtxKey      ctxKey = "UserId"
+	WarehouseCtxKey ctxKey = "WarehouseId"
+)
 
 type Middleware struct {
 	tokenService *jwt.Service
@@ -141,6 +146,45 @@ func (middleware *Middleware) CheckBlockStatus() func(http.Handler) http.Handler
 	}
 }
 
+// ScopeWarehouse stores the site of a WAREHOUSE user whose employee is
+// assigned to one in the request context, so handlers can keep them to it.
+func (middleware *Middleware) ScopeWarehouse() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			claims, ok := request.Context().Value(UserCtxKey).(*jwt.Claims)
+			if !ok {
+				http.Error(writer, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			if claims.Role != string(sqlc.RoleWAREHOUSE) {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			warehouseId, err := middleware.queries.GetWarehouseIdForUserId(request.Context(), int32(claims.UserId))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				http.Error(writer, "internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			if !warehouseId.Valid {
+				next.ServeHTTP(writer, request)
+				return
+			}
+
+			ctx := context.WithValue(request.Context(), WarehouseCtxKey, warehouseId.Int32)
+			next.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
+// WarehouseScope returns the site the current user is limited to, if any.
+func WarehouseScope(ctx context.Context) (int32, bool) {
+	warehouseId, ok := ctx.Value(WarehouseCtxKey).(int32)
+	return warehouseId, ok
+}
+
 func (middleware *Middleware) extractAndValidateToken(r *http.Request) (*jwt.Claims, error) {
 	tokenString, err := extractBearerToken(r)
 	if err != nil {
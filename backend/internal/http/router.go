@@ -12,7 +12,9 @@ const timeout = 1 * time.Minute
 
 func Router(authRouter http.Handler, meRouter http.Handler, usersRouter http.Handler,
 	companiesRouter http.Handler, productsRouter http.Handler, warehouseRouter http.Handler,
-	ordersRouter http.Handler, invoicesRouter http.Handler, employeesRouter http.Handler) *chi.Mux {
+	ordersRouter http.Handler, invoicesRouter http.Handler, employeesRouter http.Handler,
+	suppliersRouter http.Handler, priceListsRouter http.Handler, customerGroupsRouter http.Handler,
+	discountsRouter http.Handler, companySettingsRouter http.Handler, paymentsRouter http.Handler) *chi.Mux {
 	router := chi.NewRouter()
 
 	router.Use(middleware.RequestID)
@@ -31,6 +33,12 @@ func Router(authRouter http.Handler, meRouter http.Handler, usersRouter http.Han
 		router.Mount("/orders", ordersRouter)
 		router.Mount("/invoices", invoicesRouter)
 		router.Mount("/employees", employeesRouter)
+		router.Mount("/suppliers", suppliersRouter)
+		router.Mount("/price-lists", priceListsRouter)
+		router.Mount("/customer-groups", customerGroupsRouter)
+		router.Mount("/discounts", discountsRouter)
+		router.Mount("/company-settings", companySettingsRouter)
+		router.Mount("/payments", paymentsRouter)
 
 		router.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
 			w.WriteHeader(http.StatusOK)
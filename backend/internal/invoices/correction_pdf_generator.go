@@ -0,0 +1,82 @@
+package invoices
+
+import (
+	"fmt"
+	"mleczarnia/internal/companysettings"
+	"mleczarnia/internal/pdfutil"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// GenerateCorrectionPDF renders a corrective invoice. The parties are taken
+// from the original invoice, as they stood when it was issued.
+func GenerateCorrectionPDF(data CorrectionDetails, invoice InvoiceDetails, logo *companysettings.Logo) ([]byte, error) {
+	pdf := pdfutil.New()
+
+	addLogo(pdf, logo)
+	addCorrectionHeader(pdf, data)
+	addPartiesSection(pdf, invoice)
+	addCorrectionLinesTable(pdf, data.Lines)
+	addVatSummary(pdf, data.VatSummary, data.NetAmount, data.VatAmount, data.TotalAmount)
+	addCorrectionTotals(pdf, data)
+
+	return pdfutil.Output(pdf)
+}
+
+func addCorrectionHeader(pdf *gofpdf.Fpdf, data CorrectionDetails) {
+	pdf.SetFont("JuliaMono", "B", 18)
+	pdf.Cell(0, 10, "Faktura korygująca nr: "+data.CorrectionNumber)
+	pdf.Ln(12)
+
+	pdf.SetFont("JuliaMono", "", 10)
+	pdf.Cell(0, 6, fmt.Sprintf("Do faktury nr %s z dnia %s", data.InvoiceNumber, data.InvoiceIssueDate.Format("2006-01-02")))
+	pdf.Ln(5)
+
+	pdf.Cell(0, 6, fmt.Sprintf("Data wystawienia: %s", data.IssueDate.Format("2006-01-02")))
+	pdf.Ln(5)
+
+	pdf.MultiCell(0, 6, fmt.Sprintf("Przyczyna korekty: %s", data.Reason), "", "", false)
+	pdf.Ln(4)
+}
+
+func addCorrectionLinesTable(pdf *gofpdf.Fpdf, lines []CorrectionLine) {
+	pdf.SetFont("JuliaMono", "B", 7)
+
+	headers := []string{"Nazwa towaru", "VAT", "Ilość przed", "Ilość po", "Cena przed", "Cena po", "Zmiana netto", "Zmiana VAT", "Zmiana brutto"}
+	widths := []float64{40, 10, 14, 14, 20, 20, 22, 20, 20}
+
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("JuliaMono", "", 7)
+
+	for _, l := range lines {
+		pdf.CellFormat(widths[0], 6, l.ProductName, "1", 0, "", false, 0, "")
+		pdf.CellFormat(widths[1], 6, rateLabel(l.VatRate), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[2], 6, fmt.Sprintf("%d %s", l.QuantityBefore, l.Unit), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[3], 6, fmt.Sprintf("%d %s", l.QuantityAfter, l.Unit), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[4], 6, l.UnitPriceBefore, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], 6, l.UnitPriceAfter, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[6], 6, l.NetChange, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[7], 6, l.VatChange, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[8], 6, l.GrossChange, "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	pdf.Ln(8)
+}
+
+func addCorrectionTotals(pdf *gofpdf.Fpdf, data CorrectionDetails) {
+	label := "Do zapłaty:"
+	if strings.HasPrefix(data.TotalAmount, "-") {
+		label = "Do zwrotu:"
+	}
+
+	pdf.SetFont("JuliaMono", "B", 11)
+	pdf.Cell(140, 8, label)
+	pdf.CellFormat(40, 8, strings.TrimPrefix(data.TotalAmount, "-"), "1", 0, "R", false, 0, "")
+	pdf.Ln(12)
+}
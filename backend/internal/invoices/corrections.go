@@ -0,0 +1,416 @@
+package invoices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/vat"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// lineChange is the new state of one invoice line. Nil fields keep the
+// current value.
+type lineChange struct {
+	orderItemId int32
+	quantity    *int32
+	unitPrice   *decimal.Decimal
+}
+
+func (service *Service) ListCorrections(
+	ctx context.Context,
+	invoiceId int32,
+	role sqlc.Role,
+	userId int32,
+) (*[]CorrectionListItem, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*[]CorrectionListItem, error) {
+		qtx := service.query.WithTx(tx)
+
+		invoice, err := qtx.GetInvoiceWithOrder(ctx, invoiceId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrInvoiceNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := checkCustomerAccess(ctx, qtx, invoice.CustomerID, role, userId); err != nil {
+			return nil, err
+		}
+
+		rows, err := qtx.ListCorrectiveInvoicesForInvoice(ctx, invoiceId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		result := make([]CorrectionListItem, len(rows))
+		for i, r := range rows {
+			result[i] = CorrectionListItem{
+				Id:               r.ID,
+				CorrectionNumber: r.CorrectionNumber,
+				IssueDate:        r.IssueDate.Time,
+				Reason:           r.Reason,
+				StockMovementId:  optionalInt(r.StockMovementID),
+				NetAmount:        r.NetAmount,
+				VatAmount:        r.VatAmount,
+				TotalAmount:      r.TotalAmount,
+			}
+		}
+
+		return &result, nil
+	})
+}
+
+func (service *Service) GetCorrection(
+	ctx context.Context,
+	correctionId int32,
+	role sqlc.Role,
+	userId int32,
+) (*CorrectionDetails, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*CorrectionDetails, error) {
+		qtx := service.query.WithTx(tx)
+
+		correction, err := qtx.GetCorrectiveInvoiceById(ctx, correctionId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrCorrectionNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := checkCustomerAccess(ctx, qtx, correction.CustomerID, role, userId); err != nil {
+			return nil, err
+		}
+
+		lines, err := qtx.ListCorrectiveInvoiceLines(ctx, correctionId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		details := CorrectionDetails{
+			Id:               correction.ID,
+			CorrectionNumber: correction.CorrectionNumber,
+			IssueDate:        correction.IssueDate.Time,
+			Reason:           correction.Reason,
+			InvoiceId:        correction.InvoiceID,
+			InvoiceNumber:    correction.InvoiceNumber,
+			InvoiceIssueDate: correction.InvoiceIssueDate.Time,
+			StockMovementId:  optionalInt(correction.StockMovementID),
+			NetAmount:        correction.NetAmount,
+			VatAmount:        correction.VatAmount,
+			TotalAmount:      correction.TotalAmount,
+		}
+
+		summary := vat.NewSummary()
+		for _, l := range lines {
+			details.Lines = append(details.Lines, CorrectionLine{
+				OrderItemId:     l.OrderItemID,
+				ProductName:     l.ProductName,
+				Unit:            l.Unit,
+				VatRate:         l.VatRate,
+				QuantityBefore:  l.QuantityBefore,
+				QuantityAfter:   l.QuantityAfter,
+				UnitPriceBefore: l.UnitPriceBefore,
+				UnitPriceAfter:  l.UnitPriceAfter,
+				NetChange:       l.NetChange,
+				VatChange:       l.VatChange,
+				GrossChange:     l.GrossChange,
+			})
+
+			net, err := decimal.NewFromString(l.NetChange)
+			if err != nil {
+				return nil, ErrFailedToCreateDecimal
+			}
+			summary.Add(l.VatRate, net)
+		}
+
+		// the summary is not stored; it is rebuilt the same way the totals
+		// were computed when the correction was issued
+		for _, line := range summary.Lines() {
+			details.VatSummary = append(details.VatSummary, VatSummaryLine{
+				VatRate:     line.Rate,
+				NetAmount:   line.Net.StringFixed(2),
+				VatAmount:   line.Vat.StringFixed(2),
+				GrossAmount: line.Gross.StringFixed(2),
+			})
+		}
+
+		return &details, nil
+	})
+}
+
+// CreateCorrection issues a corrective invoice setting new quantities and
+// prices on lines of an invoice.
+func (service *Service) CreateCorrection(
+	ctx context.Context,
+	invoiceId int32,
+	userId int32,
+	req CreateCorrectionRequest,
+) (*int32, error) {
+	changes := make([]lineChange, len(req.Lines))
+	for i, l := range req.Lines {
+		if l.UnitPrice != nil && l.UnitPrice.IsNegative() {
+			return nil, ErrNegativePrice
+		}
+		changes[i] = lineChange{orderItemId: l.OrderItemId, quantity: l.Quantity, unitPrice: l.UnitPrice}
+	}
+
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*int32, error) {
+		qtx := service.query.WithTx(tx)
+
+		return service.createCorrection(ctx, qtx, invoiceId, userId, req.Reason, nil, changes)
+	})
+}
+
+// CreateCorrectionFromReturn credits a RETURN movement on the invoice of the
+// order it was returned from. The returned quantity is taken off the
+// product's lines starting from the last one.
+func (service *Service) CreateCorrectionFromReturn(ctx context.Context, movementId int32, userId int32) (*int32, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*int32, error) {
+		qtx := service.query.WithTx(tx)
+
+		movement, err := qtx.GetStockMovementById(ctx, movementId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrMovementNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if movement.MovementType != sqlc.MovementTypeRETURN || !movement.RelatedOrderID.Valid {
+			return nil, ErrNotReturnMovement
+		}
+
+		corrected, err := qtx.CorrectiveInvoiceExistsForMovement(ctx, pgtype.Int4{Int32: movementId, Valid: true})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if corrected {
+			return nil, ErrReturnAlreadyCorrected
+		}
+
+		invoice, err := qtx.GetInvoiceByOrderId(ctx, movement.RelatedOrderID.Int32)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotInvoiced
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		lines, err := qtx.ListCorrectableInvoiceLines(ctx, invoice.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		var changes []lineChange
+		remaining := movement.QuantityChange
+		for i := len(lines) - 1; i >= 0 && remaining > 0; i-- {
+			line := lines[i]
+			if line.ProductID != movement.ProductID || line.Quantity == 0 {
+				continue
+			}
+
+			taken := min(remaining, line.Quantity)
+			quantity := line.Quantity - taken
+			changes = append(changes, lineChange{orderItemId: line.OrderItemID, quantity: &quantity})
+			remaining -= taken
+		}
+
+		if remaining > 0 {
+			return nil, ErrReturnExceedsInvoiced
+		}
+
+		reason := "Zwrot towaru"
+		if movement.Reason.Valid && movement.Reason.String != "" {
+			reason += ": " + movement.Reason.String
+		}
+		if runes := []rune(reason); len(runes) > 255 {
+			reason = string(runes[:255])
+		}
+
+		return service.createCorrection(ctx, qtx, invoice.ID, userId, reason, &movementId, changes)
+	})
+}
+
+func (service *Service) createCorrection(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	invoiceId int32,
+	userId int32,
+	reason string,
+	movementId *int32,
+	changes []lineChange,
+) (*int32, error) {
+	// locking the invoice keeps two corrections from being computed against
+	// the same before values
+	if _, err := qtx.GetInvoiceForUpdate(ctx, invoiceId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	rows, err := qtx.ListCorrectableInvoiceLines(ctx, invoiceId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	current := make(map[int32]sqlc.ListCorrectableInvoiceLinesRow, len(rows))
+	for _, r := range rows {
+		current[r.OrderItemID] = r
+	}
+
+	var params []sqlc.CreateCorrectiveInvoiceLineParams
+	summary := vat.NewSummary()
+	seen := make(map[int32]bool, len(changes))
+	for _, change := range changes {
+		line, ok := current[change.orderItemId]
+		if !ok {
+			return nil, ErrLineNotOnInvoice
+		}
+		if seen[change.orderItemId] {
+			return nil, ErrDuplicateLine
+		}
+		seen[change.orderItemId] = true
+
+		priceBefore, err := decimal.NewFromString(line.UnitPrice)
+		if err != nil {
+			return nil, ErrFailedToCreateDecimal
+		}
+
+		quantityAfter := line.Quantity
+		if change.quantity != nil {
+			quantityAfter = *change.quantity
+		}
+		priceAfter := priceBefore
+		if change.unitPrice != nil {
+			priceAfter = change.unitPrice.Round(2)
+		}
+
+		if quantityAfter == line.Quantity && priceAfter.Equal(priceBefore) {
+			continue
+		}
+
+		netBefore := priceBefore.Mul(decimal.NewFromInt32(line.Quantity))
+		netAfter := priceAfter.Mul(decimal.NewFromInt32(quantityAfter))
+		netChange := netAfter.Sub(netBefore)
+		vatChange := vat.Amount(netAfter, line.VatRate).Sub(vat.Amount(netBefore, line.VatRate))
+
+		unitPriceBefore, err := db.DecimalToNumeric(priceBefore)
+		if err != nil {
+			return nil, err
+		}
+
+		unitPriceAfter, err := db.DecimalToNumeric(priceAfter)
+		if err != nil {
+			return nil, err
+		}
+
+		netChangeNumeric, err := db.DecimalToNumeric(netChange)
+		if err != nil {
+			return nil, err
+		}
+
+		vatChangeNumeric, err := db.DecimalToNumeric(vatChange)
+		if err != nil {
+			return nil, err
+		}
+
+		grossChangeNumeric, err := db.DecimalToNumeric(netChange.Add(vatChange))
+		if err != nil {
+			return nil, err
+		}
+
+		params = append(params, sqlc.CreateCorrectiveInvoiceLineParams{
+			OrderItemID:     line.OrderItemID,
+			VatRate:         line.VatRate,
+			QuantityBefore:  line.Quantity,
+			QuantityAfter:   quantityAfter,
+			UnitPriceBefore: unitPriceBefore,
+			UnitPriceAfter:  unitPriceAfter,
+			NetChange:       netChangeNumeric,
+			VatChange:       vatChangeNumeric,
+			GrossChange:     grossChangeNumeric,
+		})
+		summary.Add(line.VatRate, netChange)
+	}
+
+	if len(params) == 0 {
+		return nil, ErrEmptyCorrection
+	}
+
+	net, tax, gross := vat.Totals(summary.Lines())
+
+	netAmount, err := db.DecimalToNumeric(net)
+	if err != nil {
+		return nil, err
+	}
+
+	vatAmount, err := db.DecimalToNumeric(tax)
+	if err != nil {
+		return nil, err
+	}
+
+	totalAmount, err := db.DecimalToNumeric(gross)
+	if err != nil {
+		return nil, err
+	}
+
+	correction, err := qtx.CreateCorrectiveInvoice(ctx, sqlc.CreateCorrectiveInvoiceParams{
+		InvoiceID:       invoiceId,
+		Reason:          reason,
+		StockMovementID: db.ConvertToInt4(movementId),
+		NetAmount:       netAmount,
+		VatAmount:       vatAmount,
+		TotalAmount:     totalAmount,
+		CreatedBy:       userId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	for _, p := range params {
+		p.CorrectiveInvoiceID = correction.ID
+		if err := qtx.CreateCorrectiveInvoiceLine(ctx, p); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	// the correction changes what is due, so it may settle the invoice or
+	// reopen it
+	if err := service.payments.RefreshInvoiceStatus(ctx, qtx, invoiceId); err != nil {
+		return nil, err
+	}
+
+	return &correction.ID, nil
+}
+
+// checkCustomerAccess lets clients see only their own company's documents.
+func checkCustomerAccess(ctx context.Context, qtx *sqlc.Queries, customerId int32, role sqlc.Role, userId int32) error {
+	if role != sqlc.RoleCLIENT {
+		return nil
+	}
+
+	companyId, err := qtx.GetCompanyIdForUserId(ctx, userId)
+	if err != nil {
+		return ErrFailedToGetCompanyIdForUser
+	}
+
+	if customerId != companyId.Int32 {
+		return ErrInvoiceForbidden
+	}
+
+	return nil
+}
+
+func optionalInt(value pgtype.Int4) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int32
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"mleczarnia/internal/db/sqlc"
+
+	"github.com/shopspring/decimal"
 )
 
 type ListInvoicesResponse struct {
@@ -20,27 +22,139 @@ type InvoiceListItem struct {
 }
 
 type InvoiceDetails struct {
-	Id            int32              `json:"id"`
-	InvoiceNumber string             `json:"invoiceNumber"`
-	IssueDate     time.Time          `json:"issueDate"`
-	DueDate       time.Time          `json:"dueDate"`
-	Status        sqlc.InvoiceStatus `json:"status"`
-	CompanyName   string             `json:"companyName"`
-	TaxId         string             `json:"taxId"`
-	Email         string             `json:"email"`
-	OrderNumber   string             `json:"orderNumber"`
-	Items         []InvoiceItem      `json:"items"`
-	TotalAmount   string             `json:"totalAmount"`
+	Id             int32              `json:"id"`
+	InvoiceNumber  string             `json:"invoiceNumber"`
+	IssueDate      time.Time          `json:"issueDate"`
+	DueDate        time.Time          `json:"dueDate"`
+	Status         sqlc.InvoiceStatus `json:"status"`
+	Seller         *Seller            `json:"seller"`
+	CompanyName    string             `json:"companyName"`
+	TaxId          string             `json:"taxId"`
+	BillingAddress *Address           `json:"billingAddress"`
+	Email          string             `json:"email"`
+	OrderNumber    string             `json:"orderNumber"`
+	Items          []InvoiceItem      `json:"items"`
+	VatSummary     []VatSummaryLine   `json:"vatSummary"`
+	NetAmount      string             `json:"netAmount"`
+	VatAmount      string             `json:"vatAmount"`
+	TotalAmount    string             `json:"totalAmount"`
+	// AmountDue is the total after corrections.
+	AmountDue         string `json:"amountDue"`
+	AmountPaid        string `json:"amountPaid"`
+	AmountOutstanding string `json:"amountOutstanding"`
+	// Reminders are the payment reminders issued while the invoice was
+	// overdue.
+	Reminders []Reminder `json:"reminders"`
+}
+
+type Reminder struct {
+	Stage             int32     `json:"stage"`
+	DaysOverdue       int32     `json:"daysOverdue"`
+	Recipient         string    `json:"recipient"`
+	AmountOutstanding string    `json:"amountOutstanding"`
+	SentAt            time.Time `json:"sentAt"`
+}
+
+// Seller holds our company details as they were when the invoice was issued.
+// It is nil on invoices issued before the company settings existed.
+type Seller struct {
+	Name        string  `json:"name"`
+	TaxId       string  `json:"taxId"`
+	Regon       *string `json:"regon,omitempty"`
+	AddressLine string  `json:"addressLine"`
+	City        string  `json:"city"`
+	PostalCode  string  `json:"postalCode"`
+	Country     string  `json:"country"`
+	Iban        string  `json:"iban"`
+	BankName    *string `json:"bankName,omitempty"`
+	LogoId      *int32  `json:"-"`
+}
+
+type Address struct {
+	AddressLine string `json:"addressLine"`
+	City        string `json:"city"`
+	PostalCode  string `json:"postalCode"`
+	Country     string `json:"country"`
 }
 
 type InvoiceItem struct {
-	ProductName string `json:"productName"`
-	Unit        string `json:"unit"`
-	Quantity    int32  `json:"quantity"`
-	UnitPrice   string `json:"unitPrice"`
-	LineTotal   string `json:"lineTotal"`
+	ProductName string       `json:"productName"`
+	Unit        string       `json:"unit"`
+	Quantity    int32        `json:"quantity"`
+	UnitPrice   string       `json:"unitPrice"`
+	LineTotal   string       `json:"lineTotal"`
+	VatRate     sqlc.VatRate `json:"vatRate"`
+	VatAmount   string       `json:"vatAmount"`
+	GrossTotal  string       `json:"grossTotal"`
+}
+
+type VatSummaryLine struct {
+	VatRate     sqlc.VatRate `json:"vatRate"`
+	NetAmount   string       `json:"netAmount"`
+	VatAmount   string       `json:"vatAmount"`
+	GrossAmount string       `json:"grossAmount"`
 }
 
 type UpdateInvoiceStatusRequest struct {
-	Status sqlc.InvoiceStatus `json:"status" validate:"required,oneof=PAID UNPAID OVERDUE"`
+	Status sqlc.InvoiceStatus `json:"status" validate:"required,oneof=OVERDUE"`
+}
+
+type ListCorrectionsResponse struct {
+	Corrections []CorrectionListItem `json:"corrections"`
+}
+
+type CorrectionListItem struct {
+	Id               int32     `json:"id"`
+	CorrectionNumber string    `json:"correctionNumber"`
+	IssueDate        time.Time `json:"issueDate"`
+	Reason           string    `json:"reason"`
+	StockMovementId  *int32    `json:"stockMovementId"`
+	NetAmount        string    `json:"netAmount"`
+	VatAmount        string    `json:"vatAmount"`
+	TotalAmount      string    `json:"totalAmount"`
+}
+
+// CorrectionDetails is a corrective invoice. Its amounts are the change it
+// makes to the original invoice, negative when the invoice goes down.
+type CorrectionDetails struct {
+	Id               int32            `json:"id"`
+	CorrectionNumber string           `json:"correctionNumber"`
+	IssueDate        time.Time        `json:"issueDate"`
+	Reason           string           `json:"reason"`
+	InvoiceId        int32            `json:"invoiceId"`
+	InvoiceNumber    string           `json:"invoiceNumber"`
+	InvoiceIssueDate time.Time        `json:"invoiceIssueDate"`
+	StockMovementId  *int32           `json:"stockMovementId"`
+	Lines            []CorrectionLine `json:"lines"`
+	VatSummary       []VatSummaryLine `json:"vatSummary"`
+	NetAmount        string           `json:"netAmount"`
+	VatAmount        string           `json:"vatAmount"`
+	TotalAmount      string           `json:"totalAmount"`
+}
+
+type CorrectionLine struct {
+	OrderItemId     int32        `json:"orderItemId"`
+	ProductName     string       `json:"productName"`
+	Unit            string       `json:"unit"`
+	VatRate         sqlc.VatRate `json:"vatRate"`
+	QuantityBefore  int32        `json:"quantityBefore"`
+	QuantityAfter   int32        `json:"quantityAfter"`
+	UnitPriceBefore string       `json:"unitPriceBefore"`
+	UnitPriceAfter  string       `json:"unitPriceAfter"`
+	NetChange       string       `json:"netChange"`
+	VatChange       string       `json:"vatChange"`
+	GrossChange     string       `json:"grossChange"`
+}
+
+type CreateCorrectionRequest struct {
+	Reason string                  `json:"reason" validate:"required,max=255"`
+	Lines  []CorrectionLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// CorrectionLineRequest sets the new quantity and/or unit net price of an
+// invoice line. Fields left out keep their current value.
+type CorrectionLineRequest struct {
+	OrderItemId int32            `json:"orderItemId" validate:"required"`
+	Quantity    *int32           `json:"quantity" validate:"omitempty,gte=0"`
+	UnitPrice   *decimal.Decimal `json:"unitPrice"`
 }
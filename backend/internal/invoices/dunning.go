@@ -0,0 +1,110 @@
+package invoices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// DunningPolicy says when overdue invoices get reminders.
+type DunningPolicy struct {
+	// ReminderDays are days past the due date, in ascending order. The n-th
+	// entry is reminder stage n.
+	ReminderDays []int32
+}
+
+// RunDunning marks invoices past their due date OVERDUE and logs the
+// reminders that have fallen due.
+// An invoice that is first seen long after its due date gets only the latest
+// reminder it has reached, not the whole sequence at once. An invoice whose
+// reminder fails is logged and does not stop the others.
+func (service *Service) RunDunning(ctx context.Context) error {
+	overdue, err := service.query.MarkOverdueInvoices(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	for _, invoice := range overdue {
+		logrus.WithFields(logrus.Fields{
+			"invoiceId":     invoice.ID,
+			"invoiceNumber": invoice.InvoiceNumber,
+		}).Info("Invoice marked overdue")
+	}
+
+	invoices, err := service.query.ListOverdueInvoicesForDunning(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	var errs []error
+	for _, invoice := range invoices {
+		stage := service.dunning.stage(invoice.DaysOverdue)
+		if stage <= invoice.LastStage {
+			continue
+		}
+
+		fields := logrus.Fields{
+			"invoiceId":     invoice.ID,
+			"invoiceNumber": invoice.InvoiceNumber,
+			"stage":         stage,
+		}
+
+		outstanding, err := service.issueReminder(ctx, invoice, stage)
+		if err != nil {
+			logrus.WithError(err).WithFields(fields).Warn("Failed to issue payment reminder")
+			errs = append(errs, fmt.Errorf("invoice %d: %w", invoice.ID, err))
+			continue
+		}
+
+		logrus.WithFields(fields).WithFields(logrus.Fields{
+			"daysOverdue":       invoice.DaysOverdue,
+			"recipient":         invoice.MainEmail,
+			"amountOutstanding": outstanding.StringFixed(2),
+		}).Info("Payment reminder issued")
+	}
+
+	return errors.Join(errs...)
+}
+
+// issueReminder records the stage reminder for invoice and returns the amount
+// it asks for.
+func (service *Service) issueReminder(ctx context.Context, invoice sqlc.ListOverdueInvoicesForDunningRow, stage int32) (decimal.Decimal, error) {
+	outstanding, err := decimal.NewFromString(invoice.AmountOutstanding)
+	if err != nil {
+		return decimal.Zero, ErrFailedToCreateDecimal
+	}
+
+	amount, err := db.DecimalToNumeric(outstanding)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if err := service.query.CreateDunningReminder(ctx, sqlc.CreateDunningReminderParams{
+		InvoiceID:         invoice.ID,
+		Stage:             stage,
+		DaysOverdue:       invoice.DaysOverdue,
+		Recipient:         invoice.MainEmail,
+		AmountOutstanding: amount,
+	}); err != nil {
+		return decimal.Zero, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return outstanding, nil
+}
+
+// stage returns the latest reminder stage reached after daysOverdue, or 0
+// if none has.
+func (policy DunningPolicy) stage(daysOverdue int32) int32 {
+	var stage int32
+	for i, days := range policy.ReminderDays {
+		if daysOverdue >= days {
+			stage = int32(i + 1)
+		}
+	}
+	return stage
+}
@@ -13,4 +13,24 @@ var (
 	ErrInvalidInvoiceId            = errors.New("invalid invoice id")
 	ErrOrderIdRequired             = errors.New("order id required")
 	ErrInvalidOrderId              = errors.New("invalid order id")
+	ErrOrderNotFound               = errors.New("order not found")
+	ErrBillingAddressMissing       = errors.New("company has no billing address")
+	ErrOrderOnHold                 = errors.New("order is on credit hold")
+)
+
+var (
+	ErrCorrectionIdRequired   = errors.New("correction id required")
+	ErrInvalidCorrectionId    = errors.New("invalid correction id")
+	ErrCorrectionNotFound     = errors.New("corrective invoice not found")
+	ErrMovementIdRequired     = errors.New("movement id required")
+	ErrInvalidMovementId      = errors.New("invalid movement id")
+	ErrMovementNotFound       = errors.New("movement not found")
+	ErrLineNotOnInvoice       = errors.New("order item is not on this invoice")
+	ErrDuplicateLine          = errors.New("order item corrected more than once")
+	ErrNegativePrice          = errors.New("unit price cannot be negative")
+	ErrEmptyCorrection        = errors.New("correction does not change any line")
+	ErrNotReturnMovement      = errors.New("movement is not a return linked to an order")
+	ErrReturnAlreadyCorrected = errors.New("return already has a corrective invoice")
+	ErrOrderNotInvoiced       = errors.New("order has not been invoiced")
+	ErrReturnExceedsInvoiced  = errors.New("returned quantity exceeds invoiced quantity")
 )
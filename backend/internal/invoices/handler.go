@@ -2,10 +2,12 @@ package invoices
 
 import (
 	"errors"
+	"mleczarnia/internal/companysettings"
 	"mleczarnia/internal/db/sqlc"
 	"mleczarnia/internal/jwt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	app "mleczarnia/internal/http"
 	"mleczarnia/internal/httputil"
@@ -65,7 +67,13 @@ func (handler *Handler) GetInvoicePdf(writer http.ResponseWriter, request *http.
 		return
 	}
 
-	invoicePdfBytes, err := GenerateInvoicePDF(*invoice)
+	logo, err := handler.service.GetSellerLogo(request.Context(), *invoice)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	invoicePdfBytes, err := GenerateInvoicePDF(*invoice, logo)
 	if err != nil {
 		handler.handleServiceError(writer, err)
 		return
@@ -113,6 +121,130 @@ func (handler *Handler) UpdateStatus(writer http.ResponseWriter, request *http.R
 	writer.WriteHeader(http.StatusNoContent)
 }
 
+func (handler *Handler) ListCorrections(writer http.ResponseWriter, request *http.Request) {
+	invoiceId, err := handler.extractInvoiceId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	corrections, err := handler.service.ListCorrections(request.Context(), invoiceId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListCorrectionsResponse{Corrections: *corrections})
+}
+
+func (handler *Handler) GetCorrection(writer http.ResponseWriter, request *http.Request) {
+	correctionId, err := handler.extractCorrectionId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	correction, err := handler.service.GetCorrection(request.Context(), correctionId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, *correction)
+}
+
+func (handler *Handler) GetCorrectionPdf(writer http.ResponseWriter, request *http.Request) {
+	correctionId, err := handler.extractCorrectionId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	correction, err := handler.service.GetCorrection(request.Context(), correctionId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	invoice, err := handler.service.GetInvoiceById(request.Context(), correction.InvoiceId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	logo, err := handler.service.GetSellerLogo(request.Context(), *invoice)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	correctionPdfBytes, err := GenerateCorrectionPDF(*correction, *invoice, logo)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/pdf")
+	writer.Header().Set("Content-Disposition", "inline; filename="+strings.ReplaceAll(correction.CorrectionNumber, "/", "-")+".pdf")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(correctionPdfBytes)
+}
+
+func (handler *Handler) CreateCorrection(writer http.ResponseWriter, request *http.Request) {
+	invoiceId, err := handler.extractInvoiceId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	var body CreateCorrectionRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	correctionId, err := handler.service.CreateCorrection(request.Context(), invoiceId, int32(claims.UserId), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	handler.writeCreatedCorrection(writer, request, *correctionId)
+}
+
+func (handler *Handler) CreateCorrectionFromReturn(writer http.ResponseWriter, request *http.Request) {
+	movementId, err := handler.extractMovementId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	correctionId, err := handler.service.CreateCorrectionFromReturn(request.Context(), movementId, int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	handler.writeCreatedCorrection(writer, request, *correctionId)
+}
+
+func (handler *Handler) writeCreatedCorrection(writer http.ResponseWriter, request *http.Request, correctionId int32) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	correction, err := handler.service.GetCorrection(request.Context(), correctionId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, *correction)
+}
+
 func (handler *Handler) extractInvoiceId(request *http.Request) (int32, error) {
 	invoiceIdStr := chi.URLParam(request, "invoiceId")
 	if invoiceIdStr == "" {
@@ -141,6 +273,34 @@ func (handler *Handler) extractOrderId(request *http.Request) (int32, error) {
 	return int32(orderId), nil
 }
 
+func (handler *Handler) extractCorrectionId(request *http.Request) (int32, error) {
+	correctionIdStr := chi.URLParam(request, "correctionId")
+	if correctionIdStr == "" {
+		return 0, ErrCorrectionIdRequired
+	}
+
+	correctionId, err := strconv.Atoi(correctionIdStr)
+	if err != nil {
+		return 0, ErrInvalidCorrectionId
+	}
+
+	return int32(correctionId), nil
+}
+
+func (handler *Handler) extractMovementId(request *http.Request) (int32, error) {
+	movementIdStr := chi.URLParam(request, "movementId")
+	if movementIdStr == "" {
+		return 0, ErrMovementIdRequired
+	}
+
+	movementId, err := strconv.Atoi(movementIdStr)
+	if err != nil {
+		return 0, ErrInvalidMovementId
+	}
+
+	return int32(movementId), nil
+}
+
 func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
 	statusCode, message := handler.mapErrorToResponse(err)
 	logrus.WithError(err).Info()
@@ -150,7 +310,34 @@ func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error
 // TODO: error handling
 func (handler *Handler) mapErrorToResponse(err error) (int, string) {
 	switch {
-	case errors.Is(err, ErrInvalidStatusChange):
+	case errors.Is(err, ErrInvalidStatusChange),
+		errors.Is(err, ErrOrderOnHold):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, companysettings.ErrSettingsNotConfigured),
+		errors.Is(err, ErrBillingAddressMissing):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, ErrOrderNotFound),
+		errors.Is(err, ErrInvoiceNotFound),
+		errors.Is(err, ErrCorrectionNotFound),
+		errors.Is(err, ErrMovementNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrInvoiceForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, ErrInvoiceIdRequired),
+		errors.Is(err, ErrInvalidInvoiceId),
+		errors.Is(err, ErrCorrectionIdRequired),
+		errors.Is(err, ErrInvalidCorrectionId),
+		errors.Is(err, ErrMovementIdRequired),
+		errors.Is(err, ErrInvalidMovementId),
+		errors.Is(err, ErrLineNotOnInvoice),
+		errors.Is(err, ErrDuplicateLine),
+		errors.Is(err, ErrNegativePrice),
+		errors.Is(err, ErrEmptyCorrection),
+		errors.Is(err, ErrNotReturnMovement):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, ErrReturnAlreadyCorrected),
+		errors.Is(err, ErrOrderNotInvoiced),
+		errors.Is(err, ErrReturnExceedsInvoiced):
 		return http.StatusConflict, err.Error()
 
 	default:
@@ -3,35 +3,48 @@ package invoices
 import (
 	"bytes"
 	"fmt"
+	"mleczarnia/internal/companysettings"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/pdfutil"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
-func GenerateInvoicePDF(data InvoiceDetails) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetMargins(15, 20, 15)
-	pdf.AddPage()
+func GenerateInvoicePDF(data InvoiceDetails, logo *companysettings.Logo) ([]byte, error) {
+	pdf := pdfutil.New()
 
-	pdf.AddUTF8Font("JuliaMono", "", "internal/assets/fonts/JuliaMono-Regular.ttf")
-	pdf.AddUTF8Font("JuliaMono", "B", "internal/assets/fonts/JuliaMono-Bold.ttf")
+	addLogo(pdf, logo)
+	addHeader(pdf, "Faktura nr: ", data)
+	addPartiesSection(pdf, data)
+	addItemsTable(pdf, data.Items)
+	addVatSummary(pdf, data.VatSummary, data.NetAmount, data.VatAmount, data.TotalAmount)
+	addTotals(pdf, data)
+
+	return pdfutil.Output(pdf)
+}
 
-	addHeader(pdf, data)
-	addCompanySection(pdf, data)
+// GenerateProformaPDF renders a pro-forma with the invoice layout. It asks
+// for payment but is not a VAT invoice, which the footer says.
+func GenerateProformaPDF(data InvoiceDetails, logo *companysettings.Logo) ([]byte, error) {
+	pdf := pdfutil.New()
+
+	addLogo(pdf, logo)
+	addHeader(pdf, "Faktura pro forma nr: ", data)
+	addPartiesSection(pdf, data)
 	addItemsTable(pdf, data.Items)
+	addVatSummary(pdf, data.VatSummary, data.NetAmount, data.VatAmount, data.TotalAmount)
 	addTotals(pdf, data)
 
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, err
-	}
+	pdf.SetFont("JuliaMono", "", 9)
+	pdf.Cell(0, 5, "Dokument nie jest fakturą VAT. Realizacja zamówienia nastąpi po zaksięgowaniu wpłaty.")
+	pdf.Ln(5)
 
-	return buf.Bytes(), nil
+	return pdfutil.Output(pdf)
 }
 
-func addHeader(pdf *gofpdf.Fpdf, data InvoiceDetails) {
+func addHeader(pdf *gofpdf.Fpdf, title string, data InvoiceDetails) {
 	pdf.SetFont("JuliaMono", "B", 20)
-	pdf.Cell(0, 10, "Faktura nr: "+data.InvoiceNumber)
+	pdf.Cell(0, 10, title+data.InvoiceNumber)
 	pdf.Ln(12)
 
 	pdf.SetFont("JuliaMono", "", 10)
@@ -43,32 +56,90 @@ func addHeader(pdf *gofpdf.Fpdf, data InvoiceDetails) {
 	pdf.Ln(10)
 }
 
-func addCompanySection(pdf *gofpdf.Fpdf, data InvoiceDetails) {
+// addLogo puts the logo in the top right corner, scaled to a fixed height.
+func addLogo(pdf *gofpdf.Fpdf, logo *companysettings.Logo) {
+	if logo == nil {
+		return
+	}
+
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	if logo.ContentType == "image/jpeg" {
+		options.ImageType = "JPG"
+	}
+
+	info := pdf.RegisterImageOptionsReader("logo", options, bytes.NewReader(logo.Data))
+	if info == nil || info.Height() == 0 {
+		return
+	}
+
+	height := 18.0
+	width := height * info.Width() / info.Height()
+	pageWidth, _ := pdf.GetPageSize()
+	_, _, right, _ := pdf.GetMargins()
+
+	pdf.ImageOptions("logo", pageWidth-right-width, 10, width, height, false, options, 0, "")
+}
+
+func addPartiesSection(pdf *gofpdf.Fpdf, data InvoiceDetails) {
+	var seller []string
+	if data.Seller != nil {
+		seller = append(seller,
+			data.Seller.Name,
+			data.Seller.AddressLine,
+			fmt.Sprintf("%s %s, %s", data.Seller.PostalCode, data.Seller.City, data.Seller.Country),
+			fmt.Sprintf("NIP: %s", data.Seller.TaxId),
+		)
+		if data.Seller.Regon != nil {
+			seller = append(seller, fmt.Sprintf("REGON: %s", *data.Seller.Regon))
+		}
+		if data.Seller.BankName != nil {
+			seller = append(seller, fmt.Sprintf("Bank: %s", *data.Seller.BankName))
+		}
+		seller = append(seller, fmt.Sprintf("Konto: %s", companysettings.FormatIban(data.Seller.Iban)))
+	}
+
+	buyer := []string{data.CompanyName}
+	if data.BillingAddress != nil {
+		buyer = append(buyer,
+			data.BillingAddress.AddressLine,
+			fmt.Sprintf("%s %s, %s", data.BillingAddress.PostalCode, data.BillingAddress.City, data.BillingAddress.Country),
+		)
+	}
+	buyer = append(buyer, fmt.Sprintf("NIP: %s", data.TaxId), data.Email)
+
 	pdf.SetFont("JuliaMono", "B", 11)
+	pdf.Cell(90, 6, "Sprzedawca:")
 	pdf.Cell(0, 6, "Nabywca:")
 	pdf.Ln(6)
 
-	pdf.SetFont("JuliaMono", "", 10)
-	pdf.Cell(0, 5, data.CompanyName)
-	pdf.Ln(5)
-	pdf.Cell(0, 5, fmt.Sprintf("NIP: %s", data.TaxId))
+	pdf.SetFont("JuliaMono", "", 9)
+	for i := 0; i < max(len(seller), len(buyer)); i++ {
+		pdf.Cell(90, 5, lineAt(seller, i))
+		pdf.Cell(0, 5, lineAt(buyer, i))
+		pdf.Ln(5)
+	}
 	pdf.Ln(5)
-	pdf.Cell(0, 5, data.Email)
-	pdf.Ln(10)
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
 }
 
 func addItemsTable(pdf *gofpdf.Fpdf, items []InvoiceItem) {
-	pdf.SetFont("JuliaMono", "B", 10)
+	pdf.SetFont("JuliaMono", "B", 8)
 
-	headers := []string{"Nazwa towaru", "Jednostka", "Ilość", "Cena jedn.", "Wartość"}
-	widths := []float64{70, 20, 15, 35, 30}
+	headers := []string{"Nazwa towaru", "J.m.", "Ilość", "Cena netto", "Wartość netto", "VAT", "Kwota VAT", "Brutto"}
+	widths := []float64{46, 12, 12, 22, 24, 12, 24, 28}
 
 	for i, h := range headers {
 		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", false, 0, "")
 	}
 	pdf.Ln(-1)
 
-	pdf.SetFont("JuliaMono", "", 10)
+	pdf.SetFont("JuliaMono", "", 8)
 
 	for _, it := range items {
 		pdf.CellFormat(widths[0], 6, it.ProductName, "1", 0, "", false, 0, "")
@@ -76,15 +147,62 @@ func addItemsTable(pdf *gofpdf.Fpdf, items []InvoiceItem) {
 		pdf.CellFormat(widths[2], 6, fmt.Sprintf("%d", it.Quantity), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(widths[3], 6, it.UnitPrice, "1", 0, "R", false, 0, "")
 		pdf.CellFormat(widths[4], 6, it.LineTotal, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], 6, rateLabel(it.VatRate), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[6], 6, it.VatAmount, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[7], 6, it.GrossTotal, "1", 0, "R", false, 0, "")
 		pdf.Ln(-1)
 	}
 
 	pdf.Ln(8)
 }
 
+func addVatSummary(pdf *gofpdf.Fpdf, summary []VatSummaryLine, net, tax, total string) {
+	pdf.SetFont("JuliaMono", "B", 10)
+
+	headers := []string{"Stawka VAT", "Netto", "VAT", "Brutto"}
+	widths := []float64{30, 30, 30, 30}
+	// right-aligned under the 180mm items table
+	offset := 60.0
+
+	pdf.SetX(pdf.GetX() + offset)
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("JuliaMono", "", 10)
+
+	for _, line := range summary {
+		pdf.SetX(pdf.GetX() + offset)
+		pdf.CellFormat(widths[0], 6, rateLabel(line.VatRate), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[1], 6, line.NetAmount, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], 6, line.VatAmount, "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 6, line.GrossAmount, "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	pdf.SetFont("JuliaMono", "B", 10)
+	pdf.SetX(pdf.GetX() + offset)
+	pdf.CellFormat(widths[0], 6, "Razem", "1", 0, "C", false, 0, "")
+	pdf.CellFormat(widths[1], 6, net, "1", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[2], 6, tax, "1", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[3], 6, total, "1", 0, "R", false, 0, "")
+	pdf.Ln(-1)
+
+	pdf.Ln(8)
+}
+
 func addTotals(pdf *gofpdf.Fpdf, data InvoiceDetails) {
 	pdf.SetFont("JuliaMono", "B", 11)
-	pdf.Cell(140, 8, "Razem:")
+	pdf.Cell(140, 8, "Do zapłaty:")
 	pdf.CellFormat(40, 8, data.TotalAmount, "1", 0, "R", false, 0, "")
 	pdf.Ln(12)
 }
+
+// rateLabel prints a rate the way it appears on Polish invoices.
+func rateLabel(rate sqlc.VatRate) string {
+	if rate == sqlc.VatRateZW {
+		return "zw"
+	}
+	return string(rate) + "%"
+}
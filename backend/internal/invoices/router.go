@@ -15,11 +15,16 @@ func Router(handler *Handler, middleware *app.Middleware) *chi.Mux {
 		r.Get("/", handler.ListInvoices)
 		r.Get("/{invoiceId}", handler.GetInvoiceById)
 		r.Get("/{invoiceId}/pdf", handler.GetInvoicePdf)
+		r.Get("/{invoiceId}/corrections", handler.ListCorrections)
+		r.Get("/corrections/{correctionId}", handler.GetCorrection)
+		r.Get("/corrections/{correctionId}/pdf", handler.GetCorrectionPdf)
 	})
 
 	router.Group(func(r chi.Router) {
 		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
 		r.Patch("/{invoiceId}/status", handler.UpdateStatus)
+		r.Post("/{invoiceId}/corrections", handler.CreateCorrection)
+		r.Post("/corrections/returns/{movementId}", handler.CreateCorrectionFromReturn)
 	})
 
 	return router
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mleczarnia/internal/companysettings"
 	"mleczarnia/internal/db"
+	"mleczarnia/internal/payments"
 	"time"
 
 	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/vat"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -16,12 +19,27 @@ import (
 )
 
 type Service struct {
-	query *sqlc.Queries
-	pool  *pgxpool.Pool
+	query           *sqlc.Queries
+	pool            *pgxpool.Pool
+	companySettings *companysettings.Service
+	payments        *payments.Service
+	dunning         DunningPolicy
 }
 
-func NewService(queries *sqlc.Queries, pool *pgxpool.Pool) *Service {
-	return &Service{query: queries, pool: pool}
+func NewService(
+	queries *sqlc.Queries,
+	pool *pgxpool.Pool,
+	companySettingsService *companysettings.Service,
+	paymentsService *payments.Service,
+	dunning DunningPolicy,
+) *Service {
+	return &Service{
+		query:           queries,
+		pool:            pool,
+		companySettings: companySettingsService,
+		payments:        paymentsService,
+		dunning:         dunning,
+	}
 }
 
 func (service *Service) ListInvoices(
@@ -121,11 +139,23 @@ func (service *Service) GetInvoiceById(
 			IssueDate:     invoice.IssueDate.Time,
 			DueDate:       invoice.DueDate.Time,
 			Status:        invoice.Status,
-			CompanyName:   invoice.CompanyName,
-			TaxId:         invoice.TaxID,
+			CompanyName:   invoice.BuyerName,
+			TaxId:         invoice.BuyerTaxID,
 			Email:         invoice.MainEmail,
 			OrderNumber:   invoice.OrderNumber,
+			NetAmount:     invoice.NetAmountStr,
+			VatAmount:     invoice.VatAmountStr,
 			TotalAmount:   invoice.TotalAmountStr,
+			Seller:        mapSeller(invoice),
+		}
+
+		if invoice.BillingAddressLine.Valid {
+			details.BillingAddress = &Address{
+				AddressLine: invoice.BillingAddressLine.String,
+				City:        invoice.BillingCity.String,
+				PostalCode:  invoice.BillingPostalCode.String,
+				Country:     invoice.BillingCountry.String,
+			}
 		}
 
 		for _, it := range items {
@@ -135,9 +165,61 @@ func (service *Service) GetInvoiceById(
 				Quantity:    it.Quantity,
 				UnitPrice:   it.UnitPrice,
 				LineTotal:   it.LineTotal,
+				VatRate:     it.VatRate,
+				VatAmount:   it.VatAmount,
+				GrossTotal:  it.GrossTotal,
 			})
 		}
 
+		summary, err := qtx.ListInvoiceVatSummary(ctx, invoice.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range summary {
+			details.VatSummary = append(details.VatSummary, VatSummaryLine{
+				VatRate:     line.VatRate,
+				NetAmount:   line.NetAmount,
+				VatAmount:   line.VatAmount,
+				GrossAmount: line.GrossAmount,
+			})
+		}
+
+		balance, err := qtx.GetInvoiceBalance(ctx, invoice.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		due, err := decimal.NewFromString(balance.AmountDue)
+		if err != nil {
+			return nil, ErrFailedToCreateDecimal
+		}
+
+		paid, err := decimal.NewFromString(balance.AmountPaid)
+		if err != nil {
+			return nil, ErrFailedToCreateDecimal
+		}
+
+		details.AmountDue = due.StringFixed(2)
+		details.AmountPaid = paid.StringFixed(2)
+		details.AmountOutstanding = decimal.Max(due.Sub(paid), decimal.Zero).StringFixed(2)
+
+		reminders, err := qtx.ListDunningReminders(ctx, invoice.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		details.Reminders = make([]Reminder, len(reminders))
+		for i, reminder := range reminders {
+			details.Reminders[i] = Reminder{
+				Stage:             reminder.Stage,
+				DaysOverdue:       reminder.DaysOverdue,
+				Recipient:         reminder.Recipient,
+				AmountOutstanding: reminder.AmountOutstanding,
+				SentAt:            reminder.SentAt.Time,
+			}
+		}
+
 		return &details, nil
 	})
 }
@@ -146,50 +228,117 @@ func (service *Service) CreateInvoiceForOrder(ctx context.Context, orderId int32
 	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
 		qtx := service.query.WithTx(tx)
 
-		//TODO: change order status
+		_, err := service.IssueInvoice(ctx, qtx, orderId)
+		return err
+	})
+}
 
-		exists, err := qtx.InvoiceExistsForOrder(ctx, orderId)
-		if err != nil {
-			return err
-		}
-		if exists {
-			return ErrInvoiceAlreadyExists
-		}
+// IssueInvoice creates the VAT invoice for an order inside the caller's
+// transaction.
+func (service *Service) IssueInvoice(ctx context.Context, qtx *sqlc.Queries, orderId int32) (*sqlc.Invoice, error) {
+	//TODO: change order status
 
-		items, err := qtx.GetInvoiceItems(ctx, orderId)
-		if err != nil {
-			return err
+	exists, err := qtx.InvoiceExistsForOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrInvoiceAlreadyExists
+	}
+
+	order, err := qtx.GetOrderById(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
 		}
+		return nil, err
+	}
 
-		var total decimal.Decimal
-		for _, it := range items {
-			dec, err := decimal.NewFromString(it.LineTotal)
-			if err != nil {
-				return ErrFailedToCreateDecimal
-			}
-			total = total.Add(dec)
+	if order.Status == sqlc.OrderStatusONHOLD {
+		return nil, ErrOrderOnHold
+	}
+
+	company, err := qtx.GetCustomerCompanyById(ctx, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	billingAddress, err := qtx.GetCompanyBillingAddress(ctx, order.CustomerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBillingAddressMissing
 		}
+		return nil, err
+	}
+
+	items, err := qtx.GetInvoiceItems(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
 
-		totalAmount, err := db.DecimalToNumeric(total)
+	summary := vat.NewSummary()
+	for _, it := range items {
+		dec, err := decimal.NewFromString(it.LineTotal)
 		if err != nil {
-			return err
+			return nil, ErrFailedToCreateDecimal
 		}
+		summary.Add(it.VatRate, dec)
+	}
 
-		_, err = qtx.CreateInvoice(ctx, sqlc.CreateInvoiceParams{
-			OrderID: orderId,
-			IssueDate: pgtype.Timestamptz{
-				Time:  time.Now(),
-				Valid: true,
-			},
-			DueDate: pgtype.Timestamptz{
-				Time:  time.Now().AddDate(0, 0, 14),
-				Valid: true,
-			},
-			TotalAmount: totalAmount,
-		})
+	lines := summary.Lines()
+	net, tax, gross := vat.Totals(lines)
 
-		return err
+	netAmount, err := db.DecimalToNumeric(net)
+	if err != nil {
+		return nil, err
+	}
+
+	vatAmount, err := db.DecimalToNumeric(tax)
+	if err != nil {
+		return nil, err
+	}
+
+	totalAmount, err := db.DecimalToNumeric(gross)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := qtx.CreateInvoice(ctx, sqlc.CreateInvoiceParams{
+		OrderID: orderId,
+		IssueDate: pgtype.Timestamptz{
+			Time:  time.Now(),
+			Valid: true,
+		},
+		DueDate: pgtype.Timestamptz{
+			Time:  time.Now().AddDate(0, 0, int(company.PaymentTermsDays)),
+			Valid: true,
+		},
+		TotalAmount:        totalAmount,
+		NetAmount:          netAmount,
+		VatAmount:          vatAmount,
+		BuyerName:          company.Name,
+		BuyerTaxID:         company.TaxID,
+		BillingAddressID:   pgtype.Int4{Int32: billingAddress.ID, Valid: true},
+		BillingAddressLine: pgtype.Text{String: billingAddress.AddressLine, Valid: true},
+		BillingCity:        pgtype.Text{String: billingAddress.City, Valid: true},
+		BillingPostalCode:  pgtype.Text{String: billingAddress.PostalCode, Valid: true},
+		BillingCountry:     pgtype.Text{String: billingAddress.Country, Valid: true},
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		if err := createVatSummaryLine(ctx, qtx, invoice.ID, line); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := service.companySettings.SnapshotSeller(ctx, qtx, invoice.ID); err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
 }
 
 func (service *Service) UpdateInvoiceStatus(
@@ -222,12 +371,77 @@ func (service *Service) UpdateInvoiceStatus(
 	})
 }
 
+// GetSellerLogo returns the logo the invoice was issued with, or nil if it
+// has none.
+func (service *Service) GetSellerLogo(ctx context.Context, invoice InvoiceDetails) (*companysettings.Logo, error) {
+	if invoice.Seller == nil || invoice.Seller.LogoId == nil {
+		return nil, nil
+	}
+
+	return service.companySettings.LoadLogo(ctx, *invoice.Seller.LogoId)
+}
+
+func mapSeller(invoice sqlc.GetInvoiceWithOrderRow) *Seller {
+	if !invoice.SellerName.Valid {
+		return nil
+	}
+
+	seller := &Seller{
+		Name:        invoice.SellerName.String,
+		TaxId:       invoice.SellerTaxID.String,
+		AddressLine: invoice.SellerAddressLine.String,
+		City:        invoice.SellerCity.String,
+		PostalCode:  invoice.SellerPostalCode.String,
+		Country:     invoice.SellerCountry.String,
+		Iban:        invoice.SellerIban.String,
+	}
+
+	if invoice.SellerRegon.Valid {
+		seller.Regon = &invoice.SellerRegon.String
+	}
+
+	if invoice.SellerBankName.Valid {
+		seller.BankName = &invoice.SellerBankName.String
+	}
+
+	if invoice.SellerLogoID.Valid {
+		seller.LogoId = &invoice.SellerLogoID.Int32
+	}
+
+	return seller
+}
+
+func createVatSummaryLine(ctx context.Context, qtx *sqlc.Queries, invoiceId int32, line vat.SummaryLine) error {
+	net, err := db.DecimalToNumeric(line.Net)
+	if err != nil {
+		return err
+	}
+
+	tax, err := db.DecimalToNumeric(line.Vat)
+	if err != nil {
+		return err
+	}
+
+	gross, err := db.DecimalToNumeric(line.Gross)
+	if err != nil {
+		return err
+	}
+
+	return qtx.CreateInvoiceVatSummary(ctx, sqlc.CreateInvoiceVatSummaryParams{
+		InvoiceID:   invoiceId,
+		VatRate:     line.Rate,
+		NetAmount:   net,
+		VatAmount:   tax,
+		GrossAmount: gross,
+	})
+}
+
+// isValidStatusTransition covers manual changes only. Whether an invoice is
+// paid follows from the payments allocated to it.
 func isValidStatusTransition(from, to sqlc.InvoiceStatus) bool {
 	switch from {
-	case sqlc.InvoiceStatusUNPAID:
-		return to == sqlc.InvoiceStatusPAID || to == sqlc.InvoiceStatusOVERDUE
-	case sqlc.InvoiceStatusOVERDUE:
-		return to == sqlc.InvoiceStatusPAID
+	case sqlc.InvoiceStatusUNPAID, sqlc.InvoiceStatusPARTIALLYPAID:
+		return to == sqlc.InvoiceStatusOVERDUE
 	default:
 		return false
 	}
@@ -0,0 +1,123 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// enforceCreditLimit checks a new order against the company's credit limit.
+// Over the limit the order is either refused or put ON_HOLD, depending on
+// the company's credit limit action; it reports whether the order was held.
+// Held orders keep their stock reserved.
+func (service *Service) enforceCreditLimit(ctx context.Context, qtx *sqlc.Queries, companyId, orderId int32, orderTotal decimal.Decimal) (bool, error) {
+	credit, err := qtx.GetCompanyCreditForUpdate(ctx, companyId)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !credit.CreditLimit.Valid {
+		return false, nil
+	}
+
+	limit, err := decimal.NewFromString(credit.CreditLimit.String)
+	if err != nil {
+		return false, err
+	}
+
+	exposureText, err := qtx.GetCompanyExposure(ctx, sqlc.GetCompanyExposureParams{
+		CustomerID:     companyId,
+		ExcludeOrderID: orderId,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	exposure, err := decimal.NewFromString(exposureText)
+	if err != nil {
+		return false, err
+	}
+
+	exposure = exposure.Add(orderTotal)
+	if exposure.LessThanOrEqual(limit) {
+		return false, nil
+	}
+
+	if credit.CreditLimitAction == sqlc.CreditLimitActionREJECT {
+		return false, ErrCreditLimitExceeded
+	}
+
+	exposureNum, err := db.DecimalToNumeric(exposure)
+	if err != nil {
+		return false, err
+	}
+
+	limitNum, err := db.DecimalToNumeric(limit)
+	if err != nil {
+		return false, err
+	}
+
+	if err := qtx.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
+		ID:     orderId,
+		Status: sqlc.OrderStatusONHOLD,
+	}); err != nil {
+		return false, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if err := qtx.CreateOrderCreditHold(ctx, sqlc.CreateOrderCreditHoldParams{
+		OrderID:     orderId,
+		Exposure:    exposureNum,
+		CreditLimit: limitNum,
+	}); err != nil {
+		return false, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return true, nil
+}
+
+// ReleaseOrder lets a held order through despite the credit limit. The order
+// goes back to NEW and the hold records who released it and why.
+func (service *Service) ReleaseOrder(ctx context.Context, orderId, userId int32, justification string) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		order, err := qtx.GetOrderById(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrOrderNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if order.Status != sqlc.OrderStatusONHOLD {
+			return ErrOrderNotOnHold
+		}
+
+		released, err := qtx.ReleaseOrderCreditHold(ctx, sqlc.ReleaseOrderCreditHoldParams{
+			OrderID:       orderId,
+			ReleasedBy:    pgtype.Int4{Int32: userId, Valid: true},
+			Justification: pgtype.Text{String: justification, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if released == 0 {
+			return ErrOrderNotOnHold
+		}
+
+		if err := qtx.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
+			ID:     orderId,
+			Status: sqlc.OrderStatusNEW,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
@@ -2,6 +2,7 @@ package orders
 
 import (
 	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/warehouse/movements"
 	"time"
 )
 
@@ -14,29 +15,94 @@ type GetOrderItemsResponse struct {
 }
 
 type CreateOrderRequest struct {
-	Items []struct {
-		ProductID int32 `json:"productId" validate:"required"`
-		Quantity  int32 `json:"quantity" validate:"required,min=1"`
-	} `json:"items" validate:"required,min=1,dive"`
+	WarehouseId *int32 `json:"warehouseId"`
+	// AddressId is one of the company's SHIPPING addresses, by default its
+	// first one. It is copied onto the order, so later edits to the address
+	// do not change it.
+	AddressId *int32             `json:"addressId"`
+	Items     []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type OrderItemRequest struct {
+	ProductID int32 `json:"productId" validate:"required"`
+	Quantity  int32 `json:"quantity" validate:"required,min=1"`
+}
+
+// UpdateOrderItemsRequest replaces all lines of the order.
+type UpdateOrderItemsRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
 }
 
 type UpdateOrderStatusRequest struct {
 	Status sqlc.OrderStatus `json:"status" validate:"required,oneof=NEW INVOICED IN_PREPARATION CANCELLED SHIPPED'"`
 }
 
+type ReleaseOrderRequest struct {
+	Justification string `json:"justification" validate:"required,max=1000"`
+}
+
 type OrderResponse struct {
 	ID          int32            `json:"id"`
 	OrderNumber string           `json:"orderNumber"`
 	Status      sqlc.OrderStatus `json:"status"`
 	TotalAmount string           `json:"totalAmount"`
 	OrderDate   time.Time        `json:"orderDate"`
+	// ShippingAddress is only filled in for a single order; orders placed
+	// before addresses were recorded have none.
+	ShippingAddress *ShippingAddress `json:"shippingAddress,omitempty"`
+}
+
+type ShippingAddress struct {
+	AddressLine string `json:"addressLine"`
+	City        string `json:"city"`
+	PostalCode  string `json:"postalCode"`
+	Country     string `json:"country"`
 }
 
 type OrderItemResponse struct {
-	ID          int32  `json:"id"`
-	ProductID   int32  `json:"productId"`
-	ProductName string `json:"productName"`
-	Quantity    int32  `json:"quantity"`
-	UnitPrice   string `json:"unitPrice"`
-	LineTotal   string `json:"lineTotal"`
+	ID             int32            `json:"id"`
+	ProductID      int32            `json:"productId"`
+	ProductName    string           `json:"productName"`
+	Quantity       int32            `json:"quantity"`
+	UnitPrice      string           `json:"unitPrice"`
+	LineTotal      string           `json:"lineTotal"`
+	PriceSource    sqlc.PriceSource `json:"priceSource"`
+	PriceListID    *int32           `json:"priceListId"`
+	ListPrice      string           `json:"listPrice"`
+	DiscountAmount string           `json:"discountAmount"`
+	DiscountRuleID *int32           `json:"discountRuleId"`
+	VatRate        sqlc.VatRate     `json:"vatRate"`
+	VatAmount      string           `json:"vatAmount"`
+	GrossTotal     string           `json:"grossTotal"`
+}
+
+type GetPickListResponse struct {
+	Items []movements.PickListItem `json:"items"`
+}
+
+type StockShortageResponse struct {
+	Error     string               `json:"error"`
+	Shortages []movements.Shortage `json:"shortages"`
+}
+
+type ListOrderRevisionsResponse struct {
+	Revisions []OrderRevision `json:"revisions"`
+}
+
+// OrderRevision is one edit of an order. Lines only list the products whose
+// quantity changed.
+type OrderRevision struct {
+	Revision    int32               `json:"revision"`
+	EditedBy    int32               `json:"editedBy"`
+	EditedAt    time.Time           `json:"editedAt"`
+	TotalBefore string              `json:"totalBefore"`
+	TotalAfter  string              `json:"totalAfter"`
+	Lines       []OrderRevisionLine `json:"lines"`
+}
+
+type OrderRevisionLine struct {
+	ProductID      int32  `json:"productId"`
+	ProductName    string `json:"productName"`
+	QuantityBefore int32  `json:"quantityBefore"`
+	QuantityAfter  int32  `json:"quantityAfter"`
 }
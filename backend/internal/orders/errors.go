@@ -9,4 +9,12 @@ var (
 	ErrOrderForbidden          = errors.New("forbidden")
 	ErrOrderIdRequired         = errors.New("order id required")
 	ErrInvalidOrderId          = errors.New("invalid order id")
+	ErrNotShippingAddress      = errors.New("address is not a shipping address")
+	ErrNoShippingAddress       = errors.New("company has no shipping address")
+	ErrPrepaymentRequired      = errors.New("order awaits payment of its pro-forma invoice")
+	ErrCreditLimitExceeded     = errors.New("order exceeds the company's credit limit")
+	ErrOrderNotOnHold          = errors.New("order is not on credit hold")
+	ErrOrderNotEditable        = errors.New("only NEW orders can be edited")
+	ErrOrderAlreadyInvoiced    = errors.New("order has already been invoiced")
+	ErrOrderPrepaid            = errors.New("order's pro-forma invoice is already paid")
 )
@@ -2,10 +2,13 @@ package orders
 
 import (
 	"errors"
+	"mleczarnia/internal/companies/addresses"
 	"mleczarnia/internal/db/sqlc"
 	app "mleczarnia/internal/http"
 	"mleczarnia/internal/httputil"
 	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
 	"net/http"
 	"strconv"
 
@@ -87,6 +90,70 @@ func (handler *Handler) GetOrderItems(writer http.ResponseWriter, request *http.
 	httputil.WriteJSON(writer, http.StatusOK, GetOrderItemsResponse{Items: *items})
 }
 
+func (handler *Handler) UpdateOrderItems(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var req UpdateOrderItemsRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &req); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	order, err := handler.service.UpdateOrderItems(request.Context(), orderId, sqlc.Role(claims.Role), int32(claims.UserId), req)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, order)
+}
+
+func (handler *Handler) ListOrderRevisions(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	revisions, err := handler.service.ListOrderRevisions(request.Context(), orderId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListOrderRevisionsResponse{Revisions: *revisions})
+}
+
+func (handler *Handler) GetPickList(writer http.ResponseWriter, request *http.Request) {
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	warehouseScope, err := locations.Resolve(request.Context(), nil)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	items, err := handler.service.GetPickList(request.Context(), orderId, warehouseScope)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, GetPickListResponse{Items: items})
+}
+
 func (handler *Handler) UpdateStatus(writer http.ResponseWriter, request *http.Request) {
 	orderId, err := handler.extractOrderId(request)
 	if err != nil {
@@ -108,6 +175,29 @@ func (handler *Handler) UpdateStatus(writer http.ResponseWriter, request *http.R
 	writer.WriteHeader(http.StatusNoContent)
 }
 
+func (handler *Handler) ReleaseOrder(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var req ReleaseOrderRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &req); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.ReleaseOrder(request.Context(), orderId, int32(claims.UserId), req.Justification); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
 func (handler *Handler) extractOrderId(request *http.Request) (int32, error) {
 	orderIdStr := chi.URLParam(request, "orderId")
 	if orderIdStr == "" {
@@ -123,6 +213,16 @@ func (handler *Handler) extractOrderId(request *http.Request) (int32, error) {
 }
 
 func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	var shortageErr *movements.ShortageError
+	if errors.As(err, &shortageErr) {
+		logrus.WithError(err).Info()
+		httputil.WriteJSON(writer, http.StatusConflict, StockShortageResponse{
+			Error:     err.Error(),
+			Shortages: shortageErr.Shortages,
+		})
+		return
+	}
+
 	statusCode, message := handler.mapErrorToResponse(err)
 	logrus.WithError(err).Info()
 	httputil.WriteError(writer, statusCode, message)
@@ -131,7 +231,33 @@ func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error
 // TODO: error handling
 func (handler *Handler) mapErrorToResponse(err error) (int, string) {
 	switch {
-	case errors.Is(err, ErrInvalidStatusTransition):
+	case errors.Is(err, ErrInvalidStatusTransition),
+		errors.Is(err, ErrPrepaymentRequired),
+		errors.Is(err, ErrCreditLimitExceeded),
+		errors.Is(err, ErrOrderNotOnHold),
+		errors.Is(err, ErrOrderNotEditable),
+		errors.Is(err, ErrOrderAlreadyInvoiced),
+		errors.Is(err, ErrOrderPrepaid):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, ErrOrderNotFound), errors.Is(err, locations.ErrWarehouseNotFound),
+		errors.Is(err, addresses.ErrAddressNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, ErrOrderForbidden):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, locations.ErrWarehouseInactive):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, ErrOrderIdRequired),
+		errors.Is(err, ErrInvalidOrderId),
+		errors.Is(err, ErrNotShippingAddress),
+		errors.Is(err, ErrNoShippingAddress):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, movements.ErrInsufficientStock),
+		errors.Is(err, movements.ErrExpiredLot):
 		return http.StatusConflict, err.Error()
 
 	default:
@@ -0,0 +1,41 @@
+package proformas
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/invoices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ProformaDetails struct {
+	Id               int32                     `json:"id"`
+	ProformaNumber   string                    `json:"proformaNumber"`
+	OrderId          int32                     `json:"orderId"`
+	OrderNumber      string                    `json:"orderNumber"`
+	IssueDate        time.Time                 `json:"issueDate"`
+	DueDate          time.Time                 `json:"dueDate"`
+	Status           sqlc.ProformaStatus       `json:"status"`
+	CompanyName      string                    `json:"companyName"`
+	TaxId            string                    `json:"taxId"`
+	BillingAddress   invoices.Address          `json:"billingAddress"`
+	Email            string                    `json:"email"`
+	Items            []invoices.InvoiceItem    `json:"items"`
+	VatSummary       []invoices.VatSummaryLine `json:"vatSummary"`
+	NetAmount        string                    `json:"netAmount"`
+	VatAmount        string                    `json:"vatAmount"`
+	TotalAmount      string                    `json:"totalAmount"`
+	PaidAt           *time.Time                `json:"paidAt"`
+	PaymentReference *string                   `json:"paymentReference"`
+	// InvoiceId is the final VAT invoice, issued once the pro-forma is paid.
+	InvoiceId *int32 `json:"invoiceId"`
+}
+
+// RecordPaymentRequest settles a pro-forma. Only payment in full is
+// accepted. PaidAt defaults to now and Method to a bank transfer.
+type RecordPaymentRequest struct {
+	Amount    decimal.Decimal     `json:"amount" validate:"required,decimalpos"`
+	PaidAt    *time.Time          `json:"paidAt"`
+	Method    *sqlc.PaymentMethod `json:"method" validate:"omitempty,oneof=BANK_TRANSFER CASH CARD OTHER"`
+	Reference *string             `json:"reference" validate:"omitempty,max=100"`
+}
@@ -0,0 +1,13 @@
+package proformas
+
+import "errors"
+
+var (
+	ErrProformaNotFound      = errors.New("pro-forma invoice not found")
+	ErrProformaAlreadyExists = errors.New("pro-forma invoice already exists for order")
+	ErrProformaAlreadyPaid   = errors.New("pro-forma invoice already paid")
+	ErrProformaCancelled     = errors.New("pro-forma invoice cancelled")
+	ErrOrderNotNew           = errors.New("pro-forma can only be issued for a new order")
+	ErrPaymentAmountMismatch = errors.New("payment amount does not match pro-forma total")
+	ErrProformaForbidden     = errors.New("forbidden")
+)
@@ -0,0 +1,159 @@
+package proformas
+
+import (
+	"errors"
+	"mleczarnia/internal/companysettings"
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/invoices"
+	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/orders"
+	"mleczarnia/internal/warehouse/movements"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(s *Service) *Handler {
+	return &Handler{service: s}
+}
+
+func (handler *Handler) CreateProforma(writer http.ResponseWriter, request *http.Request) {
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.CreateProforma(request.Context(), orderId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+}
+
+func (handler *Handler) GetProforma(writer http.ResponseWriter, request *http.Request) {
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	proforma, err := handler.service.GetProforma(request.Context(), orderId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, *proforma)
+}
+
+func (handler *Handler) GetProformaPdf(writer http.ResponseWriter, request *http.Request) {
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	proforma, err := handler.service.GetProforma(request.Context(), orderId, sqlc.Role(claims.Role), int32(claims.UserId))
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	proformaPdfBytes, err := handler.service.RenderPdf(request.Context(), *proforma)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/pdf")
+	writer.Header().Set("Content-Disposition", "inline; filename="+strings.ReplaceAll(proforma.ProformaNumber, "/", "-")+".pdf")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(proformaPdfBytes)
+}
+
+func (handler *Handler) RecordPayment(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	orderId, err := handler.extractOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body RecordPaymentRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.RecordPayment(request.Context(), orderId, int32(claims.UserId), body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractOrderId(request *http.Request) (int32, error) {
+	orderIdStr := chi.URLParam(request, "orderId")
+	if orderIdStr == "" {
+		return 0, orders.ErrOrderIdRequired
+	}
+
+	orderId, err := strconv.Atoi(orderIdStr)
+	if err != nil {
+		return 0, orders.ErrInvalidOrderId
+	}
+
+	return int32(orderId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrProformaNotFound),
+		errors.Is(err, orders.ErrOrderNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, ErrProformaForbidden):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, orders.ErrOrderIdRequired),
+		errors.Is(err, orders.ErrInvalidOrderId):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, ErrProformaAlreadyExists),
+		errors.Is(err, ErrProformaAlreadyPaid),
+		errors.Is(err, ErrProformaCancelled),
+		errors.Is(err, ErrOrderNotNew),
+		errors.Is(err, ErrPaymentAmountMismatch),
+		errors.Is(err, orders.ErrInvalidStatusTransition),
+		errors.Is(err, invoices.ErrInvoiceAlreadyExists),
+		errors.Is(err, invoices.ErrBillingAddressMissing),
+		errors.Is(err, companysettings.ErrSettingsNotConfigured),
+		errors.Is(err, movements.ErrInsufficientStock),
+		errors.Is(err, movements.ErrExpiredLot):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
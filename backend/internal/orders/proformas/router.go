@@ -0,0 +1,27 @@
+package proformas
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(handler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF, sqlc.RoleCLIENT))
+		r.Get("/", handler.GetProforma)
+		r.Get("/pdf", handler.GetProformaPdf)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Post("/", handler.CreateProforma)
+		r.Post("/payment", handler.RecordPayment)
+	})
+
+	return router
+}
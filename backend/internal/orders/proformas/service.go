@@ -0,0 +1,376 @@
+package proformas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/companysettings"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/invoices"
+	"mleczarnia/internal/orders"
+	"mleczarnia/internal/payments"
+	"mleczarnia/internal/vat"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// paymentDays is how long the customer has to pay a pro-forma.
+const paymentDays = 7
+
+type Service struct {
+	query           *sqlc.Queries
+	pool            *pgxpool.Pool
+	orders          *orders.Service
+	invoices        *invoices.Service
+	payments        *payments.Service
+	companySettings *companysettings.Service
+}
+
+func NewService(
+	queries *sqlc.Queries,
+	pool *pgxpool.Pool,
+	ordersService *orders.Service,
+	invoicesService *invoices.Service,
+	paymentsService *payments.Service,
+	companySettingsService *companysettings.Service,
+) *Service {
+	return &Service{
+		query:           queries,
+		pool:            pool,
+		orders:          ordersService,
+		invoices:        invoicesService,
+		payments:        paymentsService,
+		companySettings: companySettingsService,
+	}
+}
+
+// CreateProforma issues a pro-forma for a NEW order, with the buyer and
+// amounts the final invoice will have.
+func (service *Service) CreateProforma(ctx context.Context, orderId int32) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		order, err := qtx.GetOrderById(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return orders.ErrOrderNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if order.Status != sqlc.OrderStatusNEW {
+			return ErrOrderNotNew
+		}
+
+		exists, err := qtx.ProformaExistsForOrder(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if exists {
+			return ErrProformaAlreadyExists
+		}
+
+		company, err := qtx.GetCustomerCompanyById(ctx, order.CustomerID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		billingAddress, err := qtx.GetCompanyBillingAddress(ctx, order.CustomerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return invoices.ErrBillingAddressMissing
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		items, err := qtx.GetInvoiceItems(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		summary := vat.NewSummary()
+		for _, it := range items {
+			dec, err := decimal.NewFromString(it.LineTotal)
+			if err != nil {
+				return invoices.ErrFailedToCreateDecimal
+			}
+			summary.Add(it.VatRate, dec)
+		}
+
+		net, tax, gross := vat.Totals(summary.Lines())
+
+		netAmount, err := db.DecimalToNumeric(net)
+		if err != nil {
+			return err
+		}
+
+		vatAmount, err := db.DecimalToNumeric(tax)
+		if err != nil {
+			return err
+		}
+
+		totalAmount, err := db.DecimalToNumeric(gross)
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.CreateProformaInvoice(ctx, sqlc.CreateProformaInvoiceParams{
+			OrderID: orderId,
+			DueDate: pgtype.Timestamptz{
+				Time:  time.Now().AddDate(0, 0, paymentDays),
+				Valid: true,
+			},
+			NetAmount:          netAmount,
+			VatAmount:          vatAmount,
+			TotalAmount:        totalAmount,
+			BuyerName:          company.Name,
+			BuyerTaxID:         company.TaxID,
+			BillingAddressLine: billingAddress.AddressLine,
+			BillingCity:        billingAddress.City,
+			BillingPostalCode:  billingAddress.PostalCode,
+			BillingCountry:     billingAddress.Country,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+func (service *Service) GetProforma(
+	ctx context.Context,
+	orderId int32,
+	role sqlc.Role,
+	userId int32,
+) (*ProformaDetails, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*ProformaDetails, error) {
+		qtx := service.query.WithTx(tx)
+
+		proforma, err := qtx.GetProformaByOrderId(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrProformaNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if role == sqlc.RoleCLIENT {
+			companyId, err := qtx.GetCompanyIdForUserId(ctx, userId)
+			if err != nil {
+				return nil, invoices.ErrFailedToGetCompanyIdForUser
+			}
+
+			if proforma.CustomerID != companyId.Int32 {
+				return nil, ErrProformaForbidden
+			}
+		}
+
+		items, err := qtx.GetInvoiceItems(ctx, orderId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		details := ProformaDetails{
+			Id:             proforma.ID,
+			ProformaNumber: proforma.ProformaNumber,
+			OrderId:        proforma.OrderID,
+			OrderNumber:    proforma.OrderNumber,
+			IssueDate:      proforma.IssueDate.Time,
+			DueDate:        proforma.DueDate.Time,
+			Status:         proforma.Status,
+			CompanyName:    proforma.BuyerName,
+			TaxId:          proforma.BuyerTaxID,
+			BillingAddress: invoices.Address{
+				AddressLine: proforma.BillingAddressLine,
+				City:        proforma.BillingCity,
+				PostalCode:  proforma.BillingPostalCode,
+				Country:     proforma.BillingCountry,
+			},
+			Email:       proforma.MainEmail,
+			NetAmount:   proforma.NetAmount,
+			VatAmount:   proforma.VatAmount,
+			TotalAmount: proforma.TotalAmount,
+		}
+
+		if proforma.PaidAt.Valid {
+			details.PaidAt = &proforma.PaidAt.Time
+		}
+		if proforma.PaymentReference.Valid {
+			details.PaymentReference = &proforma.PaymentReference.String
+		}
+		if proforma.InvoiceID.Valid {
+			details.InvoiceId = &proforma.InvoiceID.Int32
+		}
+
+		summary := vat.NewSummary()
+		for _, it := range items {
+			details.Items = append(details.Items, invoices.InvoiceItem{
+				ProductName: it.ProductName,
+				Unit:        it.Unit,
+				Quantity:    it.Quantity,
+				UnitPrice:   it.UnitPrice,
+				LineTotal:   it.LineTotal,
+				VatRate:     it.VatRate,
+				VatAmount:   it.VatAmount,
+				GrossTotal:  it.GrossTotal,
+			})
+
+			dec, err := decimal.NewFromString(it.LineTotal)
+			if err != nil {
+				return nil, invoices.ErrFailedToCreateDecimal
+			}
+			summary.Add(it.VatRate, dec)
+		}
+
+		for _, line := range summary.Lines() {
+			details.VatSummary = append(details.VatSummary, invoices.VatSummaryLine{
+				VatRate:     line.Rate,
+				NetAmount:   line.Net.StringFixed(2),
+				VatAmount:   line.Vat.StringFixed(2),
+				GrossAmount: line.Gross.StringFixed(2),
+			})
+		}
+
+		return &details, nil
+	})
+}
+
+// RenderPdf prints a pro-forma with the seller details as they are now. A
+// pro-forma is not an accounting document, so they are not snapshotted.
+func (service *Service) RenderPdf(ctx context.Context, proforma ProformaDetails) ([]byte, error) {
+	settings, err := service.companySettings.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logo, err := service.companySettings.GetLogo(ctx)
+	if err != nil && !errors.Is(err, companysettings.ErrLogoNotFound) {
+		return nil, err
+	}
+
+	billingAddress := proforma.BillingAddress
+
+	return invoices.GenerateProformaPDF(invoices.InvoiceDetails{
+		InvoiceNumber: proforma.ProformaNumber,
+		IssueDate:     proforma.IssueDate,
+		DueDate:       proforma.DueDate,
+		Seller: &invoices.Seller{
+			Name:        settings.LegalName,
+			TaxId:       settings.TaxId,
+			Regon:       settings.Regon,
+			AddressLine: settings.AddressLine,
+			City:        settings.City,
+			PostalCode:  settings.PostalCode,
+			Country:     settings.Country,
+			Iban:        settings.Iban,
+			BankName:    settings.BankName,
+		},
+		CompanyName:    proforma.CompanyName,
+		TaxId:          proforma.TaxId,
+		BillingAddress: &billingAddress,
+		Email:          proforma.Email,
+		OrderNumber:    proforma.OrderNumber,
+		Items:          proforma.Items,
+		VatSummary:     proforma.VatSummary,
+		NetAmount:      proforma.NetAmount,
+		VatAmount:      proforma.VatAmount,
+		TotalAmount:    proforma.TotalAmount,
+	}, logo)
+}
+
+// RecordPayment settles a pro-forma in full. The final VAT invoice is issued
+// and the payment is booked against it in one transaction, then the order
+// moves on to preparation. The customer has paid either way, so when the
+// order cannot be picked, e.g. because its stock was written off, it stays
+// NEW with a warning for staff to resolve.
+func (service *Service) RecordPayment(ctx context.Context, orderId int32, userId int32, req RecordPaymentRequest) error {
+	if err := service.bookPayment(ctx, orderId, userId, req); err != nil {
+		return err
+	}
+
+	// the pro-forma is paid now, so the prepayment gate lets this through
+	if err := service.orders.UpdateOrderStatus(ctx, orderId, sqlc.OrderStatusINPREPARATION); err != nil {
+		logrus.WithError(err).WithField("orderId", orderId).Warn("Pro-forma paid but the order could not move to preparation")
+	}
+
+	return nil
+}
+
+func (service *Service) bookPayment(ctx context.Context, orderId int32, userId int32, req RecordPaymentRequest) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		proforma, err := qtx.GetProformaForUpdate(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrProformaNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		switch proforma.Status {
+		case sqlc.ProformaStatusPAID:
+			return ErrProformaAlreadyPaid
+		case sqlc.ProformaStatusCANCELLED:
+			return ErrProformaCancelled
+		}
+
+		total, err := decimal.NewFromString(proforma.TotalAmount)
+		if err != nil {
+			return invoices.ErrFailedToCreateDecimal
+		}
+
+		if !req.Amount.Equal(total) {
+			return ErrPaymentAmountMismatch
+		}
+
+		order, err := qtx.GetOrderById(ctx, orderId)
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		invoice, err := service.invoices.IssueInvoice(ctx, qtx, orderId)
+		if err != nil {
+			return err
+		}
+
+		paidAt := time.Now()
+		if req.PaidAt != nil {
+			paidAt = *req.PaidAt
+		}
+
+		method := sqlc.PaymentMethodBANKTRANSFER
+		if req.Method != nil {
+			method = *req.Method
+		}
+
+		// the allocation settles the new invoice, which marks it PAID
+		if _, err := service.payments.Record(ctx, qtx, &userId, payments.CreatePaymentRequest{
+			CompanyId:   order.CustomerID,
+			Amount:      req.Amount,
+			PaymentDate: paidAt,
+			Method:      method,
+			Reference:   req.Reference,
+			Allocations: []payments.AllocationRequest{{InvoiceId: invoice.ID, Amount: req.Amount}},
+		}); err != nil {
+			return err
+		}
+
+		if err := qtx.MarkProformaPaid(ctx, sqlc.MarkProformaPaidParams{
+			ID:               proforma.ID,
+			PaidAt:           db.ConvertToTimestamptz(&paidAt),
+			PaymentReference: db.ConvertToText(req.Reference),
+			InvoiceID:        pgtype.Int4{Int32: invoice.ID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,313 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/invoices"
+	"mleczarnia/internal/vat"
+	"mleczarnia/internal/warehouse/movements"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// UpdateOrderItems replaces the lines of a NEW order. The new lines are
+// priced as CreateOrder prices them, as of the order date, the stock
+// reservation follows the new quantities and the order is checked against
+// the credit limit again. Each edit that changes anything is kept as a
+// revision.
+func (service *Service) UpdateOrderItems(ctx context.Context, orderId int32, role sqlc.Role, userId int32, req UpdateOrderItemsRequest) (*OrderResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*OrderResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		order, err := qtx.GetOrderById(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if role == sqlc.RoleCLIENT {
+			companyId, err := qtx.GetCompanyIdForUserId(ctx, userId)
+			if err != nil {
+				return nil, invoices.ErrFailedToGetCompanyIdForUser
+			}
+
+			if order.CustomerID != companyId.Int32 {
+				return nil, ErrOrderForbidden
+			}
+		}
+
+		if order.Status != sqlc.OrderStatusNEW {
+			return nil, ErrOrderNotEditable
+		}
+
+		invoiced, err := qtx.InvoiceExistsForOrder(ctx, orderId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if invoiced {
+			return nil, ErrOrderAlreadyInvoiced
+		}
+
+		prepayment, err := qtx.GetOrderPrepaymentStatus(ctx, orderId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if prepayment.ProformaStatus.Valid && prepayment.ProformaStatus.ProformaStatus == sqlc.ProformaStatusPAID {
+			return nil, ErrOrderPrepaid
+		}
+
+		oldLines, err := orderStockLines(ctx, qtx, orderId)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := service.movements.ReleaseReservation(ctx, qtx, order.WarehouseID, oldLines); err != nil {
+			return nil, err
+		}
+
+		if err := qtx.DeleteOrderItems(ctx, orderId); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		totalAmount, lines, err := service.insertOrderItems(ctx, qtx, order.CustomerID, orderId, order.OrderDate.Time, req.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := service.movements.ReserveStock(ctx, qtx, order.WarehouseID, lines); err != nil {
+			return nil, err
+		}
+
+		totalAmountNum, err := db.DecimalToNumeric(totalAmount)
+		if err != nil {
+			return nil, err
+		}
+
+		orderUpdated, err := qtx.SetOrderTotalAmount(ctx, sqlc.SetOrderTotalAmountParams{
+			TotalAmount: totalAmountNum,
+			ID:          orderId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		held, err := service.enforceCreditLimit(ctx, qtx, order.CustomerID, orderId, totalAmount)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			orderUpdated.Status = sqlc.OrderStatusONHOLD
+		}
+
+		if prepayment.ProformaStatus.Valid && prepayment.ProformaStatus.ProformaStatus == sqlc.ProformaStatusUNPAID {
+			if err := refreshProformaAmounts(ctx, qtx, orderId); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := recordRevision(ctx, qtx, orderId, userId, order.TotalAmount, totalAmount, oldLines, lines); err != nil {
+			return nil, err
+		}
+
+		response := &OrderResponse{
+			ID:          orderUpdated.ID,
+			OrderNumber: orderUpdated.OrderNumber,
+			Status:      orderUpdated.Status,
+			TotalAmount: totalAmount.String(),
+			OrderDate:   orderUpdated.OrderDate.Time,
+		}
+
+		if order.ShippingAddressLine.Valid {
+			response.ShippingAddress = &ShippingAddress{
+				AddressLine: order.ShippingAddressLine.String,
+				City:        order.ShippingCity.String,
+				PostalCode:  order.ShippingPostalCode.String,
+				Country:     order.ShippingCountry.String,
+			}
+		}
+
+		return response, nil
+	})
+}
+
+// recordRevision stores the edit with the quantity of every product it
+// changed. An edit that changes neither quantities nor the total is not
+// recorded.
+func recordRevision(ctx context.Context, qtx *sqlc.Queries, orderId, userId int32, totalBefore string, totalAfter decimal.Decimal, oldLines, newLines []movements.StockLine) error {
+	before, err := decimal.NewFromString(totalBefore)
+	if err != nil {
+		return err
+	}
+
+	quantities := make(map[int32][2]int32)
+	for _, line := range oldLines {
+		q := quantities[line.ProductId]
+		q[0] += line.Quantity
+		quantities[line.ProductId] = q
+	}
+	for _, line := range newLines {
+		q := quantities[line.ProductId]
+		q[1] += line.Quantity
+		quantities[line.ProductId] = q
+	}
+
+	changed := make([]int32, 0, len(quantities))
+	for productId, q := range quantities {
+		if q[0] != q[1] {
+			changed = append(changed, productId)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i] < changed[j] })
+
+	if len(changed) == 0 && before.Equal(totalAfter) {
+		return nil
+	}
+
+	beforeNum, err := db.DecimalToNumeric(before)
+	if err != nil {
+		return err
+	}
+
+	afterNum, err := db.DecimalToNumeric(totalAfter)
+	if err != nil {
+		return err
+	}
+
+	revisionId, err := qtx.CreateOrderRevision(ctx, sqlc.CreateOrderRevisionParams{
+		OrderID:     orderId,
+		EditedBy:    userId,
+		TotalBefore: beforeNum,
+		TotalAfter:  afterNum,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	for _, productId := range changed {
+		if err := qtx.CreateOrderRevisionLine(ctx, sqlc.CreateOrderRevisionLineParams{
+			RevisionID:     revisionId,
+			ProductID:      productId,
+			QuantityBefore: quantities[productId][0],
+			QuantityAfter:  quantities[productId][1],
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return nil
+}
+
+// refreshProformaAmounts brings an unpaid pro-forma in line with the edited
+// order, summing VAT by rate as the pro-forma was issued.
+func refreshProformaAmounts(ctx context.Context, qtx *sqlc.Queries, orderId int32) error {
+	items, err := qtx.GetInvoiceItems(ctx, orderId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	summary := vat.NewSummary()
+	for _, item := range items {
+		lineTotal, err := decimal.NewFromString(item.LineTotal)
+		if err != nil {
+			return err
+		}
+		summary.Add(item.VatRate, lineTotal)
+	}
+
+	net, tax, gross := vat.Totals(summary.Lines())
+
+	netAmount, err := db.DecimalToNumeric(net)
+	if err != nil {
+		return err
+	}
+
+	vatAmount, err := db.DecimalToNumeric(tax)
+	if err != nil {
+		return err
+	}
+
+	totalAmount, err := db.DecimalToNumeric(gross)
+	if err != nil {
+		return err
+	}
+
+	if err := qtx.UpdateProformaAmounts(ctx, sqlc.UpdateProformaAmountsParams{
+		OrderID:     orderId,
+		NetAmount:   netAmount,
+		VatAmount:   vatAmount,
+		TotalAmount: totalAmount,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func (service *Service) ListOrderRevisions(ctx context.Context, orderId int32, role sqlc.Role, userId int32) (*[]OrderRevision, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*[]OrderRevision, error) {
+		qtx := service.query.WithTx(tx)
+
+		order, err := qtx.GetOrderById(ctx, orderId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, err
+		}
+
+		if role == sqlc.RoleCLIENT {
+			companyId, err := qtx.GetCompanyIdForUserId(ctx, userId)
+			if err != nil {
+				return nil, invoices.ErrFailedToGetCompanyIdForUser
+			}
+
+			if order.CustomerID != companyId.Int32 {
+				return nil, ErrOrderForbidden
+			}
+		}
+
+		rows, err := qtx.ListOrderRevisions(ctx, orderId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		lines, err := qtx.ListOrderRevisionLines(ctx, orderId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		linesByRevision := make(map[int32][]OrderRevisionLine)
+		for _, line := range lines {
+			linesByRevision[line.RevisionID] = append(linesByRevision[line.RevisionID], OrderRevisionLine{
+				ProductID:      line.ProductID,
+				ProductName:    line.ProductName,
+				QuantityBefore: line.QuantityBefore,
+				QuantityAfter:  line.QuantityAfter,
+			})
+		}
+
+		revisions := make([]OrderRevision, len(rows))
+		for i, row := range rows {
+			revisions[i] = OrderRevision{
+				Revision:    row.Revision,
+				EditedBy:    row.EditedBy,
+				EditedAt:    row.EditedAt.Time,
+				TotalBefore: row.TotalBefore,
+				TotalAfter:  row.TotalAfter,
+				Lines:       linesByRevision[row.ID],
+			}
+			if revisions[i].Lines == nil {
+				revisions[i].Lines = []OrderRevisionLine{}
+			}
+		}
+
+		return &revisions, nil
+	})
+}
@@ -4,11 +4,15 @@ import (
 	"mleczarnia/internal/db/sqlc"
 	app "mleczarnia/internal/http"
 	"mleczarnia/internal/invoices"
+	"net/http"
 
 	"github.com/go-chi/chi/v5"
 )
 
-func Router(handler *Handler, invoicesHandler *invoices.Handler, middleware *app.Middleware) *chi.Mux {
+func Router(handler *Handler,
+	invoicesHandler *invoices.Handler,
+	middleware *app.Middleware,
+	proformasRouter http.Handler) *chi.Mux {
 	router := chi.NewRouter()
 
 	router.Group(func(r chi.Router) {
@@ -17,13 +21,24 @@ func Router(handler *Handler, invoicesHandler *invoices.Handler, middleware *app
 		r.Get("/", handler.ListOrders)
 		r.Get("/{orderId}", handler.GetOrder)
 		r.Get("/{orderId}/items", handler.GetOrderItems)
+		r.Put("/{orderId}/items", handler.UpdateOrderItems)
+		r.Get("/{orderId}/revisions", handler.ListOrderRevisions)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF, sqlc.RoleWAREHOUSE))
+		r.Use(middleware.ScopeWarehouse())
+		r.Get("/{orderId}/pick-list", handler.GetPickList)
 	})
 
 	router.Group(func(r chi.Router) {
 		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
 		r.Patch("/{orderId}/status", handler.UpdateStatus)
+		r.Post("/{orderId}/release", handler.ReleaseOrder)
 		r.Post("/{orderId}/invoices", invoicesHandler.CreateInvoiceForOrder)
 	})
 
+	router.Mount("/{orderId}/proforma", proformasRouter)
+
 	return router
 }
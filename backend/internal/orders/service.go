@@ -4,23 +4,49 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mleczarnia/internal/companies/addresses"
 	"mleczarnia/internal/db"
 	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/discounts"
 	"mleczarnia/internal/invoices"
+	"mleczarnia/internal/pricelists"
 	"mleczarnia/internal/products"
+	"mleczarnia/internal/vat"
+	"mleczarnia/internal/warehouse/documents"
+	"mleczarnia/internal/warehouse/movements"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 )
 
 type Service struct {
-	query *sqlc.Queries
-	pool  *pgxpool.Pool
+	query      *sqlc.Queries
+	pool       *pgxpool.Pool
+	movements  *movements.Service
+	documents  *documents.Service
+	priceLists *pricelists.Service
+	discounts  *discounts.Service
 }
 
-func NewService(queries *sqlc.Queries, pool *pgxpool.Pool) *Service {
-	return &Service{query: queries, pool: pool}
+func NewService(
+	queries *sqlc.Queries,
+	pool *pgxpool.Pool,
+	movementsService *movements.Service,
+	documentsService *documents.Service,
+	priceListsService *pricelists.Service,
+	discountsService *discounts.Service,
+) *Service {
+	return &Service{
+		query:      queries,
+		pool:       pool,
+		movements:  movementsService,
+		documents:  documentsService,
+		priceLists: priceListsService,
+		discounts:  discountsService,
+	}
 }
 
 func (service *Service) CreateOrder(ctx context.Context, userId int32, req CreateOrderRequest) (*OrderResponse, error) {
@@ -32,70 +58,205 @@ func (service *Service) CreateOrder(ctx context.Context, userId int32, req Creat
 			return nil, invoices.ErrFailedToGetCompanyIdForUser
 		}
 
-		order, err := qtx.CreateOrder(ctx, companyId.Int32)
-		if err != nil {
-			return nil, ErrCouldNotCreateOrder
+		return service.CreateOrderForCompany(ctx, qtx, companyId.Int32, req)
+	})
+}
+
+// CreateOrderForCompany places an order for a company inside the caller's
+// transaction: it prices the items, reserves stock and applies the credit
+// limit.
+func (service *Service) CreateOrderForCompany(ctx context.Context, qtx *sqlc.Queries, companyId int32, req CreateOrderRequest) (*OrderResponse, error) {
+	warehouseId, err := service.movements.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := resolveShippingAddress(ctx, qtx, companyId, req.AddressId)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := qtx.CreateOrder(ctx, sqlc.CreateOrderParams{
+		CustomerID:          companyId,
+		WarehouseID:         warehouseId,
+		ShippingAddressID:   pgtype.Int4{Int32: address.ID, Valid: true},
+		ShippingAddressLine: pgtype.Text{String: address.AddressLine, Valid: true},
+		ShippingCity:        pgtype.Text{String: address.City, Valid: true},
+		ShippingPostalCode:  pgtype.Text{String: address.PostalCode, Valid: true},
+		ShippingCountry:     pgtype.Text{String: address.Country, Valid: true},
+	})
+	if err != nil {
+		return nil, ErrCouldNotCreateOrder
+	}
+
+	totalAmount, lines, err := service.insertOrderItems(ctx, qtx, companyId, order.ID, order.OrderDate.Time, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.movements.ReserveStock(ctx, qtx, warehouseId, lines); err != nil {
+		return nil, err
+	}
+
+	totalAmountNum, err := db.DecimalToNumeric(totalAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	orderUpdated, err := qtx.SetOrderTotalAmount(ctx, sqlc.SetOrderTotalAmountParams{
+		TotalAmount: totalAmountNum,
+		ID:          order.ID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
 		}
+		return nil, err
+	}
 
-		totalAmount := decimal.NewFromInt32(0)
+	held, err := service.enforceCreditLimit(ctx, qtx, companyId, orderUpdated.ID, totalAmount)
+	if err != nil {
+		return nil, err
+	}
+	if held {
+		orderUpdated.Status = sqlc.OrderStatusONHOLD
+	}
 
-		for _, item := range req.Items {
-			product, err := qtx.GetProductById(ctx, item.ProductID)
-			if err != nil {
-				if errors.Is(err, pgx.ErrNoRows) {
-					return nil, products.ErrProductNotFound
-				}
-				return nil, fmt.Errorf("%w : %v", db.ErrDatabaseOperation, err)
-			}
+	return &OrderResponse{
+		ID:          orderUpdated.ID,
+		OrderNumber: orderUpdated.OrderNumber,
+		Status:      orderUpdated.Status,
+		TotalAmount: totalAmount.String(),
+		OrderDate:   orderUpdated.OrderDate.Time,
+		ShippingAddress: &ShippingAddress{
+			AddressLine: address.AddressLine,
+			City:        address.City,
+			PostalCode:  address.PostalCode,
+			Country:     address.Country,
+		},
+	}, nil
+}
 
-			price, err := decimal.NewFromString(product.DefaultPriceText)
-			if err != nil {
-				return nil, err
+// resolveShippingAddress returns the company's SHIPPING address addressId, or
+// its first SHIPPING address when addressId is nil.
+func resolveShippingAddress(ctx context.Context, qtx *sqlc.Queries, companyId int32, addressId *int32) (*sqlc.CompanyAddress, error) {
+	if addressId == nil {
+		address, err := qtx.GetCompanyShippingAddress(ctx, companyId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrNoShippingAddress
 			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		return &address, nil
+	}
 
-			lineTotal, err := db.DecimalToNumeric(decimal.NewFromInt32(item.Quantity).Mul(price))
-			if err != nil {
-				return nil, err
-			}
+	address, err := qtx.GetCompanyAddress(ctx, sqlc.GetCompanyAddressParams{
+		ID:                *addressId,
+		CustomerCompanyID: companyId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, addresses.ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if address.Type != sqlc.AddressTypeSHIPPING {
+		return nil, ErrNotShippingAddress
+	}
+
+	return &address, nil
+}
+
+// insertOrderItems prices the items for the company as of orderDate, with
+// price lists, discounts and VAT, and adds them to the order. It returns the
+// gross total and the stock the items need.
+func (service *Service) insertOrderItems(ctx context.Context, qtx *sqlc.Queries, companyId, orderId int32, orderDate time.Time, items []OrderItemRequest) (decimal.Decimal, []movements.StockLine, error) {
+	totalAmount := decimal.NewFromInt32(0)
+	lines := make([]movements.StockLine, 0, len(items))
 
-			if _, err := qtx.InsertOrderItem(ctx, sqlc.InsertOrderItemParams{
-				OrderID:   order.ID,
-				ProductID: product.ID,
-				Quantity:  item.Quantity,
-				UnitPrice: product.DefaultPrice,
-				LineTotal: lineTotal,
-			}); err != nil {
-				return nil, err
+	for _, item := range items {
+		product, err := qtx.GetProductById(ctx, item.ProductID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return decimal.Decimal{}, nil, products.ErrProductNotFound
 			}
+			return decimal.Decimal{}, nil, fmt.Errorf("%w : %v", db.ErrDatabaseOperation, err)
+		}
 
-			totalAmount = totalAmount.Add(price)
+		defaultPrice, err := decimal.NewFromString(product.DefaultPriceText)
+		if err != nil {
+			return decimal.Decimal{}, nil, err
 		}
 
-		totalAmountNum, err := db.DecimalToNumeric(totalAmount)
+		price, err := service.priceLists.ResolvePrice(ctx, qtx, companyId, product.ID, defaultPrice, orderDate)
 		if err != nil {
-			return nil, err
+			return decimal.Decimal{}, nil, err
 		}
 
-		orderUpdated, err := qtx.SetOrderTotalAmount(ctx, sqlc.SetOrderTotalAmountParams{
-			TotalAmount: totalAmountNum,
-			ID:          order.ID,
-		})
+		discount, err := service.discounts.Apply(ctx, qtx, product.ID, product.Category, item.Quantity, price.UnitPrice, orderDate)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return nil, err
-			}
-			return nil, err
+			return decimal.Decimal{}, nil, err
 		}
 
-		return &OrderResponse{
-			ID:          orderUpdated.ID,
-			OrderNumber: orderUpdated.OrderNumber,
-			Status:      orderUpdated.Status,
-			TotalAmount: totalAmount.String(),
-			OrderDate:   orderUpdated.OrderDate.Time,
-		}, nil
+		listPrice, err := db.DecimalToNumeric(price.UnitPrice)
+		if err != nil {
+			return decimal.Decimal{}, nil, err
+		}
 
-	})
+		netPrice := price.UnitPrice.Sub(discount.UnitDiscount)
+		unitPrice, err := db.DecimalToNumeric(netPrice)
+		if err != nil {
+			return decimal.Decimal{}, nil, err
+		}
+
+		discountAmount, err := db.DecimalToNumeric(decimal.NewFromInt32(item.Quantity).Mul(discount.UnitDiscount))
+		if err != nil {
+			return decimal.Decimal{}, nil, err
+		}
+
+		lineTotalDec := decimal.NewFromInt32(item.Quantity).Mul(netPrice)
+		lineTotal, err := db.DecimalToNumeric(lineTotalDec)
+		if err != nil {
+			return decimal.Decimal{}, nil, err
+		}
+
+		vatAmountDec := vat.Amount(lineTotalDec, product.VatRate)
+		vatAmount, err := db.DecimalToNumeric(vatAmountDec)
+		if err != nil {
+			return decimal.Decimal{}, nil, err
+		}
+
+		grossTotalDec := lineTotalDec.Add(vatAmountDec)
+		grossTotal, err := db.DecimalToNumeric(grossTotalDec)
+		if err != nil {
+			return decimal.Decimal{}, nil, err
+		}
+
+		if _, err := qtx.InsertOrderItem(ctx, sqlc.InsertOrderItemParams{
+			OrderID:        orderId,
+			ProductID:      product.ID,
+			Quantity:       item.Quantity,
+			UnitPrice:      unitPrice,
+			LineTotal:      lineTotal,
+			PriceSource:    price.Source,
+			PriceListID:    db.ConvertToInt4(price.PriceListId),
+			ListPrice:      listPrice,
+			DiscountAmount: discountAmount,
+			DiscountRuleID: db.ConvertToInt4(discount.RuleId),
+			VatRate:        product.VatRate,
+			VatAmount:      vatAmount,
+			GrossTotal:     grossTotal,
+		}); err != nil {
+			return decimal.Decimal{}, nil, err
+		}
+
+		totalAmount = totalAmount.Add(grossTotalDec)
+		lines = append(lines, movements.StockLine{ProductId: product.ID, Quantity: item.Quantity})
+	}
+
+	return totalAmount, lines, nil
 }
 
 func (service *Service) ListOrders(ctx context.Context, userId int32, role sqlc.Role) (*[]OrderResponse, error) {
@@ -167,13 +328,24 @@ func (service *Service) GetOrder(ctx context.Context, orderId int32, role sqlc.R
 			}
 		}
 
-		return &OrderResponse{
+		response := &OrderResponse{
 			ID:          order.ID,
 			OrderNumber: order.OrderNumber,
 			Status:      order.Status,
 			TotalAmount: order.TotalAmount,
 			OrderDate:   order.OrderDate.Time,
-		}, nil
+		}
+
+		if order.ShippingAddressLine.Valid {
+			response.ShippingAddress = &ShippingAddress{
+				AddressLine: order.ShippingAddressLine.String,
+				City:        order.ShippingCity.String,
+				PostalCode:  order.ShippingPostalCode.String,
+				Country:     order.ShippingCountry.String,
+			}
+		}
+
+		return response, nil
 	})
 }
 
@@ -211,12 +383,24 @@ func (service *Service) GetOrderItems(ctx context.Context, orderId int32, role s
 		items := make([]OrderItemResponse, len(rows))
 		for i, row := range rows {
 			items[i] = OrderItemResponse{
-				ID:          row.ID,
-				ProductID:   row.ProductID,
-				ProductName: row.ProductName,
-				Quantity:    row.Quantity,
-				UnitPrice:   row.UnitPrice,
-				LineTotal:   row.LineTotal,
+				ID:             row.ID,
+				ProductID:      row.ProductID,
+				ProductName:    row.ProductName,
+				Quantity:       row.Quantity,
+				UnitPrice:      row.UnitPrice,
+				LineTotal:      row.LineTotal,
+				PriceSource:    row.PriceSource,
+				ListPrice:      row.ListPrice,
+				DiscountAmount: row.DiscountAmount,
+				VatRate:        row.VatRate,
+				VatAmount:      row.VatAmount,
+				GrossTotal:     row.GrossTotal,
+			}
+			if row.PriceListID.Valid {
+				items[i].PriceListID = &row.PriceListID.Int32
+			}
+			if row.DiscountRuleID.Valid {
+				items[i].DiscountRuleID = &row.DiscountRuleID.Int32
 			}
 		}
 		return &items, nil
@@ -227,27 +411,126 @@ func (service *Service) UpdateOrderStatus(ctx context.Context, orderId int32, ne
 	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
 		qtx := service.query.WithTx(tx)
 
-		order, err := qtx.GetOrderById(ctx, orderId)
+		return service.ApplyStatus(ctx, qtx, orderId, newStatus)
+	})
+}
+
+// ApplyStatus moves an order to a new status inside the caller's
+// transaction, reserving, picking or dispatching stock as the status needs.
+func (service *Service) ApplyStatus(ctx context.Context, qtx *sqlc.Queries, orderId int32, newStatus sqlc.OrderStatus) error {
+	order, err := qtx.GetOrderById(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !isValidStatusTransition(order.Status, newStatus) {
+		return ErrInvalidStatusTransition
+	}
+
+	if isPaymentGated(order.Status, newStatus) {
+		if err := requirePrepayment(ctx, qtx, orderId); err != nil {
+			return err
+		}
+	}
+
+	switch newStatus {
+	case sqlc.OrderStatusCANCELLED:
+		lines, err := orderStockLines(ctx, qtx, orderId)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return ErrOrderNotFound
-			}
-			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			return err
 		}
 
-		if !isValidStatusTransition(order.Status, newStatus) {
-			return ErrInvalidStatusTransition
+		if err := service.movements.ReleaseReservation(ctx, qtx, order.WarehouseID, lines); err != nil {
+			return err
 		}
 
-		if err := qtx.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
-			ID:     orderId,
-			Status: newStatus,
-		}); err != nil {
+		if err := qtx.CancelProformaForOrder(ctx, orderId); err != nil {
 			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
 		}
+	case sqlc.OrderStatusINPREPARATION:
+		lines, err := orderStockLines(ctx, qtx, orderId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := service.movements.BuildPickList(ctx, qtx, order.WarehouseID, orderId, lines); err != nil {
+			return err
+		}
+	case sqlc.OrderStatusSHIPPED:
+		lines, err := orderStockLines(ctx, qtx, orderId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := service.movements.DispatchOrder(ctx, qtx, order.WarehouseID, orderId, lines, nil); err != nil {
+			return err
+		}
+
+		if _, err := service.documents.IssueOrderDispatch(ctx, qtx, order.WarehouseID, orderId, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := qtx.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
+		ID:     orderId,
+		Status: newStatus,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func (service *Service) GetPickList(ctx context.Context, orderId int32, warehouseScope *int32) ([]movements.PickListItem, error) {
+	order, err := service.query.GetOrderById(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if warehouseScope != nil && order.WarehouseID != *warehouseScope {
+		return nil, ErrOrderForbidden
+	}
 
+	return service.movements.PickList(ctx, service.query, orderId)
+}
+
+func orderStockLines(ctx context.Context, qtx *sqlc.Queries, orderId int32) ([]movements.StockLine, error) {
+	items, err := qtx.GetOrderItems(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	lines := make([]movements.StockLine, len(items))
+	for i, item := range items {
+		lines[i] = movements.StockLine{ProductId: item.ProductID, Quantity: item.Quantity}
+	}
+
+	return lines, nil
+}
+
+// requirePrepayment stops orders of prepayment customers until their
+// pro-forma is paid.
+func requirePrepayment(ctx context.Context, qtx *sqlc.Queries, orderId int32) error {
+	status, err := qtx.GetOrderPrepaymentStatus(ctx, orderId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !status.RequiresPrepayment {
 		return nil
-	})
+	}
+
+	if !status.ProformaStatus.Valid || status.ProformaStatus.ProformaStatus != sqlc.ProformaStatusPAID {
+		return ErrPrepaymentRequired
+	}
+
+	return nil
 }
 
 func isValidStatusTransition(current, next sqlc.OrderStatus) bool {
@@ -256,7 +539,16 @@ func isValidStatusTransition(current, next sqlc.OrderStatus) bool {
 		return next == sqlc.OrderStatusINPREPARATION || next == sqlc.OrderStatusCANCELLED
 	case sqlc.OrderStatusINPREPARATION:
 		return next == sqlc.OrderStatusSHIPPED || next == sqlc.OrderStatusCANCELLED
+	case sqlc.OrderStatusONHOLD:
+		// Held orders only go back to NEW through ReleaseOrder.
+		return next == sqlc.OrderStatusCANCELLED
 	default:
 		return false
 	}
 }
+
+// isPaymentGated reports whether a transition has to wait for prepayment.
+// Stock is only committed to an order once it is being prepared.
+func isPaymentGated(current, next sqlc.OrderStatus) bool {
+	return current == sqlc.OrderStatusNEW && next == sqlc.OrderStatusINPREPARATION
+}
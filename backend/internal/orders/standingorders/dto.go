@@ -0,0 +1,70 @@
+package standingorders
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/orders"
+	"time"
+)
+
+// StandingOrderRequest describes a whole template; an update replaces the
+// lines and skip dates as well.
+type StandingOrderRequest struct {
+	Name        string `json:"name" validate:"required,max=200"`
+	AddressId   int32  `json:"addressId" validate:"required"`
+	WarehouseId *int32 `json:"warehouseId"`
+	// Weekdays are ISO weekdays, 1 = Monday ... 7 = Sunday.
+	Weekdays  []int32                   `json:"weekdays" validate:"required,min=1,dive,min=1,max=7"`
+	StartDate time.Time                 `json:"startDate" validate:"required"`
+	EndDate   *time.Time                `json:"endDate"`
+	Items     []orders.OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	// SkipDates are days the template does not deliver on, such as public
+	// holidays.
+	SkipDates []time.Time `json:"skipDates"`
+}
+
+// UpdateOccurrenceRequest changes a single delivery date: Skip cancels it,
+// otherwise Items replace the template's lines for that date only.
+type UpdateOccurrenceRequest struct {
+	Skip  bool                      `json:"skip"`
+	Items []orders.OrderItemRequest `json:"items" validate:"omitempty,dive"`
+}
+
+type ListStandingOrdersResponse struct {
+	StandingOrders []StandingOrderResponse `json:"standingOrders"`
+}
+
+type StandingOrderResponse struct {
+	ID          int32               `json:"id"`
+	Name        string              `json:"name"`
+	AddressId   int32               `json:"addressId"`
+	WarehouseId *int32              `json:"warehouseId"`
+	Weekdays    []int32             `json:"weekdays"`
+	StartDate   time.Time           `json:"startDate"`
+	EndDate     *time.Time          `json:"endDate"`
+	IsPaused    bool                `json:"isPaused"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	Items       []StandingOrderItem `json:"items"`
+	SkipDates   []time.Time         `json:"skipDates"`
+}
+
+type StandingOrderItem struct {
+	ProductID   int32  `json:"productId"`
+	ProductName string `json:"productName"`
+	Quantity    int32  `json:"quantity"`
+}
+
+type ListOccurrencesResponse struct {
+	Occurrences []OccurrenceResponse `json:"occurrences"`
+}
+
+// OccurrenceResponse is one scheduled delivery date. Items are the lines the
+// order is placed with: the date's own lines when CustomLines is set, the
+// template's otherwise.
+type OccurrenceResponse struct {
+	DeliveryDate  time.Time                          `json:"deliveryDate"`
+	Status        sqlc.StandingOrderOccurrenceStatus `json:"status"`
+	CustomLines   bool                               `json:"customLines"`
+	Items         []StandingOrderItem                `json:"items"`
+	OrderId       *int32                             `json:"orderId"`
+	FailureReason *string                            `json:"failureReason"`
+}
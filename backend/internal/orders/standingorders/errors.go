@@ -0,0 +1,20 @@
+package standingorders
+
+import "errors"
+
+var (
+	ErrCompanyIdRequired       = errors.New("company id is required")
+	ErrInvalidCompanyId        = errors.New("invalid company id")
+	ErrCompanyNotFound         = errors.New("company not found")
+	ErrStandingOrderIdRequired = errors.New("standing order id is required")
+	ErrInvalidStandingOrderId  = errors.New("invalid standing order id")
+	ErrStandingOrderNotFound   = errors.New("standing order not found")
+	ErrInvalidDate             = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidDateRange        = errors.New("end date is before start date")
+	ErrDateRangeTooLong        = errors.New("date range is too long")
+	ErrDuplicateProduct        = errors.New("product is listed more than once")
+	ErrDateNotScheduled        = errors.New("standing order does not deliver on this date")
+	ErrDateInPast              = errors.New("date is in the past")
+	ErrItemsRequired           = errors.New("items are required unless the date is skipped")
+	ErrOccurrenceProcessed     = errors.New("order for this date has already been processed")
+)
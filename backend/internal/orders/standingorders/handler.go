@@ -0,0 +1,309 @@
+package standingorders
+
+import (
+	"errors"
+	"mleczarnia/internal/companies/addresses"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/orders"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/warehouse/locations"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultOccurrenceDays is how many days of occurrences are listed when no
+// range is given.
+const defaultOccurrenceDays = 14
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListStandingOrders(writer http.ResponseWriter, request *http.Request) {
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	standingOrders, err := handler.service.ListStandingOrders(request.Context(), companyId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListStandingOrdersResponse{StandingOrders: *standingOrders})
+}
+
+func (handler *Handler) CreateStandingOrder(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var req StandingOrderRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &req); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	standingOrder, err := handler.service.CreateStandingOrder(request.Context(), companyId, int32(claims.UserId), req)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, standingOrder)
+}
+
+func (handler *Handler) GetStandingOrder(writer http.ResponseWriter, request *http.Request) {
+	companyId, standingOrderId, err := handler.extractIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	standingOrder, err := handler.service.GetStandingOrder(request.Context(), companyId, standingOrderId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, standingOrder)
+}
+
+func (handler *Handler) UpdateStandingOrder(writer http.ResponseWriter, request *http.Request) {
+	companyId, standingOrderId, err := handler.extractIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var req StandingOrderRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &req); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	standingOrder, err := handler.service.UpdateStandingOrder(request.Context(), companyId, standingOrderId, req)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, standingOrder)
+}
+
+func (handler *Handler) PauseStandingOrder(writer http.ResponseWriter, request *http.Request) {
+	handler.setPaused(writer, request, true)
+}
+
+func (handler *Handler) ResumeStandingOrder(writer http.ResponseWriter, request *http.Request) {
+	handler.setPaused(writer, request, false)
+}
+
+func (handler *Handler) setPaused(writer http.ResponseWriter, request *http.Request, paused bool) {
+	companyId, standingOrderId, err := handler.extractIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.SetPaused(request.Context(), companyId, standingOrderId, paused); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// ListOccurrences lists the delivery dates between ?from= and ?to=
+// (YYYY-MM-DD), by default the next two weeks.
+func (handler *Handler) ListOccurrences(writer http.ResponseWriter, request *http.Request) {
+	companyId, standingOrderId, err := handler.extractIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	from := today()
+	if fromDate, err := parseDate(request.URL.Query().Get("from")); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	} else if fromDate != nil {
+		from = *fromDate
+	}
+
+	to := from.AddDate(0, 0, defaultOccurrenceDays-1)
+	if toDate, err := parseDate(request.URL.Query().Get("to")); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	} else if toDate != nil {
+		to = *toDate
+	}
+
+	occurrences, err := handler.service.ListOccurrences(request.Context(), companyId, standingOrderId, from, to)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListOccurrencesResponse{Occurrences: *occurrences})
+}
+
+func (handler *Handler) UpdateOccurrence(writer http.ResponseWriter, request *http.Request) {
+	companyId, standingOrderId, err := handler.extractIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	date, err := handler.extractDate(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var req UpdateOccurrenceRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &req); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.UpdateOccurrence(request.Context(), companyId, standingOrderId, date, req); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) RevertOccurrence(writer http.ResponseWriter, request *http.Request) {
+	companyId, standingOrderId, err := handler.extractIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	date, err := handler.extractDate(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.RevertOccurrence(request.Context(), companyId, standingOrderId, date); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	return &date, nil
+}
+
+func (handler *Handler) extractDate(request *http.Request) (time.Time, error) {
+	date, err := parseDate(chi.URLParam(request, "date"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date == nil {
+		return time.Time{}, ErrInvalidDate
+	}
+
+	return *date, nil
+}
+
+func (handler *Handler) extractIds(request *http.Request) (int32, int32, error) {
+	companyId, err := handler.extractCompanyId(request)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	standingOrderIdStr := chi.URLParam(request, "standingOrderId")
+	if standingOrderIdStr == "" {
+		return 0, 0, ErrStandingOrderIdRequired
+	}
+
+	standingOrderId, err := strconv.Atoi(standingOrderIdStr)
+	if err != nil {
+		return 0, 0, ErrInvalidStandingOrderId
+	}
+
+	return companyId, int32(standingOrderId), nil
+}
+
+func (handler *Handler) extractCompanyId(request *http.Request) (int32, error) {
+	companyIdStr := chi.URLParam(request, "companyId")
+	if companyIdStr == "" {
+		return 0, ErrCompanyIdRequired
+	}
+
+	companyId, err := strconv.Atoi(companyIdStr)
+	if err != nil {
+		return 0, ErrInvalidCompanyId
+	}
+
+	return int32(companyId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrCompanyIdRequired),
+		errors.Is(err, ErrInvalidCompanyId),
+		errors.Is(err, ErrStandingOrderIdRequired),
+		errors.Is(err, ErrInvalidStandingOrderId),
+		errors.Is(err, ErrInvalidDate),
+		errors.Is(err, ErrInvalidDateRange),
+		errors.Is(err, ErrDateRangeTooLong),
+		errors.Is(err, ErrDuplicateProduct),
+		errors.Is(err, ErrDateNotScheduled),
+		errors.Is(err, ErrDateInPast),
+		errors.Is(err, ErrItemsRequired),
+		errors.Is(err, orders.ErrNotShippingAddress):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, ErrCompanyNotFound),
+		errors.Is(err, ErrStandingOrderNotFound),
+		errors.Is(err, addresses.ErrAddressNotFound),
+		errors.Is(err, products.ErrProductNotFound),
+		errors.Is(err, locations.ErrWarehouseNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, ErrOccurrenceProcessed),
+		errors.Is(err, locations.ErrWarehouseInactive):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,28 @@
+package standingorders
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Router is mounted under a company, so clients can manage their own
+// standing orders.
+func Router(handler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+	router.Use(middleware.RequireRoleOrCompanyOwner("companyId", sqlc.RoleADMIN, sqlc.RoleSTAFF))
+
+	router.Get("/", handler.ListStandingOrders)
+	router.Post("/", handler.CreateStandingOrder)
+	router.Get("/{standingOrderId}", handler.GetStandingOrder)
+	router.Put("/{standingOrderId}", handler.UpdateStandingOrder)
+	router.Patch("/{standingOrderId}/pause", handler.PauseStandingOrder)
+	router.Patch("/{standingOrderId}/resume", handler.ResumeStandingOrder)
+	router.Get("/{standingOrderId}/occurrences", handler.ListOccurrences)
+	router.Put("/{standingOrderId}/occurrences/{date}", handler.UpdateOccurrence)
+	router.Delete("/{standingOrderId}/occurrences/{date}", handler.RevertOccurrence)
+
+	return router
+}
@@ -0,0 +1,731 @@
+package standingorders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/companies/addresses"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/orders"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/warehouse/locations"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// maxOccurrenceDays caps how many days of occurrences can be listed at once.
+const maxOccurrenceDays = 92
+
+type Service struct {
+	query  *sqlc.Queries
+	pool   *pgxpool.Pool
+	orders *orders.Service
+	// leadDays is how many days ahead of a delivery date its order is
+	// placed.
+	leadDays int32
+}
+
+func NewService(queries *sqlc.Queries, pool *pgxpool.Pool, ordersService *orders.Service, leadDays int32) *Service {
+	return &Service{
+		query:    queries,
+		pool:     pool,
+		orders:   ordersService,
+		leadDays: leadDays,
+	}
+}
+
+func (service *Service) ListStandingOrders(ctx context.Context, companyId int32) (*[]StandingOrderResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*[]StandingOrderResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		templates, err := qtx.ListStandingOrders(ctx, companyId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		response := make([]StandingOrderResponse, 0, len(templates))
+		for _, template := range templates {
+			standingOrder, err := buildStandingOrder(ctx, qtx, template)
+			if err != nil {
+				return nil, err
+			}
+			response = append(response, *standingOrder)
+		}
+
+		return &response, nil
+	})
+}
+
+func (service *Service) GetStandingOrder(ctx context.Context, companyId, standingOrderId int32) (*StandingOrderResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StandingOrderResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		template, err := getStandingOrder(ctx, qtx, companyId, standingOrderId)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildStandingOrder(ctx, qtx, *template)
+	})
+}
+
+func (service *Service) CreateStandingOrder(ctx context.Context, companyId, userId int32, req StandingOrderRequest) (*StandingOrderResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StandingOrderResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		if _, err := qtx.GetCustomerCompanyById(ctx, companyId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrCompanyNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := validateTemplate(ctx, qtx, companyId, req); err != nil {
+			return nil, err
+		}
+
+		template, err := qtx.CreateStandingOrder(ctx, sqlc.CreateStandingOrderParams{
+			CustomerID:  companyId,
+			Name:        req.Name,
+			AddressID:   req.AddressId,
+			WarehouseID: db.ConvertToInt4(req.WarehouseId),
+			Weekdays:    normalizeWeekdays(req.Weekdays),
+			StartDate:   db.ConvertToDate(&req.StartDate),
+			EndDate:     db.ConvertToDate(req.EndDate),
+			CreatedBy:   userId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := insertTemplateDetails(ctx, qtx, template.ID, req); err != nil {
+			return nil, err
+		}
+
+		return buildStandingOrder(ctx, qtx, template)
+	})
+}
+
+// UpdateStandingOrder replaces the template with req. Dates the client has
+// already changed or skipped keep their changes.
+func (service *Service) UpdateStandingOrder(ctx context.Context, companyId, standingOrderId int32, req StandingOrderRequest) (*StandingOrderResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StandingOrderResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		if err := validateTemplate(ctx, qtx, companyId, req); err != nil {
+			return nil, err
+		}
+
+		template, err := qtx.UpdateStandingOrder(ctx, sqlc.UpdateStandingOrderParams{
+			ID:          standingOrderId,
+			CustomerID:  companyId,
+			Name:        req.Name,
+			AddressID:   req.AddressId,
+			WarehouseID: db.ConvertToInt4(req.WarehouseId),
+			Weekdays:    normalizeWeekdays(req.Weekdays),
+			StartDate:   db.ConvertToDate(&req.StartDate),
+			EndDate:     db.ConvertToDate(req.EndDate),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrStandingOrderNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := qtx.DeleteStandingOrderLines(ctx, template.ID); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if err := qtx.DeleteStandingOrderSkips(ctx, template.ID); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := insertTemplateDetails(ctx, qtx, template.ID, req); err != nil {
+			return nil, err
+		}
+
+		return buildStandingOrder(ctx, qtx, template)
+	})
+}
+
+// SetPaused pauses or resumes a template. No orders are placed while it is
+// paused.
+func (service *Service) SetPaused(ctx context.Context, companyId, standingOrderId int32, paused bool) error {
+	rows, err := service.query.SetStandingOrderPaused(ctx, sqlc.SetStandingOrderPausedParams{
+		ID:         standingOrderId,
+		CustomerID: companyId,
+		IsPaused:   paused,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+	if rows == 0 {
+		return ErrStandingOrderNotFound
+	}
+
+	return nil
+}
+
+// ListOccurrences lists the template's delivery dates between from and to
+// with the lines each one will be ordered with. Dates on the skip list are
+// left out.
+func (service *Service) ListOccurrences(ctx context.Context, companyId, standingOrderId int32, from, to time.Time) (*[]OccurrenceResponse, error) {
+	from, to = truncateToDate(from), truncateToDate(to)
+	if from.After(to) {
+		return nil, ErrInvalidDateRange
+	}
+	if to.Sub(from) > maxOccurrenceDays*24*time.Hour {
+		return nil, ErrDateRangeTooLong
+	}
+
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*[]OccurrenceResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		template, err := getStandingOrder(ctx, qtx, companyId, standingOrderId)
+		if err != nil {
+			return nil, err
+		}
+
+		skips, err := listSkipDates(ctx, qtx, template.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		templateLines, err := qtx.ListStandingOrderLines(ctx, template.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		items := make([]StandingOrderItem, 0, len(templateLines))
+		for _, line := range templateLines {
+			items = append(items, StandingOrderItem{
+				ProductID:   line.ProductID,
+				ProductName: line.ProductName,
+				Quantity:    line.Quantity,
+			})
+		}
+
+		rangeParams := sqlc.ListStandingOrderOccurrencesParams{
+			StandingOrderID: template.ID,
+			FromDate:        db.ConvertToDate(&from),
+			ToDate:          db.ConvertToDate(&to),
+		}
+		stored, err := qtx.ListStandingOrderOccurrences(ctx, rangeParams)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		storedLines, err := qtx.ListStandingOrderOccurrenceLines(ctx, sqlc.ListStandingOrderOccurrenceLinesParams(rangeParams))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		customItems := make(map[int32][]StandingOrderItem)
+		for _, line := range storedLines {
+			customItems[line.OccurrenceID] = append(customItems[line.OccurrenceID], StandingOrderItem{
+				ProductID:   line.ProductID,
+				ProductName: line.ProductName,
+				Quantity:    line.Quantity,
+			})
+		}
+
+		occurrences := make(map[time.Time]sqlc.StandingOrderOccurrence, len(stored))
+		for _, occurrence := range stored {
+			occurrences[occurrence.DeliveryDate.Time] = occurrence
+		}
+
+		response := []OccurrenceResponse{}
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			occurrence, ok := occurrences[date]
+			if !ok {
+				if !isScheduled(*template, date, skips) {
+					continue
+				}
+				response = append(response, OccurrenceResponse{
+					DeliveryDate: date,
+					Status:       sqlc.StandingOrderOccurrenceStatusPENDING,
+					Items:        items,
+				})
+				continue
+			}
+
+			// Occurrences are kept when the template changes, so a date
+			// that was processed or changed is listed even if the template
+			// no longer delivers on it.
+			entry := OccurrenceResponse{
+				DeliveryDate: date,
+				Status:       occurrence.Status,
+				CustomLines:  occurrence.CustomLines,
+				Items:        items,
+			}
+			if occurrence.CustomLines {
+				entry.Items = customItems[occurrence.ID]
+			}
+			if occurrence.Status == sqlc.StandingOrderOccurrenceStatusSKIPPED {
+				entry.Items = []StandingOrderItem{}
+			}
+			if occurrence.OrderID.Valid {
+				entry.OrderId = &occurrence.OrderID.Int32
+			}
+			if occurrence.FailureReason.Valid {
+				entry.FailureReason = &occurrence.FailureReason.String
+			}
+			response = append(response, entry)
+		}
+
+		return &response, nil
+	})
+}
+
+// UpdateOccurrence skips a single delivery date or orders different lines on
+// it, without touching the template. It fails once the date's order has been
+// processed.
+func (service *Service) UpdateOccurrence(ctx context.Context, companyId, standingOrderId int32, date time.Time, req UpdateOccurrenceRequest) error {
+	date = truncateToDate(date)
+	if date.Before(today()) {
+		return ErrDateInPast
+	}
+	if !req.Skip && len(req.Items) == 0 {
+		return ErrItemsRequired
+	}
+
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		template, err := getStandingOrder(ctx, qtx, companyId, standingOrderId)
+		if err != nil {
+			return err
+		}
+
+		skips, err := listSkipDates(ctx, qtx, template.ID)
+		if err != nil {
+			return err
+		}
+		if !isScheduled(*template, date, skips) {
+			return ErrDateNotScheduled
+		}
+
+		status := sqlc.StandingOrderOccurrenceStatusPENDING
+		if req.Skip {
+			status = sqlc.StandingOrderOccurrenceStatusSKIPPED
+		}
+
+		occurrenceId, err := qtx.SetStandingOrderOccurrence(ctx, sqlc.SetStandingOrderOccurrenceParams{
+			StandingOrderID: template.ID,
+			DeliveryDate:    db.ConvertToDate(&date),
+			Status:          status,
+			CustomLines:     !req.Skip,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrOccurrenceProcessed
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := qtx.DeleteStandingOrderOccurrenceLines(ctx, occurrenceId); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if req.Skip {
+			return nil
+		}
+
+		if err := validateItems(ctx, qtx, req.Items); err != nil {
+			return err
+		}
+
+		for _, item := range req.Items {
+			if err := qtx.CreateStandingOrderOccurrenceLine(ctx, sqlc.CreateStandingOrderOccurrenceLineParams{
+				OccurrenceID: occurrenceId,
+				ProductID:    item.ProductID,
+				Quantity:     item.Quantity,
+			}); err != nil {
+				return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// RevertOccurrence drops the changes made to a single delivery date, so it
+// is ordered from the template again.
+func (service *Service) RevertOccurrence(ctx context.Context, companyId, standingOrderId int32, date time.Time) error {
+	date = truncateToDate(date)
+
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		template, err := getStandingOrder(ctx, qtx, companyId, standingOrderId)
+		if err != nil {
+			return err
+		}
+
+		stored, err := qtx.ListStandingOrderOccurrences(ctx, sqlc.ListStandingOrderOccurrencesParams{
+			StandingOrderID: template.ID,
+			FromDate:        db.ConvertToDate(&date),
+			ToDate:          db.ConvertToDate(&date),
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if len(stored) == 0 {
+			return nil
+		}
+
+		rows, err := qtx.DeleteStandingOrderOccurrence(ctx, sqlc.DeleteStandingOrderOccurrenceParams{
+			StandingOrderID: template.ID,
+			DeliveryDate:    db.ConvertToDate(&date),
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if rows == 0 {
+			return ErrOccurrenceProcessed
+		}
+
+		return nil
+	})
+}
+
+// Materialize places the orders of every running template for the delivery
+// dates from today up to leadDays ahead. Each date is ordered once; a date
+// whose order failed is retried on the next run while it is still ahead. A
+// template that fails is logged and does not stop the others.
+func (service *Service) Materialize(ctx context.Context) error {
+	from := today()
+	until := from.AddDate(0, 0, int(service.leadDays))
+
+	templates, err := service.query.ListDueStandingOrders(ctx, sqlc.ListDueStandingOrdersParams{
+		ToDate:   db.ConvertToDate(&until),
+		FromDate: db.ConvertToDate(&from),
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	var errs []error
+	for _, template := range templates {
+		skips, err := listSkipDates(ctx, service.query, template.ID)
+		if err != nil {
+			logrus.WithError(err).WithField("standingOrderId", template.ID).Warn("Failed to read standing order skip dates")
+			errs = append(errs, fmt.Errorf("standing order %d: %w", template.ID, err))
+			continue
+		}
+
+		for date := from; !date.After(until); date = date.AddDate(0, 0, 1) {
+			if !isScheduled(template, date, skips) {
+				continue
+			}
+
+			if err := service.materializeDate(ctx, template, date); err != nil {
+				fields := logrus.Fields{
+					"standingOrderId": template.ID,
+					"deliveryDate":    date.Format("2006-01-02"),
+				}
+				logrus.WithError(err).WithFields(fields).Warn("Failed to place standing order")
+
+				// The order's transaction was rolled back, so the failure
+				// is recorded on its own.
+				if err := service.query.RecordStandingOrderOccurrence(ctx, sqlc.RecordStandingOrderOccurrenceParams{
+					StandingOrderID: template.ID,
+					DeliveryDate:    db.ConvertToDate(&date),
+					Status:          sqlc.StandingOrderOccurrenceStatusFAILED,
+					FailureReason:   pgtype.Text{String: err.Error(), Valid: true},
+				}); err != nil {
+					logrus.WithError(err).WithFields(fields).Warn("Failed to record standing order failure")
+					errs = append(errs, fmt.Errorf("standing order %d: %w: %v", template.ID, db.ErrDatabaseOperation, err))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (service *Service) materializeDate(ctx context.Context, template sqlc.StandingOrder, date time.Time) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		occurrence, err := qtx.LockStandingOrderOccurrence(ctx, sqlc.LockStandingOrderOccurrenceParams{
+			StandingOrderID: template.ID,
+			DeliveryDate:    db.ConvertToDate(&date),
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if occurrence.Status != sqlc.StandingOrderOccurrenceStatusPENDING &&
+			occurrence.Status != sqlc.StandingOrderOccurrenceStatusFAILED {
+			return nil
+		}
+
+		items, err := occurrenceItems(ctx, qtx, template.ID, date, occurrence.CustomLines)
+		if err != nil {
+			return err
+		}
+
+		req := orders.CreateOrderRequest{
+			AddressId: &template.AddressID,
+			Items:     items,
+		}
+		if template.WarehouseID.Valid {
+			req.WarehouseId = &template.WarehouseID.Int32
+		}
+
+		order, err := service.orders.CreateOrderForCompany(ctx, qtx, template.CustomerID, req)
+		if err != nil {
+			return err
+		}
+
+		if err := qtx.RecordStandingOrderOccurrence(ctx, sqlc.RecordStandingOrderOccurrenceParams{
+			StandingOrderID: template.ID,
+			DeliveryDate:    db.ConvertToDate(&date),
+			Status:          sqlc.StandingOrderOccurrenceStatusCREATED,
+			OrderID:         pgtype.Int4{Int32: order.ID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"standingOrderId": template.ID,
+			"deliveryDate":    date.Format("2006-01-02"),
+			"orderId":         order.ID,
+		}).Info("Standing order placed")
+
+		return nil
+	})
+}
+
+// occurrenceItems returns the lines a delivery date is ordered with: its own
+// when the client changed them, the template's otherwise.
+func occurrenceItems(ctx context.Context, qtx *sqlc.Queries, standingOrderId int32, date time.Time, customLines bool) ([]orders.OrderItemRequest, error) {
+	var items []orders.OrderItemRequest
+
+	if customLines {
+		lines, err := qtx.ListStandingOrderOccurrenceLines(ctx, sqlc.ListStandingOrderOccurrenceLinesParams{
+			StandingOrderID: standingOrderId,
+			FromDate:        db.ConvertToDate(&date),
+			ToDate:          db.ConvertToDate(&date),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		for _, line := range lines {
+			items = append(items, orders.OrderItemRequest{ProductID: line.ProductID, Quantity: line.Quantity})
+		}
+	} else {
+		lines, err := qtx.ListStandingOrderLines(ctx, standingOrderId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		for _, line := range lines {
+			items = append(items, orders.OrderItemRequest{ProductID: line.ProductID, Quantity: line.Quantity})
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, ErrItemsRequired
+	}
+
+	return items, nil
+}
+
+func getStandingOrder(ctx context.Context, qtx *sqlc.Queries, companyId, standingOrderId int32) (*sqlc.StandingOrder, error) {
+	template, err := qtx.GetStandingOrder(ctx, sqlc.GetStandingOrderParams{
+		ID:         standingOrderId,
+		CustomerID: companyId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStandingOrderNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return &template, nil
+}
+
+// validateTemplate checks that the address is one of the company's shipping
+// addresses and that the warehouse and products exist.
+func validateTemplate(ctx context.Context, qtx *sqlc.Queries, companyId int32, req StandingOrderRequest) error {
+	if req.EndDate != nil && truncateToDate(*req.EndDate).Before(truncateToDate(req.StartDate)) {
+		return ErrInvalidDateRange
+	}
+
+	address, err := qtx.GetCompanyAddress(ctx, sqlc.GetCompanyAddressParams{
+		ID:                req.AddressId,
+		CustomerCompanyID: companyId,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return addresses.ErrAddressNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+	if address.Type != sqlc.AddressTypeSHIPPING {
+		return orders.ErrNotShippingAddress
+	}
+
+	if req.WarehouseId != nil {
+		warehouse, err := qtx.GetWarehouseById(ctx, *req.WarehouseId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return locations.ErrWarehouseNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		if !warehouse.IsActive {
+			return locations.ErrWarehouseInactive
+		}
+	}
+
+	return validateItems(ctx, qtx, req.Items)
+}
+
+func validateItems(ctx context.Context, qtx *sqlc.Queries, items []orders.OrderItemRequest) error {
+	seen := make(map[int32]bool, len(items))
+	for _, item := range items {
+		if seen[item.ProductID] {
+			return ErrDuplicateProduct
+		}
+		seen[item.ProductID] = true
+
+		if _, err := qtx.GetProductById(ctx, item.ProductID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return products.ErrProductNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return nil
+}
+
+func insertTemplateDetails(ctx context.Context, qtx *sqlc.Queries, standingOrderId int32, req StandingOrderRequest) error {
+	for _, item := range req.Items {
+		if err := qtx.CreateStandingOrderLine(ctx, sqlc.CreateStandingOrderLineParams{
+			StandingOrderID: standingOrderId,
+			ProductID:       item.ProductID,
+			Quantity:        item.Quantity,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	for _, skipDate := range req.SkipDates {
+		skipDate = truncateToDate(skipDate)
+		if err := qtx.CreateStandingOrderSkip(ctx, sqlc.CreateStandingOrderSkipParams{
+			StandingOrderID: standingOrderId,
+			SkipDate:        db.ConvertToDate(&skipDate),
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return nil
+}
+
+func buildStandingOrder(ctx context.Context, qtx *sqlc.Queries, template sqlc.StandingOrder) (*StandingOrderResponse, error) {
+	lines, err := qtx.ListStandingOrderLines(ctx, template.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	skips, err := qtx.ListStandingOrderSkips(ctx, template.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	response := StandingOrderResponse{
+		ID:        template.ID,
+		Name:      template.Name,
+		AddressId: template.AddressID,
+		Weekdays:  template.Weekdays,
+		StartDate: template.StartDate.Time,
+		IsPaused:  template.IsPaused,
+		CreatedAt: template.CreatedAt.Time,
+		Items:     make([]StandingOrderItem, 0, len(lines)),
+		SkipDates: make([]time.Time, 0, len(skips)),
+	}
+	if template.WarehouseID.Valid {
+		response.WarehouseId = &template.WarehouseID.Int32
+	}
+	if template.EndDate.Valid {
+		response.EndDate = &template.EndDate.Time
+	}
+
+	for _, line := range lines {
+		response.Items = append(response.Items, StandingOrderItem{
+			ProductID:   line.ProductID,
+			ProductName: line.ProductName,
+			Quantity:    line.Quantity,
+		})
+	}
+	for _, skip := range skips {
+		response.SkipDates = append(response.SkipDates, skip.Time)
+	}
+
+	return &response, nil
+}
+
+func listSkipDates(ctx context.Context, qtx *sqlc.Queries, standingOrderId int32) (map[time.Time]bool, error) {
+	skips, err := qtx.ListStandingOrderSkips(ctx, standingOrderId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	dates := make(map[time.Time]bool, len(skips))
+	for _, skip := range skips {
+		dates[skip.Time] = true
+	}
+
+	return dates, nil
+}
+
+// isScheduled reports whether the template delivers on date: within its
+// start and end date, on one of its weekdays and not on its skip list.
+func isScheduled(template sqlc.StandingOrder, date time.Time, skips map[time.Time]bool) bool {
+	if date.Before(template.StartDate.Time) {
+		return false
+	}
+	if template.EndDate.Valid && date.After(template.EndDate.Time) {
+		return false
+	}
+	if skips[date] {
+		return false
+	}
+
+	return slices.Contains(template.Weekdays, isoWeekday(date))
+}
+
+// isoWeekday numbers the days from 1 = Monday to 7 = Sunday.
+func isoWeekday(date time.Time) int32 {
+	if date.Weekday() == time.Sunday {
+		return 7
+	}
+	return int32(date.Weekday())
+}
+
+func normalizeWeekdays(weekdays []int32) []int32 {
+	normalized := slices.Clone(weekdays)
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// today is the current date in UTC, the zone delivery dates are kept in.
+func today() time.Time {
+	return truncateToDate(time.Now().UTC())
+}
+
+// truncateToDate drops the time of day, leaving midnight UTC as pgtype.Date
+// values are read back.
+func truncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,68 @@
+package payments
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Payment struct {
+	Id          int32              `json:"id"`
+	CompanyId   int32              `json:"companyId"`
+	CompanyName string             `json:"companyName"`
+	Amount      string             `json:"amount"`
+	PaymentDate time.Time          `json:"paymentDate"`
+	Method      sqlc.PaymentMethod `json:"method"`
+	Reference   *string            `json:"reference"`
+	// Unallocated is the part of the payment not yet settling any invoice.
+	Unallocated string    `json:"unallocated"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type ListPaymentsResponse struct {
+	Payments []Payment `json:"payments"`
+}
+
+type PaymentDetails struct {
+	Id          int32              `json:"id"`
+	CompanyId   int32              `json:"companyId"`
+	CompanyName string             `json:"companyName"`
+	Amount      string             `json:"amount"`
+	PaymentDate time.Time          `json:"paymentDate"`
+	Method      sqlc.PaymentMethod `json:"method"`
+	Reference   *string            `json:"reference"`
+	Unallocated string             `json:"unallocated"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	Allocations []Allocation       `json:"allocations"`
+}
+
+type Allocation struct {
+	Id            int32     `json:"id"`
+	InvoiceId     int32     `json:"invoiceId"`
+	InvoiceNumber string    `json:"invoiceNumber"`
+	Amount        string    `json:"amount"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// CreatePaymentRequest records money received from a company. Allocations
+// may cover only part of the amount; the rest stays on the company's account
+// as credit.
+type CreatePaymentRequest struct {
+	CompanyId   int32               `json:"companyId" validate:"required"`
+	Amount      decimal.Decimal     `json:"amount" validate:"required,decimalpos"`
+	PaymentDate time.Time           `json:"paymentDate" validate:"required"`
+	Method      sqlc.PaymentMethod  `json:"method" validate:"required,oneof=BANK_TRANSFER CASH CARD OTHER"`
+	Reference   *string             `json:"reference" validate:"omitempty,max=255"`
+	Allocations []AllocationRequest `json:"allocations" validate:"dive"`
+}
+
+type AllocationRequest struct {
+	InvoiceId int32           `json:"invoiceId" validate:"required"`
+	Amount    decimal.Decimal `json:"amount" validate:"required,decimalpos"`
+}
+
+// AllocatePaymentRequest settles invoices from what is left of a payment.
+type AllocatePaymentRequest struct {
+	Allocations []AllocationRequest `json:"allocations" validate:"required,min=1,dive"`
+}
@@ -0,0 +1,17 @@
+package payments
+
+import "errors"
+
+var (
+	ErrPaymentIdRequired     = errors.New("payment id required")
+	ErrInvalidPaymentId      = errors.New("invalid payment id")
+	ErrPaymentNotFound       = errors.New("payment not found")
+	ErrInvalidCompanyId      = errors.New("invalid company id")
+	ErrInvoiceNotFound       = errors.New("invoice not found")
+	ErrFailedToCreateDecimal = errors.New("internal server error")
+
+	ErrInvoiceOfOtherCustomer       = errors.New("invoice belongs to another company")
+	ErrDuplicateInvoice             = errors.New("invoice allocated more than once")
+	ErrAllocationExceedsOutstanding = errors.New("allocation exceeds the amount outstanding on the invoice")
+	ErrAllocationExceedsPayment     = errors.New("allocations exceed the unallocated amount of the payment")
+)
@@ -0,0 +1,138 @@
+package payments
+
+import (
+	"errors"
+	"mleczarnia/internal/companies"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListPayments(writer http.ResponseWriter, request *http.Request) {
+	var companyId *int32
+	if companyIdStr := request.URL.Query().Get("companyId"); companyIdStr != "" {
+		value, err := strconv.Atoi(companyIdStr)
+		if err != nil {
+			handler.handleServiceError(writer, ErrInvalidCompanyId)
+			return
+		}
+		id := int32(value)
+		companyId = &id
+	}
+
+	payments, err := handler.service.ListPayments(request.Context(), companyId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListPaymentsResponse{Payments: payments})
+}
+
+func (handler *Handler) GetPayment(writer http.ResponseWriter, request *http.Request) {
+	paymentId, err := handler.extractPaymentId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	payment, err := handler.service.GetPayment(request.Context(), paymentId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, payment)
+}
+
+func (handler *Handler) RecordPayment(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	var body CreatePaymentRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payment, err := handler.service.RecordPayment(request.Context(), int32(claims.UserId), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, payment)
+}
+
+func (handler *Handler) AllocatePayment(writer http.ResponseWriter, request *http.Request) {
+	paymentId, err := handler.extractPaymentId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body AllocatePaymentRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	payment, err := handler.service.AllocatePayment(request.Context(), paymentId, body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, payment)
+}
+
+func (handler *Handler) extractPaymentId(request *http.Request) (int32, error) {
+	paymentIdStr := chi.URLParam(request, "paymentId")
+	if paymentIdStr == "" {
+		return 0, ErrPaymentIdRequired
+	}
+
+	paymentId, err := strconv.Atoi(paymentIdStr)
+	if err != nil {
+		return 0, ErrInvalidPaymentId
+	}
+
+	return int32(paymentId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrPaymentIdRequired), errors.Is(err, ErrInvalidPaymentId),
+		errors.Is(err, ErrInvalidCompanyId), errors.Is(err, ErrDuplicateInvoice),
+		errors.Is(err, ErrInvoiceOfOtherCustomer):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, ErrPaymentNotFound), errors.Is(err, ErrInvoiceNotFound),
+		errors.Is(err, companies.ErrCompanyNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, ErrAllocationExceedsOutstanding), errors.Is(err, ErrAllocationExceedsPayment):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
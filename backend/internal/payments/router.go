@@ -0,0 +1,25 @@
+package payments
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(paymentsHandler *Handler, middleware *app.Middleware, statementsRouter http.Handler) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Get("/", paymentsHandler.ListPayments)
+		r.Get("/{paymentId}", paymentsHandler.GetPayment)
+		r.Post("/", paymentsHandler.RecordPayment)
+		r.Post("/{paymentId}/allocations", paymentsHandler.AllocatePayment)
+	})
+
+	router.Mount("/statements", statementsRouter)
+
+	return router
+}
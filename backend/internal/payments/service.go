@@ -0,0 +1,349 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/companies"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type Service struct {
+	query *sqlc.Queries
+	pool  *pgxpool.Pool
+}
+
+func NewService(queries *sqlc.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{query: queries, pool: pool}
+}
+
+func (service *Service) ListPayments(ctx context.Context, companyId *int32) ([]Payment, error) {
+	rows, err := service.query.ListPayments(ctx, db.ConvertToInt4(companyId))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Payment, len(rows))
+	for i, row := range rows {
+		result[i] = Payment{
+			Id:          row.ID,
+			CompanyId:   row.CustomerID,
+			CompanyName: row.CompanyName,
+			Amount:      row.Amount,
+			PaymentDate: row.PaymentDate.Time,
+			Method:      row.Method,
+			Unallocated: row.Unallocated,
+			CreatedAt:   row.CreatedAt.Time,
+		}
+		if row.Reference.Valid {
+			result[i].Reference = &row.Reference.String
+		}
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetPayment(ctx context.Context, paymentId int32) (*PaymentDetails, error) {
+	return getPayment(ctx, service.query, paymentId)
+}
+
+// RecordPayment stores a payment together with the invoices it settles.
+func (service *Service) RecordPayment(ctx context.Context, userId int32, req CreatePaymentRequest) (*PaymentDetails, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*PaymentDetails, error) {
+		qtx := service.query.WithTx(tx)
+
+		paymentId, err := service.Record(ctx, qtx, &userId, req)
+		if err != nil {
+			return nil, err
+		}
+
+		return getPayment(ctx, qtx, paymentId)
+	})
+}
+
+// AllocatePayment settles invoices from the unallocated part of an earlier
+// payment, e.g. an overpayment kept as credit.
+func (service *Service) AllocatePayment(ctx context.Context, paymentId int32, req AllocatePaymentRequest) (*PaymentDetails, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*PaymentDetails, error) {
+		qtx := service.query.WithTx(tx)
+
+		if err := service.Allocate(ctx, qtx, paymentId, req.Allocations); err != nil {
+			return nil, err
+		}
+
+		return getPayment(ctx, qtx, paymentId)
+	})
+}
+
+// Record stores a payment within the caller's transaction and allocates it.
+// createdBy is nil for payments no user entered by hand.
+func (service *Service) Record(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	createdBy *int32,
+	req CreatePaymentRequest,
+) (int32, error) {
+	if _, err := qtx.GetCustomerCompanyById(ctx, req.CompanyId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, companies.ErrCompanyNotFound
+		}
+		return 0, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	amount, err := db.DecimalToNumeric(req.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	payment, err := qtx.CreatePayment(ctx, sqlc.CreatePaymentParams{
+		CustomerID:  req.CompanyId,
+		Amount:      amount,
+		PaymentDate: db.ConvertToDate(&req.PaymentDate),
+		Method:      req.Method,
+		Reference:   db.ConvertToText(req.Reference),
+		CreatedBy:   db.ConvertToInt4(createdBy),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if err := service.Allocate(ctx, qtx, payment.ID, req.Allocations); err != nil {
+		return 0, err
+	}
+
+	return payment.ID, nil
+}
+
+// Allocate settles invoices of the payment's company from what is left of
+// the payment and updates their statuses.
+func (service *Service) Allocate(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	paymentId int32,
+	allocations []AllocationRequest,
+) error {
+	payment, err := qtx.GetPaymentForUpdate(ctx, paymentId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPaymentNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	unallocated, err := decimal.NewFromString(payment.Unallocated)
+	if err != nil {
+		return ErrFailedToCreateDecimal
+	}
+
+	seen := make(map[int32]bool, len(allocations))
+	for _, allocation := range allocations {
+		if seen[allocation.InvoiceId] {
+			return ErrDuplicateInvoice
+		}
+		seen[allocation.InvoiceId] = true
+
+		balance, err := qtx.GetInvoiceBalanceForUpdate(ctx, allocation.InvoiceId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrInvoiceNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if balance.CustomerID != payment.CustomerID {
+			return ErrInvoiceOfOtherCustomer
+		}
+
+		due, paid, err := parseBalance(balance.AmountDue, balance.AmountPaid)
+		if err != nil {
+			return err
+		}
+
+		if allocation.Amount.GreaterThan(due.Sub(paid)) {
+			return ErrAllocationExceedsOutstanding
+		}
+
+		if allocation.Amount.GreaterThan(unallocated) {
+			return ErrAllocationExceedsPayment
+		}
+		unallocated = unallocated.Sub(allocation.Amount)
+
+		amount, err := db.DecimalToNumeric(allocation.Amount)
+		if err != nil {
+			return err
+		}
+
+		if err := qtx.CreatePaymentAllocation(ctx, sqlc.CreatePaymentAllocationParams{
+			PaymentID: paymentId,
+			InvoiceID: allocation.InvoiceId,
+			Amount:    amount,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := service.RefreshInvoiceStatus(ctx, qtx, allocation.InvoiceId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RefreshInvoiceStatus derives the invoice status from its balance. It has to
+// run whenever a payment or a correction changes what is outstanding. When a
+// correction leaves the invoice paid above what is due, the excess goes back
+// to the payments as unallocated credit.
+func (service *Service) RefreshInvoiceStatus(ctx context.Context, qtx *sqlc.Queries, invoiceId int32) error {
+	balance, err := qtx.GetInvoiceBalanceForUpdate(ctx, invoiceId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInvoiceNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	due, paid, err := parseBalance(balance.AmountDue, balance.AmountPaid)
+	if err != nil {
+		return err
+	}
+
+	if excess := paid.Sub(decimal.Max(due, decimal.Zero)); excess.IsPositive() {
+		if err := releaseAllocations(ctx, qtx, invoiceId, excess); err != nil {
+			return err
+		}
+		paid = paid.Sub(excess)
+	}
+
+	status := deriveStatus(balance.Status, due, paid)
+	if status == balance.Status {
+		return nil
+	}
+
+	if _, err := qtx.UpdateInvoiceStatus(ctx, sqlc.UpdateInvoiceStatusParams{
+		ID:     invoiceId,
+		Status: status,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// releaseAllocations takes amount off the invoice's allocations, newest
+// first, so it becomes unallocated on their payments again.
+func releaseAllocations(ctx context.Context, qtx *sqlc.Queries, invoiceId int32, amount decimal.Decimal) error {
+	allocations, err := qtx.ListInvoiceAllocationsForUpdate(ctx, invoiceId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	for _, allocation := range allocations {
+		if !amount.IsPositive() {
+			break
+		}
+
+		allocated, err := decimal.NewFromString(allocation.Amount)
+		if err != nil {
+			return ErrFailedToCreateDecimal
+		}
+
+		if allocated.LessThanOrEqual(amount) {
+			if err := qtx.DeletePaymentAllocation(ctx, allocation.ID); err != nil {
+				return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+			amount = amount.Sub(allocated)
+			continue
+		}
+
+		remaining, err := db.DecimalToNumeric(allocated.Sub(amount))
+		if err != nil {
+			return err
+		}
+		if err := qtx.UpdatePaymentAllocationAmount(ctx, sqlc.UpdatePaymentAllocationAmountParams{
+			ID:     allocation.ID,
+			Amount: remaining,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+		amount = decimal.Zero
+	}
+
+	return nil
+}
+
+// deriveStatus keeps an unsettled invoice OVERDUE once it has been marked so;
+// only payment in full clears it.
+func deriveStatus(current sqlc.InvoiceStatus, due, paid decimal.Decimal) sqlc.InvoiceStatus {
+	switch {
+	case paid.GreaterThanOrEqual(due):
+		return sqlc.InvoiceStatusPAID
+	case current == sqlc.InvoiceStatusOVERDUE:
+		return sqlc.InvoiceStatusOVERDUE
+	case paid.IsPositive():
+		return sqlc.InvoiceStatusPARTIALLYPAID
+	default:
+		return sqlc.InvoiceStatusUNPAID
+	}
+}
+
+func parseBalance(amountDue, amountPaid string) (decimal.Decimal, decimal.Decimal, error) {
+	due, err := decimal.NewFromString(amountDue)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, ErrFailedToCreateDecimal
+	}
+
+	paid, err := decimal.NewFromString(amountPaid)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, ErrFailedToCreateDecimal
+	}
+
+	return due, paid, nil
+}
+
+func getPayment(ctx context.Context, qtx *sqlc.Queries, paymentId int32) (*PaymentDetails, error) {
+	payment, err := qtx.GetPaymentById(ctx, paymentId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	allocations, err := qtx.ListPaymentAllocations(ctx, paymentId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	details := PaymentDetails{
+		Id:          payment.ID,
+		CompanyId:   payment.CustomerID,
+		CompanyName: payment.CompanyName,
+		Amount:      payment.Amount,
+		PaymentDate: payment.PaymentDate.Time,
+		Method:      payment.Method,
+		Unallocated: payment.Unallocated,
+		CreatedAt:   payment.CreatedAt.Time,
+		Allocations: make([]Allocation, len(allocations)),
+	}
+
+	if payment.Reference.Valid {
+		details.Reference = &payment.Reference.String
+	}
+
+	for i, allocation := range allocations {
+		details.Allocations[i] = Allocation{
+			Id:            allocation.ID,
+			InvoiceId:     allocation.InvoiceID,
+			InvoiceNumber: allocation.InvoiceNumber,
+			Amount:        allocation.Amount,
+			CreatedAt:     allocation.CreatedAt.Time,
+		}
+	}
+
+	return &details, nil
+}
@@ -0,0 +1,168 @@
+package statements
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// csvColumns lists the header names banks use for each column we read,
+// lower-cased. Date, amount and title are required.
+var csvColumns = map[string][]string{
+	"date":      {"data", "data operacji", "data księgowania", "data ksiegowania", "data waluty", "date", "booking date"},
+	"amount":    {"kwota", "kwota operacji", "amount"},
+	"title":     {"tytuł", "tytul", "tytuł operacji", "tytul operacji", "opis", "opis operacji", "title", "description"},
+	"name":      {"kontrahent", "nadawca", "nazwa kontrahenta", "dane kontrahenta", "nadawca / odbiorca", "counterparty"},
+	"account":   {"rachunek kontrahenta", "numer rachunku", "nr rachunku", "rachunek", "account"},
+	"reference": {"numer referencyjny", "nr referencyjny", "referencja", "id transakcji", "reference"},
+}
+
+var csvDateLayouts = []string{"2006-01-02", "02.01.2006", "02-01-2006", "2006.01.02", "02/01/2006"}
+
+func parseCSV(text string) ([]entry, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = csvSeparator(text)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	// banks put the account and period above the header, so look for the
+	// first row that names the required columns
+	headerRow := -1
+	var columns map[string]int
+	for i, record := range records {
+		columns = csvHeader(record)
+		if columns != nil {
+			headerRow = i
+			break
+		}
+	}
+	if headerRow < 0 {
+		return nil, ErrUnrecognizedStatement
+	}
+
+	var entries []entry
+	for i, record := range records[headerRow+1:] {
+		row := headerRow + i + 2
+		if isBlank(record) {
+			continue
+		}
+
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		date, err := parseCSVDate(value("date"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: invalid date %q", ErrInvalidStatement, row, value("date"))
+		}
+
+		amount, err := parseCSVAmount(value("amount"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: invalid amount %q", ErrInvalidStatement, row, value("amount"))
+		}
+
+		e := entry{
+			bookingDate:         date,
+			amount:              amount,
+			counterpartyName:    value("name"),
+			counterpartyAccount: strings.ReplaceAll(value("account"), " ", ""),
+			title:               value("title"),
+			reference:           value("reference"),
+		}
+		// the bank's transaction id names the entry in every export that
+		// contains it
+		if e.reference != "" {
+			e.source = "CSV|" + e.reference
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// csvSeparator picks semicolon, which Polish banks use because of the
+// decimal comma, when the first line has more of them than commas.
+func csvSeparator(text string) rune {
+	firstLine, _, _ := strings.Cut(text, "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		return ';'
+	}
+	return ','
+}
+
+func csvHeader(record []string) map[string]int {
+	columns := make(map[string]int)
+	for index, cell := range record {
+		cell = strings.ToLower(strings.TrimSpace(cell))
+		for column, names := range csvColumns {
+			if _, taken := columns[column]; taken {
+				continue
+			}
+			for _, name := range names {
+				if cell == name {
+					columns[column] = index
+				}
+			}
+		}
+	}
+
+	for _, required := range []string{"date", "amount", "title"} {
+		if _, ok := columns[required]; !ok {
+			return nil
+		}
+	}
+
+	return columns
+}
+
+func parseCSVDate(value string) (time.Time, error) {
+	// some banks add the time of day
+	value, _, _ = strings.Cut(value, " ")
+
+	var err error
+	for _, layout := range csvDateLayouts {
+		var date time.Time
+		if date, err = time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+// parseCSVAmount accepts both "1 234,56" and "1,234.56". Whichever of comma
+// and dot comes last is the decimal separator.
+func parseCSVAmount(value string) (decimal.Decimal, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "PLN")
+	value = strings.NewReplacer(" ", "", "\u00a0", "").Replace(value)
+
+	if strings.LastIndex(value, ",") > strings.LastIndex(value, ".") {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	return decimal.NewFromString(value)
+}
+
+func isBlank(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
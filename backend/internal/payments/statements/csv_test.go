@@ -0,0 +1,88 @@
+package statements
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      []entry
+	}{
+		{
+			name: "semicolons and decimal commas below a preamble",
+			statement: strings.Join([]string{
+				"Rachunek;PL61109010140000071219812874",
+				"",
+				"Data operacji;Kwota;Tytuł;Kontrahent;Rachunek kontrahenta;Numer referencyjny",
+				"01.03.2025;1 234,56;FV/2025/001;ACME SP Z O O;PL27 1140 2004 0000 3002 0135 5387;TX-1",
+				"02.03.2025;-20,50;Opłata;;;",
+			}, "\n"),
+			want: []entry{
+				{
+					bookingDate:         day("2025-03-01"),
+					amount:              dec("1234.56"),
+					counterpartyName:    "ACME SP Z O O",
+					counterpartyAccount: "PL27114020040000300201355387",
+					title:               "FV/2025/001",
+					reference:           "TX-1",
+					source:              "CSV|TX-1",
+				},
+				{bookingDate: day("2025-03-02"), amount: dec("-20.50"), title: "Opłata"},
+			},
+		},
+		{
+			name: "commas and decimal dots",
+			statement: strings.Join([]string{
+				"Date,Amount,Description,Reference",
+				`2025-03-01 10:15,"1,234.56",FV/2025/001,TX-1`,
+			}, "\n"),
+			want: []entry{
+				{bookingDate: day("2025-03-01"), amount: dec("1234.56"), title: "FV/2025/001", reference: "TX-1", source: "CSV|TX-1"},
+			},
+		},
+		{
+			name: "identical transfers with their own transaction ids",
+			statement: strings.Join([]string{
+				"Data;Kwota;Tytuł;Id transakcji",
+				"01.03.2025;150,00;FV/2025/001;TX-1",
+				"01.03.2025;150,00;FV/2025/001;TX-2",
+			}, "\n"),
+			want: []entry{
+				{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001", reference: "TX-1", source: "CSV|TX-1"},
+				{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001", reference: "TX-2", source: "CSV|TX-2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := parseCSV(test.statement)
+			if err != nil {
+				t.Fatalf("parseCSV: %v", err)
+			}
+			assertEntries(t, entries, test.want)
+		})
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+	}{
+		{name: "no header", statement: "a;b;c\n1;2;3"},
+		{name: "invalid date", statement: "Data;Kwota;Tytuł\n2025/13/45;1,00;x"},
+		{name: "invalid amount", statement: "Data;Kwota;Tytuł\n01.03.2025;abc;x"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parseCSV(test.statement); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
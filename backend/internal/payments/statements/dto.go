@@ -0,0 +1,66 @@
+package statements
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/payments"
+	"time"
+)
+
+// ImportResult counts what happened to the transfers of an uploaded
+// statement. Outgoing transfers are skipped and ones imported before are
+// counted as duplicates.
+type ImportResult struct {
+	StatementId int32                    `json:"statementId"`
+	Format      sqlc.BankStatementFormat `json:"format"`
+	Matched     int                      `json:"matched"`
+	Unmatched   int                      `json:"unmatched"`
+	Duplicates  int                      `json:"duplicates"`
+	Skipped     int                      `json:"skipped"`
+}
+
+type Statement struct {
+	Id               int32                    `json:"id"`
+	Format           sqlc.BankStatementFormat `json:"format"`
+	ImportedAt       time.Time                `json:"importedAt"`
+	ImportedBy       string                   `json:"importedBy"`
+	TransactionCount int64                    `json:"transactionCount"`
+	UnmatchedCount   int64                    `json:"unmatchedCount"`
+}
+
+type ListStatementsResponse struct {
+	Statements []Statement `json:"statements"`
+}
+
+type StatementDetails struct {
+	Id           int32                    `json:"id"`
+	Format       sqlc.BankStatementFormat `json:"format"`
+	ImportedAt   time.Time                `json:"importedAt"`
+	ImportedBy   string                   `json:"importedBy"`
+	Transactions []Transaction            `json:"transactions"`
+}
+
+type Transaction struct {
+	Id                  int32                      `json:"id"`
+	StatementId         int32                      `json:"statementId"`
+	BookingDate         time.Time                  `json:"bookingDate"`
+	Amount              string                     `json:"amount"`
+	CounterpartyName    *string                    `json:"counterpartyName"`
+	CounterpartyAccount *string                    `json:"counterpartyAccount"`
+	Title               string                     `json:"title"`
+	BankReference       *string                    `json:"bankReference"`
+	Status              sqlc.BankTransactionStatus `json:"status"`
+	PaymentId           *int32                     `json:"paymentId"`
+	CompanyId           *int32                     `json:"companyId"`
+	CompanyName         *string                    `json:"companyName"`
+}
+
+type ListTransactionsResponse struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+// AssignTransactionRequest books an unmatched transfer as a payment from the
+// given company. Whatever the allocations leave over becomes credit.
+type AssignTransactionRequest struct {
+	CompanyId   int32                        `json:"companyId" validate:"required"`
+	Allocations []payments.AllocationRequest `json:"allocations" validate:"dive"`
+}
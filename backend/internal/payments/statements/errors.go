@@ -0,0 +1,19 @@
+package statements
+
+import "errors"
+
+var (
+	ErrStatementIdRequired      = errors.New("statement id required")
+	ErrInvalidStatementId       = errors.New("invalid statement id")
+	ErrStatementNotFound        = errors.New("bank statement not found")
+	ErrTransactionIdRequired    = errors.New("transaction id required")
+	ErrInvalidTransactionId     = errors.New("invalid transaction id")
+	ErrTransactionNotFound      = errors.New("bank transaction not found")
+	ErrInvalidTransactionStatus = errors.New("invalid transaction status")
+	ErrTransactionResolved      = errors.New("bank transaction is already resolved")
+
+	ErrStatementTooLarge     = errors.New("statement file too large")
+	ErrUnrecognizedStatement = errors.New("unrecognized statement format")
+	ErrInvalidStatement      = errors.New("invalid statement")
+	ErrEmptyStatement        = errors.New("statement contains no transactions")
+)
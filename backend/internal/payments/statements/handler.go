@@ -0,0 +1,202 @@
+package statements
+
+import (
+	"errors"
+	"io"
+	"mleczarnia/internal/companies"
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/payments"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const maxStatementSize = 5 << 20
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListStatements(writer http.ResponseWriter, request *http.Request) {
+	statements, err := handler.service.ListStatements(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListStatementsResponse{Statements: statements})
+}
+
+func (handler *Handler) GetStatement(writer http.ResponseWriter, request *http.Request) {
+	statementId, err := handler.extractStatementId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	statement, err := handler.service.GetStatement(request.Context(), statementId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, statement)
+}
+
+// ImportStatement takes the raw MT940 or CSV file as the request body. The
+// format is recognised from the content.
+func (handler *Handler) ImportStatement(writer http.ResponseWriter, request *http.Request) {
+	defer request.Body.Close()
+
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	data, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxStatementSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handler.handleServiceError(writer, ErrStatementTooLarge)
+			return
+		}
+		httputil.WriteError(writer, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := handler.service.ImportStatement(request.Context(), int32(claims.UserId), data)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, result)
+}
+
+func (handler *Handler) ListTransactions(writer http.ResponseWriter, request *http.Request) {
+	var status *sqlc.BankTransactionStatus
+	if statusStr := request.URL.Query().Get("status"); statusStr != "" {
+		value := sqlc.BankTransactionStatus(statusStr)
+		switch value {
+		case sqlc.BankTransactionStatusMATCHED, sqlc.BankTransactionStatusUNMATCHED,
+			sqlc.BankTransactionStatusASSIGNED, sqlc.BankTransactionStatusIGNORED:
+			status = &value
+		default:
+			handler.handleServiceError(writer, ErrInvalidTransactionStatus)
+			return
+		}
+	}
+
+	transactions, err := handler.service.ListTransactions(request.Context(), status)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListTransactionsResponse{Transactions: transactions})
+}
+
+func (handler *Handler) AssignTransaction(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	transactionId, err := handler.extractTransactionId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body AssignTransactionRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.AssignTransaction(request.Context(), transactionId, int32(claims.UserId), body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) IgnoreTransaction(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	transactionId, err := handler.extractTransactionId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.IgnoreTransaction(request.Context(), transactionId, int32(claims.UserId)); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractStatementId(request *http.Request) (int32, error) {
+	statementIdStr := chi.URLParam(request, "statementId")
+	if statementIdStr == "" {
+		return 0, ErrStatementIdRequired
+	}
+
+	statementId, err := strconv.Atoi(statementIdStr)
+	if err != nil {
+		return 0, ErrInvalidStatementId
+	}
+
+	return int32(statementId), nil
+}
+
+func (handler *Handler) extractTransactionId(request *http.Request) (int32, error) {
+	transactionIdStr := chi.URLParam(request, "transactionId")
+	if transactionIdStr == "" {
+		return 0, ErrTransactionIdRequired
+	}
+
+	transactionId, err := strconv.Atoi(transactionIdStr)
+	if err != nil {
+		return 0, ErrInvalidTransactionId
+	}
+
+	return int32(transactionId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrStatementIdRequired), errors.Is(err, ErrInvalidStatementId),
+		errors.Is(err, ErrTransactionIdRequired), errors.Is(err, ErrInvalidTransactionId),
+		errors.Is(err, ErrInvalidTransactionStatus), errors.Is(err, ErrUnrecognizedStatement),
+		errors.Is(err, ErrInvalidStatement), errors.Is(err, ErrEmptyStatement),
+		errors.Is(err, payments.ErrDuplicateInvoice), errors.Is(err, payments.ErrInvoiceOfOtherCustomer):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, ErrStatementNotFound), errors.Is(err, ErrTransactionNotFound),
+		errors.Is(err, payments.ErrInvoiceNotFound), errors.Is(err, companies.ErrCompanyNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, ErrTransactionResolved),
+		errors.Is(err, payments.ErrAllocationExceedsOutstanding), errors.Is(err, payments.ErrAllocationExceedsPayment):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, ErrStatementTooLarge):
+		return http.StatusRequestEntityTooLarge, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,181 @@
+package statements
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	mt940Field = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	// value date, optional entry date, credit/debit mark, optional funds
+	// code, amount, transaction type and references
+	mt940Transaction = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d{0,2})([A-Z][A-Z0-9]{3})(.*)$`)
+	// Polish banks structure field 86 as a transaction code followed by
+	// subfields, e.g. 020~20title~32name
+	mt940Structured = regexp.MustCompile(`^\d{3}([~^<])\d{2}`)
+)
+
+type mt940Tag struct {
+	name  string
+	lines []string
+}
+
+func parseMT940(text string) ([]entry, error) {
+	var tags []mt940Tag
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \r")
+		if match := mt940Field.FindStringSubmatch(line); match != nil {
+			tags = append(tags, mt940Tag{name: match[1], lines: []string{match[2]}})
+			continue
+		}
+		// anything else continues the previous field, except the block
+		// delimiters some banks wrap statements in
+		if len(tags) > 0 && line != "" && line != "-" && !strings.HasPrefix(line, "{") {
+			tags[len(tags)-1].lines = append(tags[len(tags)-1].lines, line)
+		}
+	}
+
+	var entries []entry
+	var current *entry
+	// a file may hold several statements, each opened by :20:; the account
+	// in :25: and the statement and sequence number in :28C: identify it
+	var reference, account, number string
+	position := 0
+	for _, tag := range tags {
+		switch tag.name {
+		case "20":
+			reference, account, number = strings.TrimSpace(tag.lines[0]), "", ""
+			position = 0
+		case "25":
+			account = strings.TrimSpace(tag.lines[0])
+		case "28C":
+			number = strings.TrimSpace(tag.lines[0])
+		case "61":
+			if current != nil {
+				entries = append(entries, *current)
+			}
+
+			e, bankReference, err := parseMT940Transaction(tag.lines)
+			if err != nil {
+				return nil, err
+			}
+			// the bank's own reference names the transfer in every
+			// statement that lists it, so overlapping statements and
+			// re-exports dedupe; only without it is the entry named by its
+			// place in this statement
+			if bankReference != "" {
+				e.source = strings.Join([]string{"MT940", account, bankReference}, "|")
+			} else {
+				e.source = mt940Source(reference, account, number)
+				e.position = position
+			}
+			position++
+			current = &e
+		case "86":
+			if current != nil {
+				applyMT940Information(current, strings.Join(tag.lines, ""))
+			}
+		}
+	}
+	if current != nil {
+		entries = append(entries, *current)
+	}
+
+	return entries, nil
+}
+
+// mt940Source names the statement an entry without a bank reference belongs
+// to by its account and its :28C: statement and sequence number, or its :20:
+// reference when the bank leaves the number out. Without either the entry is
+// told apart by its content.
+func mt940Source(reference, account, number string) string {
+	if number != "" {
+		return strings.Join([]string{"MT940", account, number}, "|")
+	}
+	if reference != "" {
+		return strings.Join([]string{"MT940", account, reference}, "|")
+	}
+	return ""
+}
+
+// parseMT940Transaction reads a :61: field. It also returns the bank's
+// reference, the part after "//", which is empty when the bank gives none.
+func parseMT940Transaction(lines []string) (entry, string, error) {
+	match := mt940Transaction.FindStringSubmatch(lines[0])
+	if match == nil {
+		return entry{}, "", fmt.Errorf("%w: unreadable :61: line %q", ErrInvalidStatement, lines[0])
+	}
+
+	date, err := time.Parse("060102", match[1])
+	if err != nil {
+		return entry{}, "", fmt.Errorf("%w: invalid date %q", ErrInvalidStatement, match[1])
+	}
+
+	amount, err := decimal.NewFromString(strings.Replace(match[5], ",", ".", 1))
+	if err != nil {
+		return entry{}, "", fmt.Errorf("%w: invalid amount %q", ErrInvalidStatement, match[5])
+	}
+
+	// a reversed debit gives the money back, so it counts as a credit
+	if match[3] == "D" || match[3] == "RC" {
+		amount = amount.Neg()
+	}
+
+	e := entry{bookingDate: date, amount: amount}
+
+	var bankReference string
+	references := match[7]
+	if customer, bank, found := strings.Cut(references, "//"); found {
+		bankReference = strings.TrimSpace(bank)
+		e.reference = bankReference
+	} else if customer != "NONREF" {
+		e.reference = strings.TrimSpace(customer)
+	}
+
+	return e, bankReference, nil
+}
+
+// applyMT940Information reads the title and counterparty from field 86.
+// Unstructured fields are taken whole as the title.
+func applyMT940Information(e *entry, information string) {
+	match := mt940Structured.FindStringSubmatch(information)
+	if match == nil {
+		e.title = strings.TrimSpace(information)
+		return
+	}
+
+	var title, name, altName, account, bankCode strings.Builder
+	for _, subfield := range strings.Split(information, match[1])[1:] {
+		if len(subfield) < 2 {
+			continue
+		}
+
+		value := subfield[2:]
+		switch code := subfield[:2]; code {
+		case "20", "21", "22", "23", "24", "25", "26", "60", "61", "62", "63":
+			title.WriteString(value)
+		case "32", "33":
+			name.WriteString(value)
+		case "27", "28":
+			altName.WriteString(value)
+		case "38":
+			account.WriteString(value)
+		case "30", "31":
+			bankCode.WriteString(value)
+		}
+	}
+
+	e.title = strings.TrimSpace(title.String())
+	e.counterpartyName = strings.TrimSpace(name.String())
+	if e.counterpartyName == "" {
+		e.counterpartyName = strings.TrimSpace(altName.String())
+	}
+	e.counterpartyAccount = strings.TrimSpace(account.String())
+	if e.counterpartyAccount == "" {
+		e.counterpartyAccount = strings.TrimSpace(bankCode.String())
+	}
+}
@@ -0,0 +1,160 @@
+package statements
+
+import (
+	"strings"
+	"testing"
+)
+
+// mt940 joins statement lines the way banks write them.
+func mt940(lines ...string) string {
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func TestParseMT940(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      []entry
+	}{
+		{
+			name: "bank reference names the entry",
+			statement: mt940(
+				":20:ST250301",
+				":25:PL61109010140000071219812874",
+				":28C:61/1",
+				":61:2503010301C150,00NTRFFV-1//BR0001",
+				":86:020~20FV/2025/001~32ACME SP Z O O~38PL27114020040000300201355387",
+			),
+			want: []entry{{
+				bookingDate:         day("2025-03-01"),
+				amount:              dec("150.00"),
+				counterpartyName:    "ACME SP Z O O",
+				counterpartyAccount: "PL27114020040000300201355387",
+				title:               "FV/2025/001",
+				reference:           "BR0001",
+				source:              "MT940|PL61109010140000071219812874|BR0001",
+			}},
+		},
+		{
+			name: "identical transfers with their own bank references",
+			statement: mt940(
+				":20:ST250301",
+				":25:PL61109010140000071219812874",
+				":28C:61/1",
+				":61:2503010301C150,00NTRFNONREF//BR0001",
+				":86:FV/2025/001",
+				":61:2503010301C150,00NTRFNONREF//BR0002",
+				":86:FV/2025/001",
+			),
+			want: []entry{
+				{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001", reference: "BR0001", source: "MT940|PL61109010140000071219812874|BR0001"},
+				{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001", reference: "BR0002", source: "MT940|PL61109010140000071219812874|BR0002"},
+			},
+		},
+		{
+			name: "without bank references the statement position tells identical transfers apart",
+			statement: mt940(
+				":20:ST250301",
+				":25:PL61109010140000071219812874",
+				":28C:61/1",
+				":61:2503010301C150,00NTRFNONREF",
+				":86:FV/2025/001",
+				":61:2503010301C150,00NTRFNONREF",
+				":86:FV/2025/001",
+			),
+			want: []entry{
+				{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001", source: "MT940|PL61109010140000071219812874|61/1", position: 0},
+				{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001", source: "MT940|PL61109010140000071219812874|61/1", position: 1},
+			},
+		},
+		{
+			name: "statement reference is used when the number is missing",
+			statement: mt940(
+				":20:ST250301",
+				":25:PL61109010140000071219812874",
+				":61:2503010301D20,50NTRFREF-7",
+				":86:Opłata",
+			),
+			want: []entry{
+				{bookingDate: day("2025-03-01"), amount: dec("-20.50"), title: "Opłata", reference: "REF-7", source: "MT940|PL61109010140000071219812874|ST250301"},
+			},
+		},
+		{
+			name: "positions restart with each statement in the file",
+			statement: mt940(
+				":20:ST250301",
+				":25:PL61109010140000071219812874",
+				":28C:61/1",
+				":61:2503010301C10,00NTRFNONREF",
+				":86:A",
+				":20:ST250302",
+				":25:PL61109010140000071219812874",
+				":28C:62/1",
+				":61:2503020302C10,00NTRFNONREF",
+				":86:A",
+			),
+			want: []entry{
+				{bookingDate: day("2025-03-01"), amount: dec("10.00"), title: "A", source: "MT940|PL61109010140000071219812874|61/1", position: 0},
+				{bookingDate: day("2025-03-02"), amount: dec("10.00"), title: "A", source: "MT940|PL61109010140000071219812874|62/1", position: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, err := parseMT940(test.statement)
+			if err != nil {
+				t.Fatalf("parseMT940: %v", err)
+			}
+			assertEntries(t, entries, test.want)
+		})
+	}
+}
+
+func TestParseMT940Overlap(t *testing.T) {
+	daily := mt940(
+		":20:D250301",
+		":25:PL61109010140000071219812874",
+		":28C:61/1",
+		":61:2503010301C150,00NTRFNONREF//BR0001",
+		":86:FV/2025/001",
+	)
+	monthly := mt940(
+		":20:M2503",
+		":25:PL61109010140000071219812874",
+		":28C:3/1",
+		":61:2502280228C99,00NTRFNONREF//BR0000",
+		":86:FV/2025/000",
+		":61:2503010301C150,00NTRFNONREF//BR0001",
+		":86:FV/2025/001",
+	)
+	reexport := strings.ReplaceAll(daily, ":20:D250301", ":20:X999")
+
+	tests := []struct {
+		name  string
+		first string
+		again string
+		index int
+	}{
+		{name: "daily and monthly statement", first: daily, again: monthly, index: 1},
+		{name: "re-export with a new statement reference", first: daily, again: reexport, index: 0},
+		{name: "same file uploaded again", first: daily, again: daily, index: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			first, err := parseMT940(test.first)
+			if err != nil {
+				t.Fatalf("parseMT940: %v", err)
+			}
+			again, err := parseMT940(test.again)
+			if err != nil {
+				t.Fatalf("parseMT940: %v", err)
+			}
+
+			if first[0].fingerprint() != again[test.index].fingerprint() {
+				t.Errorf("fingerprints differ: %+v and %+v", first[0], again[test.index])
+			}
+		})
+	}
+}
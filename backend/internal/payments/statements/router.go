@@ -0,0 +1,25 @@
+package statements
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(statementsHandler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Get("/", statementsHandler.ListStatements)
+		r.Post("/", statementsHandler.ImportStatement)
+		r.Get("/transactions", statementsHandler.ListTransactions)
+		r.Post("/transactions/{transactionId}/assign", statementsHandler.AssignTransaction)
+		r.Post("/transactions/{transactionId}/ignore", statementsHandler.IgnoreTransaction)
+		r.Get("/{statementId}", statementsHandler.GetStatement)
+	})
+
+	return router
+}
@@ -0,0 +1,468 @@
+package statements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/payments"
+	"regexp"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// invoice numbers look like FAK/20260042; customers also write them as
+	// FAK/2026/0042 or with spaces
+	invoiceNumberPattern = regexp.MustCompile(`(?i)FAK\s*/\s*(\d{4})\s*/?\s*(\d{4,})`)
+	// split payment titles carry the buyer's NIP after /IDC/
+	taxIdPattern = regexp.MustCompile(`(?i)(?:NIP|/IDC/)[\s:.\-]*((?:\d[\s-]?){9}\d)`)
+	nonDigit     = regexp.MustCompile(`\D`)
+)
+
+// maxReferenceLength is the length of payment.reference.
+const maxReferenceLength = 255
+
+type Service struct {
+	query    *sqlc.Queries
+	pool     *pgxpool.Pool
+	payments *payments.Service
+}
+
+func NewService(queries *sqlc.Queries, pool *pgxpool.Pool, paymentsService *payments.Service) *Service {
+	return &Service{query: queries, pool: pool, payments: paymentsService}
+}
+
+func (service *Service) ListStatements(ctx context.Context) ([]Statement, error) {
+	rows, err := service.query.ListBankStatements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Statement, len(rows))
+	for i, row := range rows {
+		result[i] = Statement{
+			Id:               row.ID,
+			Format:           row.Format,
+			ImportedAt:       row.ImportedAt.Time,
+			ImportedBy:       row.ImportedBy,
+			TransactionCount: row.TransactionCount,
+			UnmatchedCount:   row.UnmatchedCount,
+		}
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetStatement(ctx context.Context, statementId int32) (*StatementDetails, error) {
+	statement, err := service.query.GetBankStatementById(ctx, statementId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStatementNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	transactions, err := service.listTransactions(ctx, sqlc.ListBankTransactionsParams{
+		StatementID: pgtype.Int4{Int32: statementId, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatementDetails{
+		Id:           statement.ID,
+		Format:       statement.Format,
+		ImportedAt:   statement.ImportedAt.Time,
+		ImportedBy:   statement.ImportedBy,
+		Transactions: transactions,
+	}, nil
+}
+
+// ListTransactions lists transfers from all statements. Filtering on
+// UNMATCHED gives the reconciliation queue.
+func (service *Service) ListTransactions(ctx context.Context, status *sqlc.BankTransactionStatus) ([]Transaction, error) {
+	params := sqlc.ListBankTransactionsParams{}
+	if status != nil {
+		params.Status = sqlc.NullBankTransactionStatus{BankTransactionStatus: *status, Valid: true}
+	}
+
+	return service.listTransactions(ctx, params)
+}
+
+// ImportStatement reads an MT940 or CSV statement and books every incoming
+// transfer it can match to an invoice as a payment. The rest are queued as
+// UNMATCHED for someone to assign by hand.
+func (service *Service) ImportStatement(ctx context.Context, userId int32, data []byte) (*ImportResult, error) {
+	format, entries, err := parseStatement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, ErrEmptyStatement
+	}
+
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*ImportResult, error) {
+		qtx := service.query.WithTx(tx)
+
+		statement, err := qtx.CreateBankStatement(ctx, sqlc.CreateBankStatementParams{
+			Format:     format,
+			ImportedBy: userId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		result := ImportResult{StatementId: statement.ID, Format: format}
+		for _, e := range entries {
+			if !e.amount.IsPositive() {
+				result.Skipped++
+				continue
+			}
+
+			transactionId, err := createTransaction(ctx, qtx, statement.ID, e)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					result.Duplicates++
+					continue
+				}
+				return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+
+			matched, err := service.match(ctx, qtx, transactionId, userId, e)
+			if err != nil {
+				return nil, err
+			}
+
+			if matched {
+				result.Matched++
+			} else {
+				result.Unmatched++
+			}
+		}
+
+		return &result, nil
+	})
+}
+
+// AssignTransaction books an unmatched transfer as a payment from the
+// company someone recognised it as.
+func (service *Service) AssignTransaction(
+	ctx context.Context,
+	transactionId int32,
+	userId int32,
+	req AssignTransactionRequest,
+) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		transaction, err := getUnmatchedTransaction(ctx, qtx, transactionId)
+		if err != nil {
+			return err
+		}
+
+		amount, err := decimal.NewFromString(transaction.Amount)
+		if err != nil {
+			return payments.ErrFailedToCreateDecimal
+		}
+
+		paymentId, err := service.payments.Record(ctx, qtx, &userId, payments.CreatePaymentRequest{
+			CompanyId:   req.CompanyId,
+			Amount:      amount,
+			PaymentDate: transaction.BookingDate.Time,
+			Method:      sqlc.PaymentMethodBANKTRANSFER,
+			Reference:   paymentReference(transaction.Title),
+			Allocations: req.Allocations,
+		})
+		if err != nil {
+			return err
+		}
+
+		return resolveTransaction(ctx, qtx, transactionId, sqlc.BankTransactionStatusASSIGNED, &paymentId, &userId)
+	})
+}
+
+// IgnoreTransaction takes a transfer that is not a customer payment, e.g. a
+// refund from a supplier, out of the queue.
+func (service *Service) IgnoreTransaction(ctx context.Context, transactionId int32, userId int32) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		if _, err := getUnmatchedTransaction(ctx, qtx, transactionId); err != nil {
+			return err
+		}
+
+		return resolveTransaction(ctx, qtx, transactionId, sqlc.BankTransactionStatusIGNORED, nil, &userId)
+	})
+}
+
+// match books the transfer as a payment if it names invoices by number or,
+// failing that, carries the NIP of a company with exactly one invoice open
+// for that amount.
+func (service *Service) match(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	transactionId int32,
+	userId int32,
+	e entry,
+) (bool, error) {
+	companyId, allocations, err := matchByInvoiceNumber(ctx, qtx, e)
+	if err != nil {
+		return false, err
+	}
+
+	if len(allocations) == 0 {
+		companyId, allocations, err = matchByTaxId(ctx, qtx, e)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(allocations) == 0 {
+		return false, nil
+	}
+
+	paymentId, err := service.payments.Record(ctx, qtx, &userId, payments.CreatePaymentRequest{
+		CompanyId:   companyId,
+		Amount:      e.amount,
+		PaymentDate: e.bookingDate,
+		Method:      sqlc.PaymentMethodBANKTRANSFER,
+		Reference:   paymentReference(e.title),
+		Allocations: allocations,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := resolveTransaction(ctx, qtx, transactionId, sqlc.BankTransactionStatusMATCHED, &paymentId, nil); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// matchByInvoiceNumber spreads the transfer over the invoices named in its
+// title, in order, as far as each is outstanding. Invoices of a company
+// other than the first one named are left alone.
+func matchByInvoiceNumber(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	e entry,
+) (int32, []payments.AllocationRequest, error) {
+	var companyId int32
+	var allocations []payments.AllocationRequest
+	seen := make(map[int32]bool)
+	remaining := e.amount
+
+	for _, match := range invoiceNumberPattern.FindAllStringSubmatch(e.title, -1) {
+		invoice, err := qtx.GetInvoiceByNumber(ctx, "FAK/"+match[1]+match[2])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return 0, nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if seen[invoice.ID] || (companyId != 0 && invoice.CustomerID != companyId) {
+			continue
+		}
+		seen[invoice.ID] = true
+
+		outstanding, err := outstandingAmount(ctx, qtx, invoice.ID)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !outstanding.IsPositive() {
+			continue
+		}
+
+		amount := decimal.Min(outstanding, remaining)
+		allocations = append(allocations, payments.AllocationRequest{InvoiceId: invoice.ID, Amount: amount})
+		companyId = invoice.CustomerID
+
+		remaining = remaining.Sub(amount)
+		if !remaining.IsPositive() {
+			break
+		}
+	}
+
+	return companyId, allocations, nil
+}
+
+func matchByTaxId(ctx context.Context, qtx *sqlc.Queries, e entry) (int32, []payments.AllocationRequest, error) {
+	match := taxIdPattern.FindStringSubmatch(e.title + " " + e.counterpartyName)
+	if match == nil {
+		return 0, nil, nil
+	}
+
+	companyIds, err := qtx.ListCompanyIdsByTaxId(ctx, nonDigit.ReplaceAllString(match[1], ""))
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+	if len(companyIds) != 1 {
+		return 0, nil, nil
+	}
+
+	amount, err := db.DecimalToNumeric(e.amount)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	invoiceIds, err := qtx.ListInvoicesWithOutstandingAmount(ctx, sqlc.ListInvoicesWithOutstandingAmountParams{
+		CustomerID: companyIds[0],
+		Amount:     amount,
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	// with several invoices open for the same amount there is no telling
+	// which one was paid
+	if len(invoiceIds) != 1 {
+		return 0, nil, nil
+	}
+
+	return companyIds[0], []payments.AllocationRequest{{InvoiceId: invoiceIds[0], Amount: e.amount}}, nil
+}
+
+func outstandingAmount(ctx context.Context, qtx *sqlc.Queries, invoiceId int32) (decimal.Decimal, error) {
+	balance, err := qtx.GetInvoiceBalance(ctx, invoiceId)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	due, err := decimal.NewFromString(balance.AmountDue)
+	if err != nil {
+		return decimal.Zero, payments.ErrFailedToCreateDecimal
+	}
+
+	paid, err := decimal.NewFromString(balance.AmountPaid)
+	if err != nil {
+		return decimal.Zero, payments.ErrFailedToCreateDecimal
+	}
+
+	return due.Sub(paid), nil
+}
+
+func createTransaction(ctx context.Context, qtx *sqlc.Queries, statementId int32, e entry) (int32, error) {
+	amount, err := db.DecimalToNumeric(e.amount)
+	if err != nil {
+		return 0, err
+	}
+
+	return qtx.CreateBankTransaction(ctx, sqlc.CreateBankTransactionParams{
+		StatementID:         statementId,
+		BookingDate:         db.ConvertToDate(&e.bookingDate),
+		Amount:              amount,
+		CounterpartyName:    optionalText(truncate(e.counterpartyName, 255)),
+		CounterpartyAccount: optionalText(truncate(e.counterpartyAccount, 50)),
+		Title:               e.title,
+		BankReference:       optionalText(truncate(e.reference, 100)),
+		Fingerprint:         e.fingerprint(),
+		Status:              sqlc.BankTransactionStatusUNMATCHED,
+	})
+}
+
+func getUnmatchedTransaction(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	transactionId int32,
+) (*sqlc.GetBankTransactionForUpdateRow, error) {
+	transaction, err := qtx.GetBankTransactionForUpdate(ctx, transactionId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if transaction.Status != sqlc.BankTransactionStatusUNMATCHED {
+		return nil, ErrTransactionResolved
+	}
+
+	return &transaction, nil
+}
+
+func resolveTransaction(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	transactionId int32,
+	status sqlc.BankTransactionStatus,
+	paymentId *int32,
+	userId *int32,
+) error {
+	if err := qtx.ResolveBankTransaction(ctx, sqlc.ResolveBankTransactionParams{
+		ID:         transactionId,
+		Status:     status,
+		PaymentID:  db.ConvertToInt4(paymentId),
+		ResolvedBy: db.ConvertToInt4(userId),
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func (service *Service) listTransactions(ctx context.Context, params sqlc.ListBankTransactionsParams) ([]Transaction, error) {
+	rows, err := service.query.ListBankTransactions(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Transaction, len(rows))
+	for i, row := range rows {
+		result[i] = Transaction{
+			Id:                  row.ID,
+			StatementId:         row.StatementID,
+			BookingDate:         row.BookingDate.Time,
+			Amount:              row.Amount,
+			CounterpartyName:    optionalString(row.CounterpartyName),
+			CounterpartyAccount: optionalString(row.CounterpartyAccount),
+			Title:               row.Title,
+			BankReference:       optionalString(row.BankReference),
+			Status:              row.Status,
+			CompanyName:         optionalString(row.CompanyName),
+		}
+		if row.PaymentID.Valid {
+			result[i].PaymentId = &row.PaymentID.Int32
+		}
+		if row.CustomerID.Valid {
+			result[i].CompanyId = &row.CustomerID.Int32
+		}
+	}
+
+	return result, nil
+}
+
+// paymentReference keeps the transfer title on the payment, cut to fit.
+func paymentReference(title string) *string {
+	if title == "" {
+		return nil
+	}
+
+	reference := truncate(title, maxReferenceLength)
+	return &reference
+}
+
+func truncate(value string, length int) string {
+	if runes := []rune(value); len(runes) > length {
+		return string(runes[:length])
+	}
+	return value
+}
+
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func optionalString(value pgtype.Text) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
@@ -0,0 +1,104 @@
+package statements
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"mleczarnia/internal/db/sqlc"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// entry is one transfer read from a statement, whatever its format. Amount
+// is negative for outgoing transfers.
+type entry struct {
+	bookingDate         time.Time
+	amount              decimal.Decimal
+	counterpartyName    string
+	counterpartyAccount string
+	title               string
+	reference           string
+	// source names the transfer by the bank's transaction reference, or
+	// failing that the MT940 statement it was read from. position tells
+	// apart entries of the same source.
+	source   string
+	position int
+}
+
+// fingerprint identifies the transfer when it is uploaded again, in the same
+// statement or an overlapping one. Two transfers with the same date, amount
+// and title are different entries, so the content alone is not enough.
+func (e entry) fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		e.source,
+		strconv.Itoa(e.position),
+		e.contentKey(),
+	}, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (e entry) contentKey() string {
+	return strings.Join([]string{
+		e.bookingDate.Format(time.DateOnly),
+		e.amount.StringFixed(2),
+		e.counterpartyAccount,
+		e.title,
+		e.reference,
+	}, "|")
+}
+
+func parseStatement(data []byte) (sqlc.BankStatementFormat, []entry, error) {
+	text := decode(data)
+
+	format := sqlc.BankStatementFormatCSV
+	var entries []entry
+	var err error
+	if strings.Contains(text, ":61:") && strings.Contains(text, ":25:") {
+		format = sqlc.BankStatementFormatMT940
+		entries, err = parseMT940(text)
+	} else {
+		entries, err = parseCSV(text)
+	}
+	if err != nil {
+		return format, nil, err
+	}
+
+	numberUnsourced(entries)
+	return format, entries, nil
+}
+
+// numberUnsourced numbers entries without a source by how many identical
+// entries came before them in the file, so identical transfers stay apart
+// while a re-upload of the same file still yields the same fingerprints.
+func numberUnsourced(entries []entry) {
+	seen := make(map[string]int)
+	for i := range entries {
+		if entries[i].source != "" {
+			continue
+		}
+		key := entries[i].contentKey()
+		entries[i].position = seen[key]
+		seen[key]++
+	}
+}
+
+// decode returns the statement as UTF-8. Files that are not valid UTF-8 are
+// read as Windows-1250, which Polish banks still export by default.
+func decode(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	decoded, err := charmap.Windows1250.NewDecoder().Bytes(data)
+	if err != nil {
+		return strings.ToValidUTF8(string(data), "?")
+	}
+
+	return string(decoded)
+}
@@ -0,0 +1,83 @@
+package statements
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func day(value string) time.Time {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func assertEntries(t *testing.T, got, want []entry) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if !g.bookingDate.Equal(w.bookingDate) || !g.amount.Equal(w.amount) ||
+			g.counterpartyName != w.counterpartyName || g.counterpartyAccount != w.counterpartyAccount ||
+			g.title != w.title || g.reference != w.reference ||
+			g.source != w.source || g.position != w.position {
+			t.Errorf("entry %d:\n got %+v\nwant %+v", i, g, w)
+		}
+	}
+}
+
+func TestNumberUnsourced(t *testing.T) {
+	transfer := entry{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001"}
+	other := entry{bookingDate: day("2025-03-01"), amount: dec("99.00"), title: "FV/2025/002"}
+	sourced := transfer
+	sourced.source = "CSV|TX-1"
+
+	tests := []struct {
+		name    string
+		entries []entry
+		want    []int
+	}{
+		{name: "distinct entries", entries: []entry{transfer, other}, want: []int{0, 0}},
+		{name: "identical entries", entries: []entry{transfer, other, transfer, transfer}, want: []int{0, 0, 1, 2}},
+		{name: "sourced entries are left alone", entries: []entry{sourced, transfer, sourced}, want: []int{0, 0, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			numberUnsourced(test.entries)
+			for i, want := range test.want {
+				if test.entries[i].position != want {
+					t.Errorf("entry %d: position %d, want %d", i, test.entries[i].position, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNumberUnsourcedKeepsFingerprints(t *testing.T) {
+	transfer := entry{bookingDate: day("2025-03-01"), amount: dec("150.00"), title: "FV/2025/001"}
+
+	first := []entry{transfer, transfer}
+	again := []entry{transfer, transfer}
+	numberUnsourced(first)
+	numberUnsourced(again)
+
+	if first[0].fingerprint() == first[1].fingerprint() {
+		t.Error("identical transfers in one file share a fingerprint")
+	}
+	for i := range first {
+		if first[i].fingerprint() != again[i].fingerprint() {
+			t.Errorf("entry %d: fingerprint changed on re-upload", i)
+		}
+	}
+}
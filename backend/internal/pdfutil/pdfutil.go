@@ -0,0 +1,29 @@
+package pdfutil
+
+import (
+	"bytes"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// New starts an A4 document with the JuliaMono fonts registered, which cover
+// Polish characters.
+func New() *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 20, 15)
+	pdf.AddPage()
+
+	pdf.AddUTF8Font("JuliaMono", "", "internal/assets/fonts/JuliaMono-Regular.ttf")
+	pdf.AddUTF8Font("JuliaMono", "B", "internal/assets/fonts/JuliaMono-Bold.ttf")
+
+	return pdf
+}
+
+func Output(pdf *gofpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
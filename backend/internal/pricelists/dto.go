@@ -0,0 +1,72 @@
+package pricelists
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type PriceList struct {
+	Id                  int32      `json:"id"`
+	Name                string     `json:"name"`
+	CustomerCompanyId   *int32     `json:"customerCompanyId"`
+	CustomerCompanyName *string    `json:"customerCompanyName"`
+	CustomerGroupId     *int32     `json:"customerGroupId"`
+	CustomerGroupName   *string    `json:"customerGroupName"`
+	ValidFrom           time.Time  `json:"validFrom"`
+	ValidTo             *time.Time `json:"validTo"`
+	IsActive            bool       `json:"isActive"`
+	CreatedAt           time.Time  `json:"createdAt"`
+}
+
+type PriceListItem struct {
+	ProductId    int32           `json:"productId"`
+	ProductName  string          `json:"productName"`
+	Unit         string          `json:"unit"`
+	Price        decimal.Decimal `json:"price"`
+	DefaultPrice decimal.Decimal `json:"defaultPrice"`
+}
+
+type ListPriceListsResponse struct {
+	PriceLists []PriceList `json:"priceLists"`
+}
+
+type GetPriceListResponse struct {
+	PriceList PriceList       `json:"priceList"`
+	Items     []PriceListItem `json:"items"`
+}
+
+// CreatePriceListRequest may name a company or a customer group as the owner;
+// a list without an owner is never applied until it is assigned.
+type CreatePriceListRequest struct {
+	Name              string     `json:"name" validate:"required,max=200"`
+	CustomerCompanyId *int32     `json:"customerCompanyId"`
+	CustomerGroupId   *int32     `json:"customerGroupId"`
+	ValidFrom         time.Time  `json:"validFrom" validate:"required"`
+	ValidTo           *time.Time `json:"validTo"`
+}
+
+type UpdatePriceListRequest struct {
+	Name      *string    `json:"name" validate:"omitempty,max=200"`
+	ValidFrom *time.Time `json:"validFrom"`
+	ValidTo   *time.Time `json:"validTo"`
+	IsActive  *bool      `json:"isActive"`
+}
+
+type AssignPriceListRequest struct {
+	CustomerCompanyId *int32 `json:"customerCompanyId"`
+	CustomerGroupId   *int32 `json:"customerGroupId"`
+}
+
+type SetPriceListItemRequest struct {
+	Price decimal.Decimal `json:"price" validate:"required,decimalpos"`
+}
+
+// ResolvedPrice is the unit price an order line is charged and where it came
+// from. PriceListId is nil for the product's default price.
+type ResolvedPrice struct {
+	UnitPrice   decimal.Decimal
+	Source      sqlc.PriceSource
+	PriceListId *int32
+}
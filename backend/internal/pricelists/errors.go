@@ -0,0 +1,12 @@
+package pricelists
+
+import "errors"
+
+var (
+	ErrPriceListIdRequired   = errors.New("price list id is required")
+	ErrInvalidPriceListId    = errors.New("invalid price list id")
+	ErrPriceListNotFound     = errors.New("price list not found")
+	ErrPriceListItemNotFound = errors.New("product is not on this price list")
+	ErrInvalidValidityRange  = errors.New("validTo must not be earlier than validFrom")
+	ErrAmbiguousOwner        = errors.New("price list can be assigned to a company or a customer group, not both")
+)
@@ -0,0 +1,225 @@
+package pricelists
+
+import (
+	"errors"
+	"mleczarnia/internal/companies"
+	"mleczarnia/internal/customergroups"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/products"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListPriceLists(writer http.ResponseWriter, request *http.Request) {
+	companyId, err := queryId(request, "companyId", companies.ErrInvalidCompanyId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	groupId, err := queryId(request, "customerGroupId", customergroups.ErrInvalidCustomerGroupId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	priceLists, err := handler.service.ListPriceLists(request.Context(), companyId, groupId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListPriceListsResponse{PriceLists: priceLists})
+}
+
+func (handler *Handler) GetPriceList(writer http.ResponseWriter, request *http.Request) {
+	priceListId, err := handler.extractPriceListId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	priceList, err := handler.service.GetPriceList(request.Context(), priceListId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, priceList)
+}
+
+func (handler *Handler) CreatePriceList(writer http.ResponseWriter, request *http.Request) {
+	var body CreatePriceListRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	priceList, err := handler.service.CreatePriceList(request.Context(), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, priceList)
+}
+
+func (handler *Handler) UpdatePriceList(writer http.ResponseWriter, request *http.Request) {
+	priceListId, err := handler.extractPriceListId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body UpdatePriceListRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.UpdatePriceList(request.Context(), priceListId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) AssignPriceList(writer http.ResponseWriter, request *http.Request) {
+	priceListId, err := handler.extractPriceListId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body AssignPriceListRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.AssignPriceList(request.Context(), priceListId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) SetPriceListItem(writer http.ResponseWriter, request *http.Request) {
+	priceListId, productId, err := handler.extractItemIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body SetPriceListItemRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.SetPriceListItem(request.Context(), priceListId, productId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) RemovePriceListItem(writer http.ResponseWriter, request *http.Request) {
+	priceListId, productId, err := handler.extractItemIds(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.RemovePriceListItem(request.Context(), priceListId, productId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func queryId(request *http.Request, name string, invalid error) (*int32, error) {
+	valueStr := request.URL.Query().Get(name)
+	if valueStr == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return nil, invalid
+	}
+
+	id := int32(value)
+	return &id, nil
+}
+
+func (handler *Handler) extractItemIds(request *http.Request) (int32, int32, error) {
+	priceListId, err := handler.extractPriceListId(request)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	productIdStr := chi.URLParam(request, "productId")
+	if productIdStr == "" {
+		return 0, 0, products.ErrProductIdRequired
+	}
+
+	productId, err := strconv.Atoi(productIdStr)
+	if err != nil {
+		return 0, 0, products.ErrInvalidProductId
+	}
+
+	return priceListId, int32(productId), nil
+}
+
+func (handler *Handler) extractPriceListId(request *http.Request) (int32, error) {
+	priceListIdStr := chi.URLParam(request, "priceListId")
+	if priceListIdStr == "" {
+		return 0, ErrPriceListIdRequired
+	}
+
+	priceListId, err := strconv.Atoi(priceListIdStr)
+	if err != nil {
+		return 0, ErrInvalidPriceListId
+	}
+
+	return int32(priceListId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrPriceListIdRequired), errors.Is(err, ErrInvalidPriceListId),
+		errors.Is(err, ErrInvalidValidityRange), errors.Is(err, ErrAmbiguousOwner),
+		errors.Is(err, products.ErrProductIdRequired), errors.Is(err, products.ErrInvalidProductId),
+		errors.Is(err, companies.ErrInvalidCompanyId), errors.Is(err, customergroups.ErrInvalidCustomerGroupId):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, ErrPriceListNotFound), errors.Is(err, ErrPriceListItemNotFound),
+		errors.Is(err, products.ErrProductNotFound), errors.Is(err, companies.ErrCompanyNotFound),
+		errors.Is(err, customergroups.ErrCustomerGroupNotFound):
+		return http.StatusNotFound, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,26 @@
+package pricelists
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(priceListsHandler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Get("/", priceListsHandler.ListPriceLists)
+		r.Get("/{priceListId}", priceListsHandler.GetPriceList)
+		r.Post("/", priceListsHandler.CreatePriceList)
+		r.Patch("/{priceListId}", priceListsHandler.UpdatePriceList)
+		r.Put("/{priceListId}/owner", priceListsHandler.AssignPriceList)
+		r.Put("/{priceListId}/items/{productId}", priceListsHandler.SetPriceListItem)
+		r.Delete("/{priceListId}/items/{productId}", priceListsHandler.RemovePriceListItem)
+	})
+
+	return router
+}
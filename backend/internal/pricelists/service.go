@@ -0,0 +1,315 @@
+package pricelists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/companies"
+	"mleczarnia/internal/customergroups"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/products"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type Service struct {
+	query *sqlc.Queries
+	pool  *pgxpool.Pool
+}
+
+func NewService(query *sqlc.Queries, pool *pgxpool.Pool) *Service {
+	return &Service{query: query, pool: pool}
+}
+
+func (service *Service) ListPriceLists(ctx context.Context, companyId *int32, groupId *int32) ([]PriceList, error) {
+	rows, err := service.query.ListPriceLists(ctx, sqlc.ListPriceListsParams{
+		CustomerCompanyID: db.ConvertToInt4(companyId),
+		CustomerGroupID:   db.ConvertToInt4(groupId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]PriceList, len(rows))
+	for i, row := range rows {
+		result[i] = mapPriceList(sqlc.GetPriceListByIdRow(row))
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetPriceList(ctx context.Context, priceListId int32) (*GetPriceListResponse, error) {
+	return service.getPriceList(ctx, service.query, priceListId)
+}
+
+func (service *Service) CreatePriceList(ctx context.Context, request CreatePriceListRequest) (*GetPriceListResponse, error) {
+	if request.ValidTo != nil && request.ValidTo.Before(request.ValidFrom) {
+		return nil, ErrInvalidValidityRange
+	}
+
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*GetPriceListResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		if err := checkOwner(ctx, qtx, request.CustomerCompanyId, request.CustomerGroupId); err != nil {
+			return nil, err
+		}
+
+		priceList, err := qtx.CreatePriceList(ctx, sqlc.CreatePriceListParams{
+			Name:              request.Name,
+			CustomerCompanyID: db.ConvertToInt4(request.CustomerCompanyId),
+			CustomerGroupID:   db.ConvertToInt4(request.CustomerGroupId),
+			ValidFrom:         db.ConvertToDate(&request.ValidFrom),
+			ValidTo:           db.ConvertToDate(request.ValidTo),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return service.getPriceList(ctx, qtx, priceList.ID)
+	})
+}
+
+func (service *Service) UpdatePriceList(ctx context.Context, priceListId int32, request UpdatePriceListRequest) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		current, err := qtx.GetPriceListById(ctx, priceListId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrPriceListNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		validFrom := current.ValidFrom.Time
+		if request.ValidFrom != nil {
+			validFrom = *request.ValidFrom
+		}
+
+		validTo := request.ValidTo
+		if validTo == nil && current.ValidTo.Valid {
+			validTo = &current.ValidTo.Time
+		}
+
+		if validTo != nil && validTo.Before(validFrom) {
+			return ErrInvalidValidityRange
+		}
+
+		if _, err := qtx.UpdatePriceList(ctx, sqlc.UpdatePriceListParams{
+			Name:      db.ConvertToText(request.Name),
+			ValidFrom: db.ConvertToDate(request.ValidFrom),
+			ValidTo:   db.ConvertToDate(request.ValidTo),
+			IsActive:  db.ConvertToBool(request.IsActive),
+			ID:        priceListId,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+// AssignPriceList replaces the owner of the list. Sending neither id detaches
+// the list so that it no longer applies to anyone.
+func (service *Service) AssignPriceList(ctx context.Context, priceListId int32, request AssignPriceListRequest) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		if err := checkOwner(ctx, qtx, request.CustomerCompanyId, request.CustomerGroupId); err != nil {
+			return err
+		}
+
+		if _, err := qtx.SetPriceListOwner(ctx, sqlc.SetPriceListOwnerParams{
+			ID:                priceListId,
+			CustomerCompanyID: db.ConvertToInt4(request.CustomerCompanyId),
+			CustomerGroupID:   db.ConvertToInt4(request.CustomerGroupId),
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrPriceListNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+func (service *Service) SetPriceListItem(
+	ctx context.Context,
+	priceListId int32,
+	productId int32,
+	request SetPriceListItemRequest,
+) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		if _, err := qtx.GetPriceListById(ctx, priceListId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrPriceListNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if _, err := qtx.GetProductById(ctx, productId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return products.ErrProductNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		price, err := db.DecimalToNumeric(request.Price)
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.UpsertPriceListItem(ctx, sqlc.UpsertPriceListItemParams{
+			PriceListID: priceListId,
+			ProductID:   productId,
+			Price:       price,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+func (service *Service) RemovePriceListItem(ctx context.Context, priceListId int32, productId int32) error {
+	if _, err := service.query.DeletePriceListItem(ctx, sqlc.DeletePriceListItemParams{
+		PriceListID: priceListId,
+		ProductID:   productId,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPriceListItemNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// ResolvePrice returns the price the customer pays for the product on the
+// given day: the customer's own price list first, then its group's, then the
+// product's default price. Among several valid lists of the same kind the one
+// that became valid most recently wins.
+func (service *Service) ResolvePrice(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	customerId int32,
+	productId int32,
+	defaultPrice decimal.Decimal,
+	on time.Time,
+) (*ResolvedPrice, error) {
+	row, err := qtx.GetEffectivePrice(ctx, sqlc.GetEffectivePriceParams{
+		CustomerID: customerId,
+		ProductID:  productId,
+		OnDate:     db.ConvertToDate(&on),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &ResolvedPrice{UnitPrice: defaultPrice, Source: sqlc.PriceSourceDEFAULTPRICE}, nil
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	price, err := decimal.NewFromString(row.PriceText)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResolvedPrice{UnitPrice: price, Source: row.PriceSource, PriceListId: &row.PriceListID}, nil
+}
+
+func (service *Service) getPriceList(ctx context.Context, qtx *sqlc.Queries, priceListId int32) (*GetPriceListResponse, error) {
+	row, err := qtx.GetPriceListById(ctx, priceListId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPriceListNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	itemRows, err := qtx.ListPriceListItems(ctx, priceListId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	items := make([]PriceListItem, len(itemRows))
+	for i, itemRow := range itemRows {
+		price, err := decimal.NewFromString(itemRow.PriceText)
+		if err != nil {
+			return nil, err
+		}
+
+		defaultPrice, err := decimal.NewFromString(itemRow.DefaultPriceText)
+		if err != nil {
+			return nil, err
+		}
+
+		items[i] = PriceListItem{
+			ProductId:    itemRow.ProductID,
+			ProductName:  itemRow.ProductName,
+			Unit:         itemRow.Unit,
+			Price:        price,
+			DefaultPrice: defaultPrice,
+		}
+	}
+
+	return &GetPriceListResponse{PriceList: mapPriceList(row), Items: items}, nil
+}
+
+func checkOwner(ctx context.Context, qtx *sqlc.Queries, companyId *int32, groupId *int32) error {
+	if companyId != nil && groupId != nil {
+		return ErrAmbiguousOwner
+	}
+
+	if companyId != nil {
+		if _, err := qtx.GetCustomerCompanyById(ctx, *companyId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return companies.ErrCompanyNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	if groupId != nil {
+		if _, err := qtx.GetCustomerGroupById(ctx, *groupId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return customergroups.ErrCustomerGroupNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return nil
+}
+
+func mapPriceList(row sqlc.GetPriceListByIdRow) PriceList {
+	priceList := PriceList{
+		Id:        row.ID,
+		Name:      row.Name,
+		ValidFrom: row.ValidFrom.Time,
+		IsActive:  row.IsActive,
+		CreatedAt: row.CreatedAt.Time,
+	}
+
+	if row.CustomerCompanyID.Valid {
+		priceList.CustomerCompanyId = &row.CustomerCompanyID.Int32
+		priceList.CustomerCompanyName = &row.CustomerCompanyName.String
+	}
+
+	if row.CustomerGroupID.Valid {
+		priceList.CustomerGroupId = &row.CustomerGroupID.Int32
+		priceList.CustomerGroupName = &row.CustomerGroupName.String
+	}
+
+	if row.ValidTo.Valid {
+		priceList.ValidTo = &row.ValidTo.Time
+	}
+
+	return priceList
+}
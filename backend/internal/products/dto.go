@@ -1,6 +1,10 @@
 package products
 
-import "github.com/shopspring/decimal"
+import (
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
 
 type Product struct {
 	Id            int32           `json:"id"`
@@ -8,6 +12,7 @@ type Product struct {
 	Category      string          `json:"category"`
 	Unit          string          `json:"unit"`
 	DefaultPrice  decimal.Decimal `json:"defaultPrice"`
+	VatRate       sqlc.VatRate    `json:"vatRate"`
 	IsActive      bool            `json:"isActive"`
 	Quantity      int32           `json:"quantity"`
 	MinQuantity   int32           `json:"minQuantity"`
@@ -25,6 +30,7 @@ type CreateProductRequest struct {
 	Category     string          `json:"category" validate:"required,max=100"`
 	Unit         string          `json:"unit" validate:"required,max=50"`
 	DefaultPrice decimal.Decimal `json:"defaultPrice" validate:"required,decimalpos"`
+	VatRate      sqlc.VatRate    `json:"vatRate" validate:"required,oneof=23 8 5 0 ZW"`
 }
 
 type UpdateProductRequest struct {
@@ -32,4 +38,5 @@ type UpdateProductRequest struct {
 	Category     *string          `json:"category" validate:"omitempty,max=100"`
 	Unit         *string          `json:"unit" validate:"omitempty,max=50"`
 	DefaultPrice *decimal.Decimal `json:"defaultPrice" validate:"omitempty"`
+	VatRate      *sqlc.VatRate    `json:"vatRate" validate:"omitempty,oneof=23 8 5 0 ZW"`
 }
@@ -57,6 +57,7 @@ func (service *Service) GetProduct(ctx context.Context, productId int32) (*Produ
 		Category:     row.Category,
 		Unit:         row.Unit,
 		DefaultPrice: price,
+		VatRate:      row.VatRate,
 		IsActive:     row.IsActive,
 	}, nil
 }
@@ -72,6 +73,7 @@ func (service *Service) CreateProduct(ctx context.Context, request CreateProduct
 		Category:     request.Category,
 		Unit:         request.Unit,
 		DefaultPrice: *price,
+		VatRate:      request.VatRate,
 	}); err != nil {
 		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
 	}
@@ -130,6 +132,7 @@ func mapProduct(product sqlc.ListProductsRow) (*Product, error) {
 		Category:      product.Category,
 		Unit:          product.Unit,
 		DefaultPrice:  price,
+		VatRate:       product.VatRate,
 		IsActive:      product.IsActive,
 		Quantity:      product.Quantity,
 		MinQuantity:   product.MinQuantity,
@@ -158,6 +161,9 @@ func buildUpdateProductParams(productId int32, request UpdateProductRequest) (*s
 		}
 		params.DefaultPrice = *price
 	}
+	if request.VatRate != nil {
+		params.VatRate = sqlc.NullVatRate{VatRate: *request.VatRate, Valid: true}
+	}
 
 	return &params, nil
 }
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Job func(ctx context.Context) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+type Scheduler struct {
+	entries []entry
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+func (scheduler *Scheduler) Every(name string, interval time.Duration, job Job) {
+	scheduler.entries = append(scheduler.entries, entry{name: name, interval: interval, job: job})
+}
+
+// Start runs every registered job once right away and then on its interval
+// until ctx is cancelled.
+func (scheduler *Scheduler) Start(ctx context.Context) {
+	for _, e := range scheduler.entries {
+		go scheduler.run(ctx, e)
+	}
+}
+
+func (scheduler *Scheduler) run(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		scheduler.execute(ctx, e)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (scheduler *Scheduler) execute(ctx context.Context, e entry) {
+	log := logrus.WithField("job", e.name)
+	started := time.Now()
+
+	if err := e.job(ctx); err != nil {
+		log.WithError(err).Error("Scheduled job failed")
+		return
+	}
+
+	log.WithField("duration", time.Since(started)).Info("Scheduled job finished")
+}
@@ -0,0 +1,65 @@
+package suppliers
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Supplier struct {
+	Id                        int32     `json:"id"`
+	Name                      string    `json:"name"`
+	TaxId                     *string   `json:"taxId,omitempty"`
+	Email                     *string   `json:"email,omitempty"`
+	PhoneNumber               *string   `json:"phoneNumber,omitempty"`
+	Address                   *string   `json:"address,omitempty"`
+	OverDeliveryTolerancePct  int32     `json:"overDeliveryTolerancePct"`
+	UnderDeliveryTolerancePct int32     `json:"underDeliveryTolerancePct"`
+	IsActive                  bool      `json:"isActive"`
+	CreatedAt                 time.Time `json:"createdAt"`
+}
+
+type ListSuppliersResponse struct {
+	Suppliers []Supplier `json:"suppliers"`
+}
+
+type CreateSupplierRequest struct {
+	Name                      string  `json:"name" validate:"required,max=200"`
+	TaxId                     *string `json:"taxId" validate:"omitempty,max=20"`
+	Email                     *string `json:"email" validate:"omitempty,email,max=255"`
+	PhoneNumber               *string `json:"phoneNumber" validate:"omitempty,e164"`
+	Address                   *string `json:"address" validate:"omitempty,max=255"`
+	OverDeliveryTolerancePct  int32   `json:"overDeliveryTolerancePct" validate:"gte=0,lte=100"`
+	UnderDeliveryTolerancePct int32   `json:"underDeliveryTolerancePct" validate:"gte=0,lte=100"`
+}
+
+type UpdateSupplierRequest struct {
+	Name                      *string `json:"name" validate:"omitempty,max=200"`
+	TaxId                     *string `json:"taxId" validate:"omitempty,max=20"`
+	Email                     *string `json:"email" validate:"omitempty,email,max=255"`
+	PhoneNumber               *string `json:"phoneNumber" validate:"omitempty,e164"`
+	Address                   *string `json:"address" validate:"omitempty,max=255"`
+	OverDeliveryTolerancePct  *int32  `json:"overDeliveryTolerancePct" validate:"omitempty,gte=0,lte=100"`
+	UnderDeliveryTolerancePct *int32  `json:"underDeliveryTolerancePct" validate:"omitempty,gte=0,lte=100"`
+}
+
+type SupplierProduct struct {
+	ProductId        int32           `json:"productId"`
+	ProductName      string          `json:"productName"`
+	Unit             string          `json:"unit"`
+	LeadTimeDays     int32           `json:"leadTimeDays"`
+	MinOrderQuantity int32           `json:"minOrderQuantity"`
+	UnitPrice        decimal.Decimal `json:"unitPrice"`
+}
+
+type ListSupplierProductsResponse struct {
+	Products []SupplierProduct `json:"products"`
+}
+
+// SetSupplierProductRequest makes the supplier the preferred source of a
+// product, replacing whichever supplier it was bought from before.
+type SetSupplierProductRequest struct {
+	LeadTimeDays     int32           `json:"leadTimeDays" validate:"gte=0"`
+	MinOrderQuantity *int32          `json:"minOrderQuantity" validate:"omitempty,gt=0"`
+	UnitPrice        decimal.Decimal `json:"unitPrice" validate:"required,decimalpos"`
+}
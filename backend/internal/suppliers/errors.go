@@ -0,0 +1,12 @@
+package suppliers
+
+import "errors"
+
+var (
+	ErrSupplierIdRequired = errors.New("supplier id is required")
+	ErrInvalidSupplierId  = errors.New("invalid supplier id")
+	ErrSupplierNotFound   = errors.New("supplier not found")
+	ErrSupplierInactive   = errors.New("supplier is inactive")
+
+	ErrSupplierProductNotFound = errors.New("product is not sourced from this supplier")
+)
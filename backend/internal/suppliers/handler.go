@@ -0,0 +1,233 @@
+package suppliers
+
+import (
+	"errors"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/products"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListSuppliers(writer http.ResponseWriter, request *http.Request) {
+	suppliers, err := handler.service.ListSuppliers(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListSuppliersResponse{Suppliers: suppliers})
+}
+
+func (handler *Handler) GetSupplier(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	supplier, err := handler.service.GetSupplier(request.Context(), supplierId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, supplier)
+}
+
+func (handler *Handler) CreateSupplier(writer http.ResponseWriter, request *http.Request) {
+	var body CreateSupplierRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	supplier, err := handler.service.CreateSupplier(request.Context(), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, supplier)
+}
+
+func (handler *Handler) UpdateSupplier(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body UpdateSupplierRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.UpdateSupplier(request.Context(), supplierId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) ActivateSupplier(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.ActivateSupplier(request.Context(), supplierId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) DeactivateSupplier(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.DeactivateSupplier(request.Context(), supplierId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) ListSupplierProducts(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	supplierProducts, err := handler.service.ListSupplierProducts(request.Context(), supplierId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListSupplierProductsResponse{Products: supplierProducts})
+}
+
+func (handler *Handler) SetSupplierProduct(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	productId, err := handler.extractProductId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body SetSupplierProductRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := handler.service.SetSupplierProduct(request.Context(), supplierId, productId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) RemoveSupplierProduct(writer http.ResponseWriter, request *http.Request) {
+	supplierId, err := handler.extractSupplierId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	productId, err := handler.extractProductId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.RemoveSupplierProduct(request.Context(), supplierId, productId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractProductId(request *http.Request) (int32, error) {
+	productIdStr := chi.URLParam(request, "productId")
+	if productIdStr == "" {
+		return 0, products.ErrProductIdRequired
+	}
+
+	productId, err := strconv.Atoi(productIdStr)
+	if err != nil {
+		return 0, products.ErrInvalidProductId
+	}
+
+	return int32(productId), nil
+}
+
+func (handler *Handler) extractSupplierId(request *http.Request) (int32, error) {
+	supplierIdStr := chi.URLParam(request, "supplierId")
+	if supplierIdStr == "" {
+		return 0, ErrSupplierIdRequired
+	}
+
+	supplierId, err := strconv.Atoi(supplierIdStr)
+	if err != nil {
+		return 0, ErrInvalidSupplierId
+	}
+
+	return int32(supplierId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrSupplierIdRequired):
+		return http.StatusBadRequest, ErrSupplierIdRequired.Error()
+	case errors.Is(err, ErrInvalidSupplierId):
+		return http.StatusBadRequest, ErrInvalidSupplierId.Error()
+	case errors.Is(err, products.ErrProductIdRequired), errors.Is(err, products.ErrInvalidProductId):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, ErrSupplierNotFound):
+		return http.StatusNotFound, ErrSupplierNotFound.Error()
+	case errors.Is(err, ErrSupplierProductNotFound), errors.Is(err, products.ErrProductNotFound):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, ErrSupplierInactive):
+		return http.StatusConflict, ErrSupplierInactive.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
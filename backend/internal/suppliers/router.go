@@ -0,0 +1,32 @@
+package suppliers
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(suppliersHandler *Handler, middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF, sqlc.RoleWAREHOUSE))
+		r.Get("/", suppliersHandler.ListSuppliers)
+		r.Get("/{supplierId}", suppliersHandler.GetSupplier)
+		r.Get("/{supplierId}/products", suppliersHandler.ListSupplierProducts)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Post("/", suppliersHandler.CreateSupplier)
+		r.Patch("/{supplierId}", suppliersHandler.UpdateSupplier)
+		r.Patch("/{supplierId}/activate", suppliersHandler.ActivateSupplier)
+		r.Patch("/{supplierId}/deactivate", suppliersHandler.DeactivateSupplier)
+		r.Put("/{supplierId}/products/{productId}", suppliersHandler.SetSupplierProduct)
+		r.Delete("/{supplierId}/products/{productId}", suppliersHandler.RemoveSupplierProduct)
+	})
+
+	return router
+}
@@ -0,0 +1,223 @@
+package suppliers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/products"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+type Service struct {
+	query *sqlc.Queries
+}
+
+func NewService(query *sqlc.Queries) *Service {
+	return &Service{query: query}
+}
+
+func (service *Service) ListSuppliers(ctx context.Context) ([]Supplier, error) {
+	rows, err := service.query.ListSuppliers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Supplier, len(rows))
+	for i, row := range rows {
+		result[i] = mapSupplier(row)
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetSupplier(ctx context.Context, supplierId int32) (*Supplier, error) {
+	row, err := service.query.GetSupplierById(ctx, supplierId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	supplier := mapSupplier(row)
+	return &supplier, nil
+}
+
+func (service *Service) CreateSupplier(ctx context.Context, request CreateSupplierRequest) (*Supplier, error) {
+	row, err := service.query.CreateSupplier(ctx, sqlc.CreateSupplierParams{
+		Name:                      request.Name,
+		TaxID:                     db.ConvertToText(request.TaxId),
+		Email:                     db.ConvertToText(request.Email),
+		Phone:                     db.ConvertToText(request.PhoneNumber),
+		Address:                   db.ConvertToText(request.Address),
+		OverDeliveryTolerancePct:  request.OverDeliveryTolerancePct,
+		UnderDeliveryTolerancePct: request.UnderDeliveryTolerancePct,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	supplier := mapSupplier(row)
+	return &supplier, nil
+}
+
+func (service *Service) UpdateSupplier(ctx context.Context, supplierId int32, request UpdateSupplierRequest) error {
+	if _, err := service.query.UpdateSupplier(ctx, sqlc.UpdateSupplierParams{
+		Name:                      db.ConvertToText(request.Name),
+		TaxID:                     db.ConvertToText(request.TaxId),
+		Email:                     db.ConvertToText(request.Email),
+		Phone:                     db.ConvertToText(request.PhoneNumber),
+		Address:                   db.ConvertToText(request.Address),
+		OverDeliveryTolerancePct:  db.ConvertToInt4(request.OverDeliveryTolerancePct),
+		UnderDeliveryTolerancePct: db.ConvertToInt4(request.UnderDeliveryTolerancePct),
+		ID:                        supplierId,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSupplierNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func (service *Service) ActivateSupplier(ctx context.Context, supplierId int32) error {
+	return service.updateSupplierStatus(ctx, supplierId, service.query.ActivateSupplier, "activate")
+}
+
+func (service *Service) DeactivateSupplier(ctx context.Context, supplierId int32) error {
+	return service.updateSupplierStatus(ctx, supplierId, service.query.DeactivateSupplier, "deactivate")
+}
+
+func (service *Service) updateSupplierStatus(ctx context.Context, supplierId int32, operation func(context.Context, int32) (sqlc.Supplier, error), operationName string) error {
+	if _, err := operation(ctx, supplierId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSupplierNotFound
+		}
+		return fmt.Errorf("%w: failed to %s supplier: %v", db.ErrDatabaseOperation, operationName, err)
+	}
+
+	return nil
+}
+
+func (service *Service) ListSupplierProducts(ctx context.Context, supplierId int32) ([]SupplierProduct, error) {
+	if _, err := service.GetSupplier(ctx, supplierId); err != nil {
+		return nil, err
+	}
+
+	rows, err := service.query.ListSupplierProducts(ctx, supplierId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]SupplierProduct, len(rows))
+	for i, row := range rows {
+		unitPrice, err := decimal.NewFromString(row.UnitPriceText)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = SupplierProduct{
+			ProductId:        row.ProductID,
+			ProductName:      row.ProductName,
+			Unit:             row.Unit,
+			LeadTimeDays:     row.LeadTimeDays,
+			MinOrderQuantity: row.MinOrderQuantity,
+			UnitPrice:        unitPrice,
+		}
+	}
+
+	return result, nil
+}
+
+func (service *Service) SetSupplierProduct(
+	ctx context.Context,
+	supplierId int32,
+	productId int32,
+	request SetSupplierProductRequest,
+) error {
+	supplier, err := service.GetSupplier(ctx, supplierId)
+	if err != nil {
+		return err
+	}
+
+	if !supplier.IsActive {
+		return ErrSupplierInactive
+	}
+
+	if _, err := service.query.GetProductById(ctx, productId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return products.ErrProductNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	unitPrice, err := db.DecimalToNumeric(request.UnitPrice)
+	if err != nil {
+		return err
+	}
+
+	minOrderQuantity := int32(1)
+	if request.MinOrderQuantity != nil {
+		minOrderQuantity = *request.MinOrderQuantity
+	}
+
+	if _, err := service.query.UpsertProductSupplier(ctx, sqlc.UpsertProductSupplierParams{
+		ProductID:        productId,
+		SupplierID:       supplierId,
+		LeadTimeDays:     request.LeadTimeDays,
+		MinOrderQuantity: minOrderQuantity,
+		UnitPrice:        unitPrice,
+	}); err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func (service *Service) RemoveSupplierProduct(ctx context.Context, supplierId int32, productId int32) error {
+	if _, err := service.query.DeleteProductSupplier(ctx, sqlc.DeleteProductSupplierParams{
+		ProductID:  productId,
+		SupplierID: supplierId,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSupplierProductNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func mapSupplier(row sqlc.Supplier) Supplier {
+	supplier := Supplier{
+		Id:                        row.ID,
+		Name:                      row.Name,
+		OverDeliveryTolerancePct:  row.OverDeliveryTolerancePct,
+		UnderDeliveryTolerancePct: row.UnderDeliveryTolerancePct,
+		IsActive:                  row.IsActive,
+		CreatedAt:                 row.CreatedAt.Time,
+	}
+
+	if row.TaxID.Valid {
+		supplier.TaxId = &row.TaxID.String
+	}
+
+	if row.Email.Valid {
+		supplier.Email = &row.Email.String
+	}
+
+	if row.Phone.Valid {
+		supplier.PhoneNumber = &row.Phone.String
+	}
+
+	if row.Address.Valid {
+		supplier.Address = &row.Address.String
+	}
+
+	return supplier
+}
@@ -0,0 +1,76 @@
+package vat
+
+import (
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
+
+// rates lists the rates in the order they are printed on an invoice.
+var rates = []sqlc.VatRate{sqlc.VatRate23, sqlc.VatRate8, sqlc.VatRate5, sqlc.VatRate0, sqlc.VatRateZW}
+
+// Percentage returns the tax percentage of a rate. The exempt rate (ZW)
+// charges no tax, same as 0%.
+func Percentage(rate sqlc.VatRate) decimal.Decimal {
+	switch rate {
+	case sqlc.VatRate23:
+		return decimal.NewFromInt(23)
+	case sqlc.VatRate8:
+		return decimal.NewFromInt(8)
+	case sqlc.VatRate5:
+		return decimal.NewFromInt(5)
+	default:
+		return decimal.Zero
+	}
+}
+
+// Amount returns the tax due on a net amount, rounded to grosze.
+func Amount(net decimal.Decimal, rate sqlc.VatRate) decimal.Decimal {
+	return net.Mul(Percentage(rate)).Div(decimal.NewFromInt(100)).Round(2)
+}
+
+type SummaryLine struct {
+	Rate  sqlc.VatRate
+	Net   decimal.Decimal
+	Vat   decimal.Decimal
+	Gross decimal.Decimal
+}
+
+// Summary groups net amounts by rate. Tax is computed once per rate on the
+// summed net, as the invoice shows it, so it may differ by a grosz from the
+// sum of per-line tax.
+type Summary struct {
+	net map[sqlc.VatRate]decimal.Decimal
+}
+
+func NewSummary() *Summary {
+	return &Summary{net: make(map[sqlc.VatRate]decimal.Decimal)}
+}
+
+func (summary *Summary) Add(rate sqlc.VatRate, net decimal.Decimal) {
+	summary.net[rate] = summary.net[rate].Add(net)
+}
+
+// Lines returns one line per rate that appeared, in invoice order.
+func (summary *Summary) Lines() []SummaryLine {
+	lines := make([]SummaryLine, 0, len(summary.net))
+	for _, rate := range rates {
+		net, ok := summary.net[rate]
+		if !ok {
+			continue
+		}
+		tax := Amount(net, rate)
+		lines = append(lines, SummaryLine{Rate: rate, Net: net, Vat: tax, Gross: net.Add(tax)})
+	}
+	return lines
+}
+
+// Totals sums the lines into the invoice's net, tax and gross amounts.
+func Totals(lines []SummaryLine) (net, tax, gross decimal.Decimal) {
+	for _, line := range lines {
+		net = net.Add(line.Net)
+		tax = tax.Add(line.Vat)
+		gross = gross.Add(line.Gross)
+	}
+	return net, tax, gross
+}
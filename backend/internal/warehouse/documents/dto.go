@@ -0,0 +1,61 @@
+package documents
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"time"
+)
+
+type Document struct {
+	Id                  int32                      `json:"id"`
+	DocumentType        sqlc.WarehouseDocumentType `json:"documentType"`
+	DocumentNumber      string                     `json:"documentNumber"`
+	WarehouseId         int32                      `json:"warehouseId"`
+	WarehouseName       string                     `json:"warehouseName"`
+	IssueDate           time.Time                  `json:"issueDate"`
+	SupplierName        *string                    `json:"supplierName,omitempty"`
+	RelatedOrderId      *int32                     `json:"relatedOrderId,omitempty"`
+	OrderNumber         *string                    `json:"orderNumber,omitempty"`
+	PurchaseOrderId     *int32                     `json:"purchaseOrderId,omitempty"`
+	PurchaseOrderNumber *string                    `json:"purchaseOrderNumber,omitempty"`
+	Note                *string                    `json:"note,omitempty"`
+	EmployeeId          *int32                     `json:"employeeId,omitempty"`
+	Lines               []DocumentLine             `json:"lines,omitempty"`
+}
+
+type DocumentLine struct {
+	MovementId  int32     `json:"movementId"`
+	ProductId   int32     `json:"productId"`
+	ProductName string    `json:"productName"`
+	Unit        string    `json:"unit"`
+	LotId       int32     `json:"lotId"`
+	BatchNumber string    `json:"batchNumber"`
+	BestBefore  time.Time `json:"bestBefore"`
+	Quantity    int32     `json:"quantity"`
+}
+
+type ListDocumentsResponse struct {
+	Documents []Document `json:"documents"`
+}
+
+type GoodsReceivedLine struct {
+	ProductId      int32     `json:"productId" validate:"required"`
+	Quantity       int32     `json:"quantity" validate:"required,gt=0"`
+	BatchNumber    string    `json:"batchNumber" validate:"required,max=100"`
+	ProductionDate time.Time `json:"productionDate" validate:"required"`
+	BestBefore     time.Time `json:"bestBefore" validate:"required,gtefield=ProductionDate"`
+}
+
+// GoodsReceivedHeader describes a PZ before any of its lines are booked.
+type GoodsReceivedHeader struct {
+	WarehouseId     int32
+	SupplierName    string
+	PurchaseOrderId *int32
+	Note            *string
+}
+
+type CreateGoodsReceivedRequest struct {
+	WarehouseId  *int32              `json:"warehouseId"`
+	SupplierName string              `json:"supplierName" validate:"required,max=200"`
+	Note         *string             `json:"note" validate:"omitempty,max=255"`
+	Lines        []GoodsReceivedLine `json:"lines" validate:"required,min=1,dive"`
+}
@@ -0,0 +1,10 @@
+package documents
+
+import "errors"
+
+var (
+	ErrDocumentNotFound    = errors.New("warehouse document not found")
+	ErrDocumentIdRequired  = errors.New("document id required")
+	ErrInvalidDocumentId   = errors.New("invalid document id")
+	ErrInvalidDocumentType = errors.New("invalid document type")
+)
@@ -0,0 +1,153 @@
+package documents
+
+import (
+	"errors"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListDocuments(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var documentType *sqlc.WarehouseDocumentType
+	if typeStr := request.URL.Query().Get("type"); typeStr != "" {
+		value := sqlc.WarehouseDocumentType(typeStr)
+		if value != sqlc.WarehouseDocumentTypePZ && value != sqlc.WarehouseDocumentTypeWZ {
+			handler.handleServiceError(writer, ErrInvalidDocumentType)
+			return
+		}
+		documentType = &value
+	}
+
+	documents, err := handler.service.ListDocuments(request.Context(), documentType, warehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListDocumentsResponse{Documents: documents})
+}
+
+func (handler *Handler) GetDocument(writer http.ResponseWriter, request *http.Request) {
+	documentId, err := handler.extractDocumentId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	document, err := handler.service.GetDocument(request.Context(), documentId, scope)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, document)
+}
+
+func (handler *Handler) GetDocumentPdf(writer http.ResponseWriter, request *http.Request) {
+	documentId, err := handler.extractDocumentId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	document, err := handler.service.GetDocument(request.Context(), documentId, scope)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	documentPdfBytes, err := GenerateDocumentPDF(*document)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/pdf")
+	writer.Header().Set("Content-Disposition", "inline; filename="+document.DocumentNumber+".pdf")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(documentPdfBytes)
+}
+
+func (handler *Handler) CreateGoodsReceived(writer http.ResponseWriter, request *http.Request) {
+	var body CreateGoodsReceivedRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
+	document, err := handler.service.CreateGoodsReceived(request.Context(), body, nil)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, document)
+}
+
+func (handler *Handler) extractDocumentId(request *http.Request) (int32, error) {
+	documentIdStr := chi.URLParam(request, "documentId")
+	if documentIdStr == "" {
+		return 0, ErrDocumentIdRequired
+	}
+
+	documentId, err := strconv.Atoi(documentIdStr)
+	if err != nil {
+		return 0, ErrInvalidDocumentId
+	}
+
+	return int32(documentId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrDocumentNotFound), errors.Is(err, locations.ErrWarehouseNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrDocumentIdRequired), errors.Is(err, ErrInvalidDocumentId), errors.Is(err, ErrInvalidDocumentType),
+		errors.Is(err, locations.ErrInvalidWarehouseId):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, locations.ErrWarehouseForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, movements.ErrLotDatesMismatch), errors.Is(err, locations.ErrWarehouseInactive):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
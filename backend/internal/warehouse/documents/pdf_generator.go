@@ -0,0 +1,95 @@
+package documents
+
+import (
+	"fmt"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/pdfutil"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+var documentTitles = map[sqlc.WarehouseDocumentType]string{
+	sqlc.WarehouseDocumentTypePZ: "Przyjęcie zewnętrzne",
+	sqlc.WarehouseDocumentTypeWZ: "Wydanie zewnętrzne",
+}
+
+func GenerateDocumentPDF(data Document) ([]byte, error) {
+	pdf := pdfutil.New()
+
+	addHeader(pdf, data)
+	addPartiesSection(pdf, data)
+	addLinesTable(pdf, data.Lines)
+	addSignatures(pdf)
+
+	return pdfutil.Output(pdf)
+}
+
+func addHeader(pdf *gofpdf.Fpdf, data Document) {
+	pdf.SetFont("JuliaMono", "B", 20)
+	pdf.Cell(0, 10, documentTitles[data.DocumentType]+" nr: "+data.DocumentNumber)
+	pdf.Ln(12)
+
+	pdf.SetFont("JuliaMono", "", 10)
+	pdf.Cell(100, 6, fmt.Sprintf("Magazyn: %s", data.WarehouseName))
+	pdf.Cell(0, 6, fmt.Sprintf("Data wystawienia: %s", data.IssueDate.Format("2006-01-02")))
+	pdf.Ln(10)
+}
+
+func addPartiesSection(pdf *gofpdf.Fpdf, data Document) {
+	pdf.SetFont("JuliaMono", "", 10)
+
+	if data.SupplierName != nil {
+		pdf.Cell(0, 5, fmt.Sprintf("Dostawca: %s", *data.SupplierName))
+		pdf.Ln(5)
+	}
+
+	if data.OrderNumber != nil {
+		pdf.Cell(0, 5, fmt.Sprintf("Zamówienie: %s", *data.OrderNumber))
+		pdf.Ln(5)
+	}
+
+	if data.PurchaseOrderNumber != nil {
+		pdf.Cell(0, 5, fmt.Sprintf("Zamówienie do dostawcy: %s", *data.PurchaseOrderNumber))
+		pdf.Ln(5)
+	}
+
+	if data.Note != nil {
+		pdf.Cell(0, 5, fmt.Sprintf("Uwagi: %s", *data.Note))
+		pdf.Ln(5)
+	}
+
+	pdf.Ln(5)
+}
+
+func addLinesTable(pdf *gofpdf.Fpdf, lines []DocumentLine) {
+	pdf.SetFont("JuliaMono", "B", 10)
+
+	headers := []string{"Nazwa towaru", "Partia", "Data przydatności", "Ilość", "Jednostka"}
+	widths := []float64{60, 40, 35, 20, 25}
+
+	for i, h := range headers {
+		pdf.CellFormat(widths[i], 7, h, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("JuliaMono", "", 10)
+
+	for _, line := range lines {
+		pdf.CellFormat(widths[0], 6, line.ProductName, "1", 0, "", false, 0, "")
+		pdf.CellFormat(widths[1], 6, line.BatchNumber, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[2], 6, line.BestBefore.Format("2006-01-02"), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[3], 6, fmt.Sprintf("%d", line.Quantity), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(widths[4], 6, line.Unit, "1", 0, "C", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	pdf.Ln(20)
+}
+
+func addSignatures(pdf *gofpdf.Fpdf) {
+	pdf.SetFont("JuliaMono", "", 10)
+	pdf.CellFormat(80, 6, "Wystawił", "T", 0, "C", false, 0, "")
+	pdf.Cell(20, 6, "")
+	pdf.CellFormat(80, 6, "Odebrał", "T", 0, "C", false, 0, "")
+	pdf.Ln(-1)
+}
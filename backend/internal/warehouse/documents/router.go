@@ -0,0 +1,26 @@
+package documents
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(
+	documentsHandler *Handler,
+	middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Get("/", documentsHandler.ListDocuments)
+	router.Get("/{documentId}", documentsHandler.GetDocument)
+	router.Get("/{documentId}/pdf", documentsHandler.GetDocumentPdf)
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleWAREHOUSE))
+		r.Post("/pz", documentsHandler.CreateGoodsReceived)
+	})
+
+	return router
+}
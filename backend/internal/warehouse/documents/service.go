@@ -0,0 +1,218 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	query     *sqlc.Queries
+	pool      *pgxpool.Pool
+	movements *movements.Service
+}
+
+func NewService(queries *sqlc.Queries, pool *pgxpool.Pool, movementsService *movements.Service) *Service {
+	return &Service{query: queries, pool: pool, movements: movementsService}
+}
+
+func (service *Service) ListDocuments(
+	ctx context.Context,
+	documentType *sqlc.WarehouseDocumentType,
+	warehouseId *int32,
+) ([]Document, error) {
+	params := sqlc.ListWarehouseDocumentsParams{WarehouseID: db.ConvertToInt4(warehouseId)}
+	if documentType != nil {
+		params.DocumentType = sqlc.NullWarehouseDocumentType{WarehouseDocumentType: *documentType, Valid: true}
+	}
+
+	rows, err := service.query.ListWarehouseDocuments(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Document, len(rows))
+	for i, row := range rows {
+		result[i] = mapDocument(row)
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetDocument(ctx context.Context, documentId int32, warehouseScope *int32) (*Document, error) {
+	return service.getDocument(ctx, service.query, documentId, warehouseScope)
+}
+
+// CreateGoodsReceived issues a PZ for one supplier delivery and books every
+// line as an INBOUND movement attached to it.
+func (service *Service) CreateGoodsReceived(ctx context.Context, req CreateGoodsReceivedRequest, empId *int32) (*Document, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*Document, error) {
+		qtx := service.query.WithTx(tx)
+
+		warehouseId, err := service.movements.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err := service.IssueGoodsReceived(ctx, qtx, GoodsReceivedHeader{
+			WarehouseId:  warehouseId,
+			SupplierName: req.SupplierName,
+			Note:         req.Note,
+		}, empId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range req.Lines {
+			if _, err := service.movements.ReceiveStock(ctx, qtx, warehouseId, movements.InboundRequest{
+				ProductId:      line.ProductId,
+				Quantity:       line.Quantity,
+				BatchNumber:    line.BatchNumber,
+				ProductionDate: line.ProductionDate,
+				BestBefore:     line.BestBefore,
+				Reason:         document.DocumentNumber,
+			}, movements.Receipt{DocumentId: &document.ID}, empId); err != nil {
+				return nil, err
+			}
+		}
+
+		return service.getDocument(ctx, qtx, document.ID, nil)
+	})
+}
+
+// IssueGoodsReceived issues an empty PZ inside the caller's transaction. The
+// caller books the delivered lines against the returned document.
+func (service *Service) IssueGoodsReceived(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	header GoodsReceivedHeader,
+	empId *int32,
+) (*sqlc.WarehouseDocument, error) {
+	document, err := qtx.CreateWarehouseDocument(ctx, sqlc.CreateWarehouseDocumentParams{
+		DocumentType:    sqlc.WarehouseDocumentTypePZ,
+		WarehouseID:     header.WarehouseId,
+		SupplierName:    db.ConvertToText(&header.SupplierName),
+		Note:            db.ConvertToText(header.Note),
+		EmployeeID:      db.ConvertToInt4(empId),
+		PurchaseOrderID: db.ConvertToInt4(header.PurchaseOrderId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return &document, nil
+}
+
+// IssueOrderDispatch issues the WZ for a shipped order inside the caller's
+// transaction and attaches the order's DISPATCH movements to it.
+func (service *Service) IssueOrderDispatch(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	orderId int32,
+	empId *int32,
+) (*sqlc.WarehouseDocument, error) {
+	document, err := qtx.CreateWarehouseDocument(ctx, sqlc.CreateWarehouseDocumentParams{
+		DocumentType:   sqlc.WarehouseDocumentTypeWZ,
+		WarehouseID:    warehouseId,
+		RelatedOrderID: db.ConvertToInt4(&orderId),
+		EmployeeID:     db.ConvertToInt4(empId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if err := qtx.AttachOrderDispatchesToDocument(ctx, sqlc.AttachOrderDispatchesToDocumentParams{
+		DocumentID:     db.ConvertToInt4(&document.ID),
+		RelatedOrderID: db.ConvertToInt4(&orderId),
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return &document, nil
+}
+
+func (service *Service) getDocument(ctx context.Context, qtx *sqlc.Queries, documentId int32, warehouseScope *int32) (*Document, error) {
+	row, err := qtx.GetWarehouseDocumentById(ctx, documentId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if warehouseScope != nil && row.WarehouseID != *warehouseScope {
+		return nil, locations.ErrWarehouseForbidden
+	}
+
+	lines, err := qtx.ListWarehouseDocumentLines(ctx, pgtype.Int4{Int32: documentId, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	document := mapDocument(sqlc.ListWarehouseDocumentsRow(row))
+	document.Lines = make([]DocumentLine, len(lines))
+	for i, line := range lines {
+		document.Lines[i] = DocumentLine{
+			MovementId:  line.MovementID,
+			ProductId:   line.ProductID,
+			ProductName: line.ProductName,
+			Unit:        line.Unit,
+			LotId:       line.LotID,
+			BatchNumber: line.BatchNumber,
+			BestBefore:  line.BestBefore.Time,
+			Quantity:    line.Quantity,
+		}
+	}
+
+	return &document, nil
+}
+
+func mapDocument(row sqlc.ListWarehouseDocumentsRow) Document {
+	document := Document{
+		Id:             row.ID,
+		DocumentType:   row.DocumentType,
+		DocumentNumber: row.DocumentNumber,
+		WarehouseId:    row.WarehouseID,
+		WarehouseName:  row.WarehouseName,
+		IssueDate:      row.IssueDate.Time,
+	}
+
+	if row.SupplierName.Valid {
+		document.SupplierName = &row.SupplierName.String
+	}
+
+	if row.RelatedOrderID.Valid {
+		document.RelatedOrderId = &row.RelatedOrderID.Int32
+	}
+
+	if row.OrderNumber.Valid {
+		document.OrderNumber = &row.OrderNumber.String
+	}
+
+	if row.PurchaseOrderID.Valid {
+		document.PurchaseOrderId = &row.PurchaseOrderID.Int32
+	}
+
+	if row.PurchaseOrderNumber.Valid {
+		document.PurchaseOrderNumber = &row.PurchaseOrderNumber.String
+	}
+
+	if row.Note.Valid {
+		document.Note = &row.Note.String
+	}
+
+	if row.EmployeeID.Valid {
+		document.EmployeeId = &row.EmployeeID.Int32
+	}
+
+	return document
+}
@@ -1,13 +1,47 @@
 package warehouse
 
+import "time"
+
 type Stock struct {
-	ProductId     int32  `json:"productId"`
-	ProductName   string `json:"productName"`
-	Quantity      int32  `json:"quantity"`
-	MinQuantity   int32  `json:"minQuantity"`
-	IsLow         bool   `json:"isLow"`
-	DamagedCount  int32  `json:"damagedCount"`
-	ReturnedCount int32  `json:"returnedCount"`
+	WarehouseId       int32      `json:"warehouseId"`
+	WarehouseName     string     `json:"warehouseName"`
+	ProductId         int32      `json:"productId"`
+	ProductName       string     `json:"productName"`
+	Quantity          int32      `json:"quantity"`
+	ReservedQuantity  int32      `json:"reservedQuantity"`
+	AvailableQuantity int32      `json:"availableQuantity"`
+	MinQuantity       int32      `json:"minQuantity"`
+	IsLow             bool       `json:"isLow"`
+	DamagedCount      int32      `json:"damagedCount"`
+	ReturnedCount     int32      `json:"returnedCount"`
+	Lots              []StockLot `json:"lots,omitempty"`
+}
+
+type StockLot struct {
+	Id             int32     `json:"id"`
+	BatchNumber    string    `json:"batchNumber"`
+	ProductionDate time.Time `json:"productionDate"`
+	BestBefore     time.Time `json:"bestBefore"`
+	Quantity       int32     `json:"quantity"`
+}
+
+type ExpiringLot struct {
+	LotId          int32     `json:"lotId"`
+	WarehouseId    int32     `json:"warehouseId"`
+	WarehouseName  string    `json:"warehouseName"`
+	ProductId      int32     `json:"productId"`
+	ProductName    string    `json:"productName"`
+	Category       string    `json:"category"`
+	BatchNumber    string    `json:"batchNumber"`
+	ProductionDate time.Time `json:"productionDate"`
+	BestBefore     time.Time `json:"bestBefore"`
+	Quantity       int32     `json:"quantity"`
+	DaysLeft       int32     `json:"daysLeft"`
+}
+
+type ListExpiringLotsResponse struct {
+	Days int32         `json:"days"`
+	Lots []ExpiringLot `json:"lots"`
 }
 
 type ListStockResponse struct {
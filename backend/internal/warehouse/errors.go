@@ -6,4 +6,5 @@ var (
 	ErrStockNotFound     = errors.New("stock not found")
 	ErrProductIdRequired = errors.New("productId required")
 	ErrInvalidProductId  = errors.New("invalid productId")
+	ErrInvalidDays       = errors.New("days must be a non-negative number")
 )
@@ -9,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/warehouse/locations"
 )
 
 type Handler struct {
@@ -20,7 +21,13 @@ func NewHandler(s *Service) *Handler {
 }
 
 func (handler *Handler) ListStock(writer http.ResponseWriter, request *http.Request) {
-	stock, err := handler.service.ListStock(request.Context())
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	stock, err := handler.service.ListStock(request.Context(), warehouseId)
 	if err != nil {
 		handler.handleServiceError(writer, err)
 		return
@@ -29,6 +36,33 @@ func (handler *Handler) ListStock(writer http.ResponseWriter, request *http.Requ
 	httputil.WriteJSON(writer, http.StatusOK, ListStockResponse{Stocks: stock})
 }
 
+func (handler *Handler) ListExpiringLots(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var days *int32
+	if daysStr := request.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 0 {
+			handler.handleServiceError(writer, ErrInvalidDays)
+			return
+		}
+		value := int32(parsed)
+		days = &value
+	}
+
+	response, err := handler.service.ListExpiringLots(request.Context(), warehouseId, days)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, response)
+}
+
 func (handler *Handler) GetStockByProductId(writer http.ResponseWriter, request *http.Request) {
 	productId, err := handler.extractProductId(request)
 	if err != nil {
@@ -36,7 +70,13 @@ func (handler *Handler) GetStockByProductId(writer http.ResponseWriter, request
 		return
 	}
 
-	stock, err := handler.service.GetStockByProductId(request.Context(), productId)
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	stock, err := handler.service.GetStockByProductId(request.Context(), warehouseId, productId)
 	if err != nil {
 		handler.handleServiceError(writer, err)
 		return
@@ -52,13 +92,19 @@ func (handler *Handler) UpdateStock(writer http.ResponseWriter, request *http.Re
 		return
 	}
 
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
 	var body UpdateStockRequest
 	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
 		handler.handleServiceError(writer, err)
 		return
 	}
 
-	if err := handler.service.UpdateStock(request.Context(), productId, body); err != nil {
+	if err := handler.service.UpdateStock(request.Context(), warehouseId, productId, body); err != nil {
 		handler.handleServiceError(writer, err)
 		return
 	}
@@ -88,8 +134,11 @@ func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error
 
 func (handler *Handler) mapErrorToResponse(err error) (int, string) {
 	switch {
-	case errors.Is(err, ErrProductIdRequired), errors.Is(err, ErrInvalidProductId):
+	case errors.Is(err, ErrProductIdRequired), errors.Is(err, ErrInvalidProductId), errors.Is(err, ErrInvalidDays),
+		errors.Is(err, locations.ErrInvalidWarehouseId):
 		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, locations.ErrWarehouseForbidden):
+		return http.StatusForbidden, err.Error()
 	case errors.Is(err, ErrStockNotFound):
 		return http.StatusBadRequest, ErrStockNotFound.Error()
 
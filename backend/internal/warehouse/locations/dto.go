@@ -0,0 +1,29 @@
+package locations
+
+import "time"
+
+type Warehouse struct {
+	Id        int32     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Address   *string   `json:"address,omitempty"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ListWarehousesResponse struct {
+	Warehouses []Warehouse `json:"warehouses"`
+}
+
+type CreateWarehouseRequest struct {
+	Code    string  `json:"code" validate:"required,max=20"`
+	Name    string  `json:"name" validate:"required,max=200"`
+	Address *string `json:"address" validate:"omitempty,max=255"`
+}
+
+type UpdateWarehouseRequest struct {
+	Code     *string `json:"code" validate:"omitempty,max=20"`
+	Name     *string `json:"name" validate:"omitempty,max=200"`
+	Address  *string `json:"address" validate:"omitempty,max=255"`
+	IsActive *bool   `json:"isActive"`
+}
@@ -0,0 +1,11 @@
+package locations
+
+import "errors"
+
+var (
+	ErrWarehouseNotFound   = errors.New("warehouse not found")
+	ErrWarehouseInactive   = errors.New("warehouse is inactive")
+	ErrWarehouseIdRequired = errors.New("warehouse id required")
+	ErrInvalidWarehouseId  = errors.New("invalid warehouse id")
+	ErrWarehouseForbidden  = errors.New("warehouse is outside of your site")
+)
@@ -0,0 +1,114 @@
+package locations
+
+import (
+	"errors"
+	"mleczarnia/internal/httputil"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListWarehouses(writer http.ResponseWriter, request *http.Request) {
+	warehouses, err := handler.service.ListWarehouses(request.Context())
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListWarehousesResponse{Warehouses: warehouses})
+}
+
+func (handler *Handler) GetWarehouse(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := handler.extractWarehouseId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	warehouse, err := handler.service.GetWarehouse(request.Context(), warehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, warehouse)
+}
+
+func (handler *Handler) CreateWarehouse(writer http.ResponseWriter, request *http.Request) {
+	var body CreateWarehouseRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	warehouse, err := handler.service.CreateWarehouse(request.Context(), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, warehouse)
+}
+
+func (handler *Handler) UpdateWarehouse(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := handler.extractWarehouseId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body UpdateWarehouseRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if err := handler.service.UpdateWarehouse(request.Context(), warehouseId, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractWarehouseId(request *http.Request) (int32, error) {
+	warehouseIdStr := chi.URLParam(request, "warehouseId")
+	if warehouseIdStr == "" {
+		return 0, ErrWarehouseIdRequired
+	}
+
+	warehouseId, err := strconv.Atoi(warehouseIdStr)
+	if err != nil {
+		return 0, ErrInvalidWarehouseId
+	}
+
+	return int32(warehouseId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrWarehouseIdRequired), errors.Is(err, ErrInvalidWarehouseId):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, ErrWarehouseNotFound):
+		return http.StatusNotFound, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,26 @@
+package locations
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(
+	locationsHandler *Handler,
+	middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Get("/", locationsHandler.ListWarehouses)
+	router.Get("/{warehouseId}", locationsHandler.GetWarehouse)
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN))
+		r.Post("/", locationsHandler.CreateWarehouse)
+		r.Patch("/{warehouseId}", locationsHandler.UpdateWarehouse)
+	})
+
+	return router
+}
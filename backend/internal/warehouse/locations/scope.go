@@ -0,0 +1,40 @@
+package locations
+
+import (
+	"context"
+	app "mleczarnia/internal/http"
+	"net/http"
+	"strconv"
+)
+
+// Resolve returns the warehouse a request should act on. Users limited to a
+// site always get their own site and are refused any other one; everyone else
+// gets the requested warehouse, which may be nil.
+func Resolve(ctx context.Context, requested *int32) (*int32, error) {
+	scope, ok := app.WarehouseScope(ctx)
+	if !ok {
+		return requested, nil
+	}
+
+	if requested != nil && *requested != scope {
+		return nil, ErrWarehouseForbidden
+	}
+
+	return &scope, nil
+}
+
+// ResolveQuery reads the optional warehouseId query parameter and resolves it
+// like Resolve.
+func ResolveQuery(request *http.Request) (*int32, error) {
+	var requested *int32
+	if warehouseIdStr := request.URL.Query().Get("warehouseId"); warehouseIdStr != "" {
+		warehouseId, err := strconv.Atoi(warehouseIdStr)
+		if err != nil {
+			return nil, ErrInvalidWarehouseId
+		}
+		value := int32(warehouseId)
+		requested = &value
+	}
+
+	return Resolve(request.Context(), requested)
+}
@@ -0,0 +1,93 @@
+package locations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type Service struct {
+	query *sqlc.Queries
+}
+
+func NewService(queries *sqlc.Queries) *Service {
+	return &Service{query: queries}
+}
+
+func (service *Service) ListWarehouses(ctx context.Context) ([]Warehouse, error) {
+	rows, err := service.query.ListWarehouses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Warehouse, len(rows))
+	for i, row := range rows {
+		result[i] = mapWarehouse(row)
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetWarehouse(ctx context.Context, warehouseId int32) (*Warehouse, error) {
+	row, err := service.query.GetWarehouseById(ctx, warehouseId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWarehouseNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	warehouse := mapWarehouse(row)
+	return &warehouse, nil
+}
+
+func (service *Service) CreateWarehouse(ctx context.Context, request CreateWarehouseRequest) (*Warehouse, error) {
+	row, err := service.query.CreateWarehouse(ctx, sqlc.CreateWarehouseParams{
+		Code:    request.Code,
+		Name:    request.Name,
+		Address: db.ConvertToText(request.Address),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	warehouse := mapWarehouse(row)
+	return &warehouse, nil
+}
+
+func (service *Service) UpdateWarehouse(ctx context.Context, warehouseId int32, request UpdateWarehouseRequest) error {
+	if _, err := service.query.UpdateWarehouse(ctx, sqlc.UpdateWarehouseParams{
+		ID:       warehouseId,
+		Code:     db.ConvertToText(request.Code),
+		Name:     db.ConvertToText(request.Name),
+		Address:  db.ConvertToText(request.Address),
+		IsActive: db.ConvertToBool(request.IsActive),
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWarehouseNotFound
+		}
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+func mapWarehouse(row sqlc.Warehouse) Warehouse {
+	warehouse := Warehouse{
+		Id:        row.ID,
+		Code:      row.Code,
+		Name:      row.Name,
+		IsActive:  row.IsActive,
+		CreatedAt: row.CreatedAt.Time,
+	}
+
+	if row.Address.Valid {
+		warehouse.Address = &row.Address.String
+	}
+
+	return warehouse
+}
@@ -0,0 +1,127 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+)
+
+const untrackedBatchNumber = "UNTRACKED"
+
+var untrackedBestBefore = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// AdjustLots posts an ADJUSTMENT movement that brings each of the given lots
+// to its counted quantity. No movement is posted when every lot already
+// matches.
+func (service *Service) AdjustLots(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	productId int32,
+	counted []LotQuantity,
+	reason string,
+	empId *int32,
+) (*StockMovement, error) {
+	var changes []lotChange
+	for _, count := range counted {
+		lot, err := qtx.GetStockLotForUpdate(ctx, count.LotId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrLotNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if diff := count.Quantity - lot.Quantity; diff != 0 {
+			changes = append(changes, lotChange{lotId: lot.ID, quantity: diff})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return service.applyMovement(ctx, qtx, movementParams{
+		warehouseId:  warehouseId,
+		productId:    productId,
+		movementType: sqlc.MovementTypeADJUSTMENT,
+		changes:      changes,
+		reason:       &reason,
+		employeeId:   empId,
+	})
+}
+
+// AdjustProduct posts an ADJUSTMENT movement that brings a product counted as
+// a whole to its counted quantity. A shortfall is taken from lots in FEFO
+// order, leaving stock already on pick lists alone, and a surplus goes to the
+// product's untracked lot since nobody recorded which batch it came from.
+func (service *Service) AdjustProduct(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	productId int32,
+	counted int32,
+	reason string,
+	empId *int32,
+) (*StockMovement, error) {
+	var current int32
+	stock, err := qtx.GetStockForUpdate(ctx, sqlc.GetStockForUpdateParams{
+		WarehouseID: warehouseId,
+		ProductID:   productId,
+	})
+	if err == nil {
+		current = stock.Quantity
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	diff := counted - current
+	if diff == 0 {
+		return nil, nil
+	}
+
+	var changes []lotChange
+	if diff < 0 {
+		changes, err = service.allocateFefo(ctx, qtx, warehouseId, productId, -diff, true)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		lot, err := service.untrackedLot(ctx, qtx, warehouseId, productId)
+		if err != nil {
+			return nil, err
+		}
+		changes = []lotChange{{lotId: lot.ID, quantity: diff}}
+	}
+
+	return service.applyMovement(ctx, qtx, movementParams{
+		warehouseId:  warehouseId,
+		productId:    productId,
+		movementType: sqlc.MovementTypeADJUSTMENT,
+		changes:      changes,
+		reason:       &reason,
+		employeeId:   empId,
+	})
+}
+
+func (service *Service) untrackedLot(ctx context.Context, qtx *sqlc.Queries, warehouseId, productId int32) (*sqlc.StockLot, error) {
+	lot, err := qtx.GetStockLotByBatchForUpdate(ctx, sqlc.GetStockLotByBatchForUpdateParams{
+		WarehouseID: warehouseId,
+		ProductID:   productId,
+		BatchNumber: untrackedBatchNumber,
+	})
+	if err == nil {
+		return &lot, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return service.receiveLot(ctx, qtx, warehouseId, productId, untrackedBatchNumber, time.Now(), untrackedBestBefore)
+}
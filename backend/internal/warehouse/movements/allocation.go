@@ -0,0 +1,107 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+)
+
+// allocateFefo picks lots for an outgoing movement in best-before order,
+// splitting the quantity across as many lots as needed. Expired lots are
+// skipped unless allowExpired is set, and quantities already on the pick list
+// of an order in preparation are left for that order.
+func (service *Service) allocateFefo(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	productId int32,
+	quantity int32,
+	allowExpired bool,
+) ([]lotChange, error) {
+	lots, err := qtx.ListStockLotsForAllocation(ctx, sqlc.ListStockLotsForAllocationParams{
+		WarehouseID: warehouseId,
+		ProductID:   productId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	picked, err := qtx.ListPickedQuantitiesByProduct(ctx, productId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	pickedByLot := make(map[int32]int32, len(picked))
+	for _, row := range picked {
+		pickedByLot[row.LotID] = row.Quantity
+	}
+
+	today := truncateToDate(time.Now())
+	remaining := quantity
+	var expiredSkipped int32
+	var changes []lotChange
+
+	for _, lot := range lots {
+		if remaining == 0 {
+			break
+		}
+
+		free := lot.Quantity - pickedByLot[lot.ID]
+		if free <= 0 {
+			continue
+		}
+
+		if isExpired(lot, today) && !allowExpired {
+			expiredSkipped += free
+			continue
+		}
+
+		take := min(remaining, free)
+		changes = append(changes, lotChange{lotId: lot.ID, quantity: -take})
+		remaining -= take
+	}
+
+	if remaining > 0 {
+		shortage := &ShortageError{Shortages: []Shortage{{
+			ProductId: productId,
+			Requested: quantity,
+			Available: quantity - remaining,
+		}}}
+		if expiredSkipped >= remaining {
+			return nil, fmt.Errorf("%w: %w", ErrExpiredLot, shortage)
+		}
+		return nil, shortage
+	}
+
+	return changes, nil
+}
+
+func (service *Service) checkLotsNotExpired(ctx context.Context, qtx *sqlc.Queries, changes []lotChange) error {
+	today := truncateToDate(time.Now())
+
+	for _, change := range changes {
+		lot, err := qtx.GetStockLotForUpdate(ctx, change.lotId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrLotNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if isExpired(lot, today) {
+			return ErrExpiredLot
+		}
+	}
+
+	return nil
+}
+
+func isExpired(lot sqlc.StockLot, today time.Time) bool {
+	return lot.BestBefore.Time.Before(today)
+}
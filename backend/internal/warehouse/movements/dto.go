@@ -6,43 +6,111 @@ import (
 )
 
 type StockMovement struct {
-	Id             int32             `json:"id"`
-	ProductId      int32             `json:"productId"`
-	QuantityChange int32             `json:"quantityChange"`
-	MovementType   sqlc.MovementType `json:"movementType"`
-	RelatedOrderId *int32            `json:"relatedOrderId,omitempty"`
-	Reason         *string           `json:"reason,omitempty"`
-	CreatedAt      time.Time         `json:"createdAt"`
-	EmployeeId     *int32            `json:"employeeId,omitempty"`
+	Id                  int32             `json:"id"`
+	WarehouseId         int32             `json:"warehouseId"`
+	ProductId           int32             `json:"productId"`
+	QuantityChange      int32             `json:"quantityChange"`
+	MovementType        sqlc.MovementType `json:"movementType"`
+	RelatedOrderId      *int32            `json:"relatedOrderId,omitempty"`
+	RelatedMovementId   *int32            `json:"relatedMovementId,omitempty"`
+	DocumentId          *int32            `json:"documentId,omitempty"`
+	PurchaseOrderLineId *int32            `json:"purchaseOrderLineId,omitempty"`
+	Reason              *string           `json:"reason,omitempty"`
+	CreatedAt           time.Time         `json:"createdAt"`
+	EmployeeId          *int32            `json:"employeeId,omitempty"`
+	OverrideReason      *string           `json:"overrideReason,omitempty"`
+	Lots                []MovementLot     `json:"lots,omitempty"`
+}
+
+type MovementLot struct {
+	LotId          int32     `json:"lotId"`
+	BatchNumber    string    `json:"batchNumber"`
+	BestBefore     time.Time `json:"bestBefore"`
+	QuantityChange int32     `json:"quantityChange"`
 }
 
 type ListStockMovementResponse struct {
 	StockMovements []StockMovement `json:"stockMovements"`
 }
 
+type StockLine struct {
+	ProductId int32
+	Quantity  int32
+}
+
+type Shortage struct {
+	ProductId int32 `json:"productId"`
+	Requested int32 `json:"requested"`
+	Available int32 `json:"available"`
+}
+
+type PickListItem struct {
+	ProductId   int32     `json:"productId"`
+	ProductName string    `json:"productName"`
+	LotId       int32     `json:"lotId"`
+	BatchNumber string    `json:"batchNumber"`
+	BestBefore  time.Time `json:"bestBefore"`
+	Quantity    int32     `json:"quantity"`
+}
+
+type Receipt struct {
+	DocumentId          *int32
+	PurchaseOrderLineId *int32
+}
+
+type LotQuantity struct {
+	LotId    int32 `json:"lotId" validate:"required"`
+	Quantity int32 `json:"quantity" validate:"required,gt=0"`
+}
+
 type InboundRequest struct {
-	ProductId int32  `json:"productId" validate:"required"`
-	Quantity  int32  `json:"quantity" validate:"required,gt=0"`
-	Reason    string `json:"reason"`
+	WarehouseId    *int32    `json:"warehouseId"`
+	ProductId      int32     `json:"productId" validate:"required"`
+	Quantity       int32     `json:"quantity" validate:"required,gt=0"`
+	BatchNumber    string    `json:"batchNumber" validate:"required,max=100"`
+	ProductionDate time.Time `json:"productionDate" validate:"required"`
+	BestBefore     time.Time `json:"bestBefore" validate:"required,gtefield=ProductionDate"`
+	Reason         string    `json:"reason"`
 }
 
 type DispatchRequest struct {
-	ProductId int32 `json:"productId" validate:"required"`
-	Quantity  int32 `json:"quantity" validate:"required,gt=0"`
+	WarehouseId *int32        `json:"warehouseId"`
+	ProductId   int32         `json:"productId" validate:"required"`
+	Quantity    int32         `json:"quantity" validate:"required,gt=0"`
+	Lots        []LotQuantity `json:"lots" validate:"omitempty,dive"`
 	//TODO: Should it be connected to order?
 	//OrderId   int32  `json:"orderId" validate:"required,gt=0"`
-	Reason string `json:"reason"`
+	Reason         string `json:"reason"`
+	OverrideReason string `json:"overrideReason" validate:"max=255"`
 }
 
 type ReturnRequest struct {
-	ProductId int32  `json:"productId" validate:"required"`
-	Quantity  int32  `json:"quantity" validate:"required,gt=0"`
-	OrderId   int32  `json:"orderId" validate:"required"`
-	Reason    string `json:"reason"`
+	WarehouseId *int32 `json:"warehouseId"`
+	ProductId   int32  `json:"productId" validate:"required"`
+	Quantity    int32  `json:"quantity" validate:"required,gt=0"`
+	LotId       int32  `json:"lotId" validate:"required"`
+	OrderId     int32  `json:"orderId" validate:"required"`
+	Reason      string `json:"reason"`
 }
 
 type LossRequest struct {
-	ProductId int32  `json:"productId" validate:"required"`
-	Quantity  int32  `json:"quantity" validate:"required,gt=0"`
-	Reason    string `json:"reason"`
+	WarehouseId *int32        `json:"warehouseId"`
+	ProductId   int32         `json:"productId" validate:"required"`
+	Quantity    int32         `json:"quantity" validate:"required,gt=0"`
+	Lots        []LotQuantity `json:"lots" validate:"required,min=1,dive"`
+	Reason      string        `json:"reason"`
+}
+
+type TransferRequest struct {
+	ProductId       int32         `json:"productId" validate:"required"`
+	Quantity        int32         `json:"quantity" validate:"required,gt=0"`
+	FromWarehouseId int32         `json:"fromWarehouseId" validate:"required"`
+	ToWarehouseId   int32         `json:"toWarehouseId" validate:"required,nefield=FromWarehouseId"`
+	Lots            []LotQuantity `json:"lots" validate:"omitempty,dive"`
+	Reason          string        `json:"reason"`
+}
+
+type TransferResponse struct {
+	Outgoing StockMovement `json:"outgoing"`
+	Incoming StockMovement `json:"incoming"`
 }
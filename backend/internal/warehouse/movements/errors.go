@@ -3,6 +3,27 @@ package movements
 import "errors"
 
 var (
-	ErrInsufficientStock = errors.New("insufficient stock")
-	ErrMovementNotFound  = errors.New("movement not found")
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrMovementNotFound     = errors.New("movement not found")
+	ErrLotNotFound          = errors.New("lot not found")
+	ErrLotProductMismatch   = errors.New("lot does not belong to product")
+	ErrLotWarehouseMismatch = errors.New("lot is stored in a different warehouse")
+	ErrLotQuantityMismatch  = errors.New("lot quantities do not add up to movement quantity")
+	ErrLotDatesMismatch     = errors.New("batch already exists with different dates")
+	ErrExpiredLot           = errors.New("lot is past its best-before date")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrProductNotOnOrder    = errors.New("product is not on the order")
+	ErrReturnExceedsShipped = errors.New("returned quantity exceeds the quantity shipped")
 )
+
+type ShortageError struct {
+	Shortages []Shortage
+}
+
+func (err *ShortageError) Error() string {
+	return ErrInsufficientStock.Error()
+}
+
+func (err *ShortageError) Unwrap() error {
+	return ErrInsufficientStock
+}
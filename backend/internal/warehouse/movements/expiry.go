@@ -0,0 +1,86 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+)
+
+const ExpiredReason = "expired"
+
+// WriteOffExpiredLots posts a LOSS movement for every lot that is past its
+// best-before date and still has stock on hand. Lots on the pick list of an
+// order in preparation are left until the order ships or is cancelled. A lot
+// that fails is logged and does not stop the others.
+func (service *Service) WriteOffExpiredLots(ctx context.Context) error {
+	lots, err := service.query.ListExpiredStockLots(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	reason := ExpiredReason
+	var errs []error
+	for _, lot := range lots {
+		fields := logrus.Fields{
+			"lotId":       lot.ID,
+			"warehouseId": lot.WarehouseID,
+			"productId":   lot.ProductID,
+			"batchNumber": lot.BatchNumber,
+			"quantity":    lot.Quantity,
+		}
+
+		var picked bool
+		err := db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+			qtx := service.query.WithTx(tx)
+
+			current, err := qtx.GetStockLotForUpdate(ctx, lot.ID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrLotNotFound
+				}
+				return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+
+			if current.Quantity == 0 {
+				return nil
+			}
+
+			picked, err = qtx.IsLotOnOpenPickList(ctx, current.ID)
+			if err != nil {
+				return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+			if picked {
+				return nil
+			}
+
+			_, err = service.applyMovement(ctx, qtx, movementParams{
+				warehouseId:  current.WarehouseID,
+				productId:    current.ProductID,
+				movementType: sqlc.MovementTypeLOSS,
+				changes:      []lotChange{{lotId: current.ID, quantity: -current.Quantity}},
+				reason:       &reason,
+			})
+			return err
+		})
+		if err != nil {
+			logrus.WithError(err).WithFields(fields).Warn("Failed to write off expired lot")
+			errs = append(errs, fmt.Errorf("lot %d: %w", lot.ID, err))
+			continue
+		}
+
+		if picked {
+			logrus.WithFields(fields).Info("Expired lot is on an open pick list, not written off")
+			continue
+		}
+
+		logrus.WithFields(fields).Info("Expired lot written off")
+	}
+
+	return errors.Join(errs...)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mleczarnia/internal/httputil"
 	"mleczarnia/internal/warehouse"
+	"mleczarnia/internal/warehouse/locations"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
@@ -18,7 +19,13 @@ func NewHandler(s *Service) *Handler {
 }
 
 func (handler *Handler) ListMovements(writer http.ResponseWriter, request *http.Request) {
-	stockMovements, err := handler.service.ListMovements(request.Context())
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	stockMovements, err := handler.service.ListMovements(request.Context(), warehouseId)
 	if err != nil {
 		handler.handleServiceError(writer, err)
 		return
@@ -34,6 +41,13 @@ func (handler *Handler) Inbound(writer http.ResponseWriter, request *http.Reques
 		return
 	}
 
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
 	movement, err := handler.service.Inbound(request.Context(), body, nil)
 	if err != nil {
 		handler.handleServiceError(writer, err)
@@ -50,6 +64,13 @@ func (handler *Handler) Dispatch(writer http.ResponseWriter, request *http.Reque
 		return
 	}
 
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
 	movement, err := handler.service.Dispatch(request.Context(), body, nil)
 	if err != nil {
 		handler.handleServiceError(writer, err)
@@ -66,6 +87,13 @@ func (handler *Handler) Return(writer http.ResponseWriter, request *http.Request
 		return
 	}
 
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
 	movement, err := handler.service.Return(request.Context(), body, nil)
 	if err != nil {
 		handler.handleServiceError(writer, err)
@@ -82,6 +110,13 @@ func (handler *Handler) Loss(writer http.ResponseWriter, request *http.Request)
 		return
 	}
 
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
 	movement, err := handler.service.Loss(request.Context(), body, nil)
 	if err != nil {
 		handler.handleServiceError(writer, err)
@@ -90,6 +125,28 @@ func (handler *Handler) Loss(writer http.ResponseWriter, request *http.Request)
 
 	httputil.WriteJSON(writer, http.StatusCreated, movement)
 }
+
+func (handler *Handler) Transfer(writer http.ResponseWriter, request *http.Request) {
+	var body TransferRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	if _, err := locations.Resolve(request.Context(), &body.FromWarehouseId); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	transfer, err := handler.service.Transfer(request.Context(), body, nil)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, transfer)
+}
+
 func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
 	statusCode, message := handler.mapErrorToResponse(err)
 	logrus.WithError(err).Info()
@@ -98,9 +155,16 @@ func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error
 
 func (handler *Handler) mapErrorToResponse(err error) (int, string) {
 	switch {
-	case errors.Is(err, warehouse.ErrStockNotFound), errors.Is(err, ErrMovementNotFound):
+	case errors.Is(err, warehouse.ErrStockNotFound), errors.Is(err, ErrMovementNotFound), errors.Is(err, ErrLotNotFound),
+		errors.Is(err, locations.ErrWarehouseNotFound), errors.Is(err, ErrOrderNotFound):
 		return http.StatusNotFound, err.Error()
-	case errors.Is(err, ErrInsufficientStock):
+	case errors.Is(err, ErrLotProductMismatch), errors.Is(err, ErrLotQuantityMismatch), errors.Is(err, ErrLotWarehouseMismatch),
+		errors.Is(err, locations.ErrInvalidWarehouseId), errors.Is(err, ErrProductNotOnOrder):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, locations.ErrWarehouseForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, ErrInsufficientStock), errors.Is(err, ErrLotDatesMismatch), errors.Is(err, ErrExpiredLot),
+		errors.Is(err, locations.ErrWarehouseInactive), errors.Is(err, ErrReturnExceedsShipped):
 		return http.StatusConflict, err.Error()
 
 	default:
@@ -0,0 +1,180 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+)
+
+// BuildPickList allocates lots for an order in FEFO order and stores them as
+// the order's pick list, replacing any previous one. Expired lots are never
+// picked. When any product is short, a *ShortageError is returned.
+func (service *Service) BuildPickList(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	orderId int32,
+	lines []StockLine,
+) ([]PickListItem, error) {
+	if err := qtx.DeletePickListItemsByOrder(ctx, orderId); err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	var shortages []Shortage
+
+	for _, line := range mergeStockLines(lines) {
+		changes, err := service.allocateFefo(ctx, qtx, warehouseId, line.ProductId, line.Quantity, false)
+		if err != nil {
+			var shortageErr *ShortageError
+			if errors.As(err, &shortageErr) {
+				shortages = append(shortages, shortageErr.Shortages...)
+				continue
+			}
+			return nil, err
+		}
+
+		for _, change := range changes {
+			if _, err := qtx.CreatePickListItem(ctx, sqlc.CreatePickListItemParams{
+				OrderID:   orderId,
+				ProductID: line.ProductId,
+				LotID:     change.lotId,
+				Quantity:  -change.quantity,
+			}); err != nil {
+				return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+		}
+	}
+
+	if len(shortages) > 0 {
+		return nil, &ShortageError{Shortages: shortages}
+	}
+
+	return service.PickList(ctx, qtx, orderId)
+}
+
+func (service *Service) PickList(ctx context.Context, qtx *sqlc.Queries, orderId int32) ([]PickListItem, error) {
+	rows, err := qtx.ListPickListItemsByOrder(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	items := make([]PickListItem, len(rows))
+	for i, row := range rows {
+		items[i] = PickListItem{
+			ProductId:   row.ProductID,
+			ProductName: row.ProductName,
+			LotId:       row.LotID,
+			BatchNumber: row.BatchNumber,
+			BestBefore:  row.BestBefore.Time,
+			Quantity:    row.Quantity,
+		}
+	}
+
+	return items, nil
+}
+
+// DispatchOrder posts one DISPATCH movement per product of an order, taking
+// the lots from its pick list and consuming the reservation. Orders without a
+// pick list fall back to FEFO allocation. Nothing is dispatched unless every
+// line can be.
+func (service *Service) DispatchOrder(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	orderId int32,
+	lines []StockLine,
+	empId *int32,
+) ([]StockMovement, error) {
+	items, err := qtx.ListPickListItemsByOrder(ctx, orderId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if len(items) == 0 {
+		return service.DispatchReserved(ctx, qtx, warehouseId, orderId, lines, empId)
+	}
+
+	var productIds []int32
+	changesByProduct := make(map[int32][]lotChange)
+	for _, item := range items {
+		if _, ok := changesByProduct[item.ProductID]; !ok {
+			productIds = append(productIds, item.ProductID)
+		}
+		changesByProduct[item.ProductID] = append(changesByProduct[item.ProductID], lotChange{
+			lotId:    item.LotID,
+			quantity: -item.Quantity,
+		})
+	}
+
+	var shortages []Shortage
+	for _, productId := range productIds {
+		shortage, err := service.checkPickedLots(ctx, qtx, productId, changesByProduct[productId])
+		if err != nil {
+			return nil, err
+		}
+		if shortage != nil {
+			shortages = append(shortages, *shortage)
+		}
+	}
+
+	if len(shortages) > 0 {
+		return nil, &ShortageError{Shortages: shortages}
+	}
+
+	result := make([]StockMovement, 0, len(productIds))
+	for _, productId := range productIds {
+		changes := changesByProduct[productId]
+
+		var picked int32
+		for _, change := range changes {
+			picked -= change.quantity
+		}
+
+		movement, err := service.applyMovement(ctx, qtx, movementParams{
+			warehouseId:     warehouseId,
+			productId:       productId,
+			movementType:    sqlc.MovementTypeDISPATCH,
+			changes:         changes,
+			orderId:         &orderId,
+			employeeId:      empId,
+			releaseReserved: picked,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *movement)
+	}
+
+	return result, nil
+}
+
+// checkPickedLots locks the picked lots of a product and reports a shortage
+// when any of them no longer holds the picked quantity, e.g. after a loss.
+func (service *Service) checkPickedLots(ctx context.Context, qtx *sqlc.Queries, productId int32, changes []lotChange) (*Shortage, error) {
+	var requested, available int32
+
+	for _, change := range changes {
+		lot, err := qtx.GetStockLotForUpdate(ctx, change.lotId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrLotNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		requested -= change.quantity
+		available += min(lot.Quantity, -change.quantity)
+	}
+
+	if available < requested {
+		return &Shortage{ProductId: productId, Requested: requested, Available: available}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,148 @@
+package movements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+)
+
+// ReserveStock reserves the given lines within the caller's transaction. When
+// any product is short, nothing is reserved and a *ShortageError listing every
+// short line is returned.
+func (service *Service) ReserveStock(ctx context.Context, qtx *sqlc.Queries, warehouseId int32, lines []StockLine) error {
+	var shortages []Shortage
+
+	for _, line := range mergeStockLines(lines) {
+		stock, err := qtx.GetStockForUpdate(ctx, sqlc.GetStockForUpdateParams{
+			WarehouseID: warehouseId,
+			ProductID:   line.ProductId,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				shortages = append(shortages, Shortage{ProductId: line.ProductId, Requested: line.Quantity})
+				continue
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		available := max(stock.Quantity-stock.ReservedQuantity, 0)
+		if available < line.Quantity {
+			shortages = append(shortages, Shortage{
+				ProductId: line.ProductId,
+				Requested: line.Quantity,
+				Available: available,
+			})
+			continue
+		}
+
+		if err := qtx.UpdateStockReservedQuantity(ctx, sqlc.UpdateStockReservedQuantityParams{
+			ID:               stock.ID,
+			ReservedQuantity: stock.ReservedQuantity + line.Quantity,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	if len(shortages) > 0 {
+		return &ShortageError{Shortages: shortages}
+	}
+
+	return nil
+}
+
+func (service *Service) ReleaseReservation(ctx context.Context, qtx *sqlc.Queries, warehouseId int32, lines []StockLine) error {
+	for _, line := range mergeStockLines(lines) {
+		stock, err := qtx.GetStockForUpdate(ctx, sqlc.GetStockForUpdateParams{
+			WarehouseID: warehouseId,
+			ProductID:   line.ProductId,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if err := qtx.UpdateStockReservedQuantity(ctx, sqlc.UpdateStockReservedQuantityParams{
+			ID:               stock.ID,
+			ReservedQuantity: max(stock.ReservedQuantity-line.Quantity, 0),
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return nil
+}
+
+// DispatchReserved turns an order's reservation into DISPATCH movements linked
+// to the order, picking lots in FEFO order.
+func (service *Service) DispatchReserved(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	orderId int32,
+	lines []StockLine,
+	empId *int32,
+) ([]StockMovement, error) {
+	var result []StockMovement
+	var shortages []Shortage
+
+	for _, line := range mergeStockLines(lines) {
+		changes, err := service.allocateFefo(ctx, qtx, warehouseId, line.ProductId, line.Quantity, false)
+		if err != nil {
+			var shortageErr *ShortageError
+			if errors.As(err, &shortageErr) {
+				shortages = append(shortages, shortageErr.Shortages...)
+				continue
+			}
+			return nil, err
+		}
+
+		movement, err := service.applyMovement(ctx, qtx, movementParams{
+			warehouseId:     warehouseId,
+			productId:       line.ProductId,
+			movementType:    sqlc.MovementTypeDISPATCH,
+			changes:         changes,
+			orderId:         &orderId,
+			employeeId:      empId,
+			releaseReserved: line.Quantity,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *movement)
+	}
+
+	if len(shortages) > 0 {
+		return nil, &ShortageError{Shortages: shortages}
+	}
+
+	return result, nil
+}
+
+// mergeStockLines sums lines per product and sorts them by product id, so
+// stock rows are always locked in the same order.
+func mergeStockLines(lines []StockLine) []StockLine {
+	totals := make(map[int32]int32, len(lines))
+	for _, line := range lines {
+		totals[line.ProductId] += line.Quantity
+	}
+
+	merged := make([]StockLine, 0, len(totals))
+	for productId, quantity := range totals {
+		merged = append(merged, StockLine{ProductId: productId, Quantity: quantity})
+	}
+
+	slices.SortFunc(merged, func(a, b StockLine) int {
+		return int(a.ProductId - b.ProductId)
+	})
+
+	return merged
+}
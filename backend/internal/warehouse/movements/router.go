@@ -21,6 +21,7 @@ func Router(
 	router.Post("/dispatch", movementsHandler.Dispatch)
 	router.Post("/return", movementsHandler.Return)
 	router.Post("/loss", movementsHandler.Loss)
+	router.Post("/transfer", movementsHandler.Transfer)
 
 	return router
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,19 +12,43 @@ import (
 
 	"mleczarnia/internal/db"
 	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/warehouse/locations"
 )
 
 type Service struct {
-	query *sqlc.Queries
-	pool  *pgxpool.Pool
+	query              *sqlc.Queries
+	pool               *pgxpool.Pool
+	defaultWarehouseId int32
 }
 
-func NewService(queries *sqlc.Queries, pool *pgxpool.Pool) *Service {
-	return &Service{query: queries, pool: pool}
+type lotChange struct {
+	lotId    int32
+	quantity int32
 }
 
-func (service *Service) ListMovements(ctx context.Context) ([]StockMovement, error) {
-	rows, err := service.query.ListStockMovements(ctx)
+type movementParams struct {
+	warehouseId         int32
+	productId           int32
+	movementType        sqlc.MovementType
+	changes             []lotChange
+	orderId             *int32
+	relatedMovementId   *int32
+	documentId          *int32
+	purchaseOrderLineId *int32
+	reason              *string
+	overrideReason      *string
+	employeeId          *int32
+	// releaseReserved is the part of the movement that was reserved by an
+	// order and is consumed together with it.
+	releaseReserved int32
+}
+
+func NewService(queries *sqlc.Queries, pool *pgxpool.Pool, defaultWarehouseId int32) *Service {
+	return &Service{query: queries, pool: pool, defaultWarehouseId: defaultWarehouseId}
+}
+
+func (service *Service) ListMovements(ctx context.Context, warehouseId *int32) ([]StockMovement, error) {
+	rows, err := service.query.ListStockMovements(ctx, db.ConvertToInt4(warehouseId))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrMovementNotFound
@@ -33,136 +58,485 @@ func (service *Service) ListMovements(ctx context.Context) ([]StockMovement, err
 
 	result := make([]StockMovement, len(rows))
 	for i, row := range rows {
-		stockMovement := StockMovement{
-			Id:             row.ID,
-			ProductId:      row.ProductID,
-			QuantityChange: row.QuantityChange,
-			MovementType:   row.MovementType,
+		result[i] = mapMovement(row)
+	}
+
+	return result, nil
+}
+
+func (service *Service) Inbound(ctx context.Context, req InboundRequest, empId *int32) (*StockMovement, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StockMovement, error) {
+		qtx := service.query.WithTx(tx)
+
+		warehouseId, err := service.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+		if err != nil {
+			return nil, err
+		}
+
+		return service.ReceiveStock(ctx, qtx, warehouseId, req, Receipt{}, empId)
+	})
+}
+
+// ReceiveStock books one delivered batch into a warehouse inside the caller's
+// transaction. The receipt links the INBOUND movement to the paperwork the
+// goods came with.
+func (service *Service) ReceiveStock(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	req InboundRequest,
+	receipt Receipt,
+	empId *int32,
+) (*StockMovement, error) {
+	lot, err := service.receiveLot(ctx, qtx, warehouseId, req.ProductId, req.BatchNumber, req.ProductionDate, req.BestBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.applyMovement(ctx, qtx, movementParams{
+		warehouseId:         warehouseId,
+		productId:           req.ProductId,
+		movementType:        sqlc.MovementTypeINBOUND,
+		changes:             []lotChange{{lotId: lot.ID, quantity: req.Quantity}},
+		documentId:          receipt.DocumentId,
+		purchaseOrderLineId: receipt.PurchaseOrderLineId,
+		reason:              &req.Reason,
+		employeeId:          empId,
+	})
+}
+
+func (service *Service) Dispatch(ctx context.Context, req DispatchRequest, empId *int32) (*StockMovement, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StockMovement, error) {
+		qtx := service.query.WithTx(tx)
+		allowExpired := req.OverrideReason != ""
+
+		warehouseId, err := service.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+		if err != nil {
+			return nil, err
 		}
 
-		if row.RelatedOrderID.Valid {
-			stockMovement.RelatedOrderId = &row.RelatedOrderID.Int32
+		var changes []lotChange
+		if len(req.Lots) > 0 {
+			changes, err = outgoingLotChanges(req.Quantity, req.Lots)
+			if err == nil && !allowExpired {
+				err = service.checkLotsNotExpired(ctx, qtx, changes)
+			}
+		} else {
+			changes, err = service.allocateFefo(ctx, qtx, warehouseId, req.ProductId, req.Quantity, allowExpired)
+		}
+		if err != nil {
+			return nil, err
 		}
 
-		if row.Reason.Valid {
-			stockMovement.Reason = &row.Reason.String
+		params := movementParams{
+			warehouseId:  warehouseId,
+			productId:    req.ProductId,
+			movementType: sqlc.MovementTypeDISPATCH,
+			changes:      changes,
+			reason:       &req.Reason,
+			employeeId:   empId,
+		}
+		if allowExpired {
+			params.overrideReason = &req.OverrideReason
 		}
 
-		if row.CreatedAt.Valid {
-			stockMovement.CreatedAt = row.CreatedAt.Time
+		return service.applyMovement(ctx, qtx, params)
+	})
+}
+
+// Return books goods a customer sent back against the order they were
+// dispatched for. Together with earlier returns it may not exceed what the
+// order had dispatched of the product.
+func (service *Service) Return(ctx context.Context, req ReturnRequest, empId *int32) (*StockMovement, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StockMovement, error) {
+		qtx := service.query.WithTx(tx)
+
+		warehouseId, err := service.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+		if err != nil {
+			return nil, err
+		}
+
+		returnable, err := qtx.GetOrderReturnableQuantity(ctx, sqlc.GetOrderReturnableQuantityParams{
+			ID:        req.OrderId,
+			ProductID: req.ProductId,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
 		}
 
-		if row.EmployeeID.Valid {
-			stockMovement.EmployeeId = &row.EmployeeID.Int32
+		if returnable.Ordered == 0 {
+			return nil, ErrProductNotOnOrder
 		}
+		if returnable.Returned+req.Quantity > returnable.Dispatched {
+			return nil, ErrReturnExceedsShipped
+		}
+
+		return service.applyMovement(ctx, qtx, movementParams{
+			warehouseId:  warehouseId,
+			productId:    req.ProductId,
+			movementType: sqlc.MovementTypeRETURN,
+			changes:      []lotChange{{lotId: req.LotId, quantity: req.Quantity}},
+			orderId:      &req.OrderId,
+			reason:       &req.Reason,
+			employeeId:   empId,
+		})
+	})
+}
 
-		result[i] = stockMovement
+func (service *Service) Loss(ctx context.Context, req LossRequest, empId *int32) (*StockMovement, error) {
+	changes, err := outgoingLotChanges(req.Quantity, req.Lots)
+	if err != nil {
+		return nil, err
 	}
 
-	return result, nil
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*StockMovement, error) {
+		qtx := service.query.WithTx(tx)
+
+		warehouseId, err := service.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+		if err != nil {
+			return nil, err
+		}
+
+		return service.applyMovement(ctx, qtx, movementParams{
+			warehouseId:  warehouseId,
+			productId:    req.ProductId,
+			movementType: sqlc.MovementTypeLOSS,
+			changes:      changes,
+			reason:       &req.Reason,
+			employeeId:   empId,
+		})
+	})
 }
 
-func (service *Service) Inbound(ctx context.Context, req InboundRequest, empId *int32) (*sqlc.StockMovement, error) {
-	return service.applyMovement(
-		ctx,
-		req.ProductId,
-		req.Quantity,
-		sqlc.MovementTypeINBOUND,
-		nil,
-		&req.Reason,
-		empId,
-	)
-}
-
-func (service *Service) Dispatch(ctx context.Context, req DispatchRequest, empId *int32) (*sqlc.StockMovement, error) {
-	return service.applyMovement(
-		ctx,
-		req.ProductId,
-		-req.Quantity,
-		sqlc.MovementTypeDISPATCH,
-		nil,
-		&req.Reason,
-		empId,
-	)
-}
-
-func (service *Service) Return(ctx context.Context, req ReturnRequest, empId *int32) (*sqlc.StockMovement, error) {
-	return service.applyMovement(
-		ctx,
-		req.ProductId,
-		req.Quantity,
-		sqlc.MovementTypeRETURN,
-		&req.OrderId,
-		&req.Reason,
-		empId,
-	)
-}
-
-func (service *Service) Loss(ctx context.Context, req LossRequest, empId *int32) (*sqlc.StockMovement, error) {
-	return service.applyMovement(
-		ctx,
-		req.ProductId,
-		-req.Quantity,
-		sqlc.MovementTypeLOSS,
-		nil,
-		&req.Reason,
-		empId,
-	)
-}
-
-func (service *Service) applyMovement(
-	ctx context.Context,
-	productId int32,
-	qtyChange int32,
-	mType sqlc.MovementType,
-	orderId *int32,
-	reason *string,
-	employeeId *int32,
-) (*sqlc.StockMovement, error) {
-	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*sqlc.StockMovement, error) {
+// Transfer moves stock from one warehouse to another in a single transaction.
+// The source gets a negative TRANSFER movement and the destination a positive
+// one pointing back to it; lots keep their batch numbers and dates.
+func (service *Service) Transfer(ctx context.Context, req TransferRequest, empId *int32) (*TransferResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*TransferResponse, error) {
 		qtx := service.query.WithTx(tx)
 
-		stock, err := qtx.GetStockForUpdate(ctx, productId)
+		fromWarehouseId, err := service.ResolveWarehouse(ctx, qtx, &req.FromWarehouseId)
 		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				stock, err = qtx.CreateStock(ctx, sqlc.CreateStockParams{
-					ProductID:   productId,
-					Quantity:    0,
-					MinQuantity: 10,
-				})
-				if err != nil {
-					logrus.WithError(err).Error("Could not create stock")
-					return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
-				}
-			}
+			return nil, err
 		}
 
-		newQty := stock.Quantity + qtyChange
-		if newQty < 0 {
-			return nil, ErrInsufficientStock
+		toWarehouseId, err := service.ResolveWarehouse(ctx, qtx, &req.ToWarehouseId)
+		if err != nil {
+			return nil, err
 		}
 
-		if err := qtx.UpdateStockQuantity(ctx, sqlc.UpdateStockQuantityParams{
-			ProductID: productId,
-			Quantity:  newQty,
-		}); err != nil {
+		var changes []lotChange
+		if len(req.Lots) > 0 {
+			changes, err = outgoingLotChanges(req.Quantity, req.Lots)
+		} else {
+			changes, err = service.allocateFefo(ctx, qtx, fromWarehouseId, req.ProductId, req.Quantity, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		outgoing, err := service.applyMovement(ctx, qtx, movementParams{
+			warehouseId:  fromWarehouseId,
+			productId:    req.ProductId,
+			movementType: sqlc.MovementTypeTRANSFER,
+			changes:      changes,
+			reason:       &req.Reason,
+			employeeId:   empId,
+		})
+		if err != nil {
 			return nil, err
 		}
 
-		params := sqlc.CreateStockMovementParams{
-			ProductID:      productId,
-			QuantityChange: qtyChange,
-			MovementType:   mType,
-			RelatedOrderID: db.ConvertToInt4(orderId),
-			Reason:         db.ConvertToText(reason),
-			EmployeeID:     db.ConvertToInt4(employeeId),
+		incomingChanges := make([]lotChange, len(changes))
+		for i, change := range changes {
+			sourceLot, err := qtx.GetStockLotForUpdate(ctx, change.lotId)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+
+			lot, err := service.receiveLot(
+				ctx,
+				qtx,
+				toWarehouseId,
+				req.ProductId,
+				sourceLot.BatchNumber,
+				sourceLot.ProductionDate.Time,
+				sourceLot.BestBefore.Time,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			incomingChanges[i] = lotChange{lotId: lot.ID, quantity: -change.quantity}
 		}
 
-		movement, err := qtx.CreateStockMovement(ctx, params)
+		incoming, err := service.applyMovement(ctx, qtx, movementParams{
+			warehouseId:       toWarehouseId,
+			productId:         req.ProductId,
+			movementType:      sqlc.MovementTypeTRANSFER,
+			changes:           incomingChanges,
+			relatedMovementId: &outgoing.Id,
+			reason:            &req.Reason,
+			employeeId:        empId,
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		return &movement, nil
+		return &TransferResponse{Outgoing: *outgoing, Incoming: *incoming}, nil
 	})
 }
+
+// ResolveWarehouse returns the requested warehouse, or the default one when
+// none is given, and checks that it can take stock movements.
+func (service *Service) ResolveWarehouse(ctx context.Context, qtx *sqlc.Queries, warehouseId *int32) (int32, error) {
+	id := service.defaultWarehouseId
+	if warehouseId != nil {
+		id = *warehouseId
+	}
+
+	warehouse, err := qtx.GetWarehouseById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, locations.ErrWarehouseNotFound
+		}
+		return 0, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !warehouse.IsActive {
+		return 0, locations.ErrWarehouseInactive
+	}
+
+	return warehouse.ID, nil
+}
+
+func (service *Service) receiveLot(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId int32,
+	productId int32,
+	batchNumber string,
+	productionDate time.Time,
+	bestBefore time.Time,
+) (*sqlc.StockLot, error) {
+	productionDate = truncateToDate(productionDate)
+	bestBefore = truncateToDate(bestBefore)
+
+	lot, err := qtx.GetStockLotByBatchForUpdate(ctx, sqlc.GetStockLotByBatchForUpdateParams{
+		WarehouseID: warehouseId,
+		ProductID:   productId,
+		BatchNumber: batchNumber,
+	})
+	if err == nil {
+		if !lot.ProductionDate.Time.Equal(productionDate) || !lot.BestBefore.Time.Equal(bestBefore) {
+			return nil, ErrLotDatesMismatch
+		}
+		return &lot, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	lot, err = qtx.CreateStockLot(ctx, sqlc.CreateStockLotParams{
+		WarehouseID:    warehouseId,
+		ProductID:      productId,
+		BatchNumber:    batchNumber,
+		ProductionDate: db.ConvertToDate(&productionDate),
+		BestBefore:     db.ConvertToDate(&bestBefore),
+		Quantity:       0,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	return &lot, nil
+}
+
+func (service *Service) applyMovement(ctx context.Context, qtx *sqlc.Queries, params movementParams) (*StockMovement, error) {
+	productId := params.productId
+
+	stock, err := qtx.GetStockForUpdate(ctx, sqlc.GetStockForUpdateParams{
+		WarehouseID: params.warehouseId,
+		ProductID:   productId,
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		stock, err = qtx.CreateStock(ctx, sqlc.CreateStockParams{
+			WarehouseID: params.warehouseId,
+			ProductID:   productId,
+			Quantity:    0,
+			MinQuantity: 10,
+		})
+		if err != nil {
+			logrus.WithError(err).Error("Could not create stock")
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	var qtyChange int32
+	for _, change := range params.changes {
+		qtyChange += change.quantity
+	}
+
+	newQty := stock.Quantity + qtyChange
+	if newQty < 0 {
+		return nil, ErrInsufficientStock
+	}
+
+	newReserved := max(stock.ReservedQuantity-params.releaseReserved, 0)
+	if qtyChange < 0 && newQty < newReserved &&
+		(params.movementType == sqlc.MovementTypeDISPATCH || params.movementType == sqlc.MovementTypeTRANSFER) {
+		return nil, ErrInsufficientStock
+	}
+
+	if err := qtx.UpdateStockQuantity(ctx, sqlc.UpdateStockQuantityParams{
+		ID:       stock.ID,
+		Quantity: newQty,
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if newReserved != stock.ReservedQuantity {
+		if err := qtx.UpdateStockReservedQuantity(ctx, sqlc.UpdateStockReservedQuantityParams{
+			ID:               stock.ID,
+			ReservedQuantity: newReserved,
+		}); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	movement, err := qtx.CreateStockMovement(ctx, sqlc.CreateStockMovementParams{
+		WarehouseID:         params.warehouseId,
+		ProductID:           productId,
+		QuantityChange:      qtyChange,
+		MovementType:        params.movementType,
+		RelatedOrderID:      db.ConvertToInt4(params.orderId),
+		RelatedMovementID:   db.ConvertToInt4(params.relatedMovementId),
+		Reason:              db.ConvertToText(params.reason),
+		EmployeeID:          db.ConvertToInt4(params.employeeId),
+		OverrideReason:      db.ConvertToText(params.overrideReason),
+		DocumentID:          db.ConvertToInt4(params.documentId),
+		PurchaseOrderLineID: db.ConvertToInt4(params.purchaseOrderLineId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	response := mapMovement(movement)
+
+	for _, change := range params.changes {
+		lot, err := qtx.GetStockLotForUpdate(ctx, change.lotId)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrLotNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if lot.ProductID != productId {
+			return nil, ErrLotProductMismatch
+		}
+
+		if lot.WarehouseID != params.warehouseId {
+			return nil, ErrLotWarehouseMismatch
+		}
+
+		newLotQty := lot.Quantity + change.quantity
+		if newLotQty < 0 {
+			return nil, ErrInsufficientStock
+		}
+
+		if err := qtx.UpdateStockLotQuantity(ctx, sqlc.UpdateStockLotQuantityParams{
+			ID:       lot.ID,
+			Quantity: newLotQty,
+		}); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if _, err := qtx.CreateStockMovementLot(ctx, sqlc.CreateStockMovementLotParams{
+			MovementID:     movement.ID,
+			LotID:          lot.ID,
+			QuantityChange: change.quantity,
+		}); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		response.Lots = append(response.Lots, MovementLot{
+			LotId:          lot.ID,
+			BatchNumber:    lot.BatchNumber,
+			BestBefore:     lot.BestBefore.Time,
+			QuantityChange: change.quantity,
+		})
+	}
+
+	return &response, nil
+}
+
+func outgoingLotChanges(quantity int32, lots []LotQuantity) ([]lotChange, error) {
+	var total int32
+	changes := make([]lotChange, len(lots))
+	for i, lot := range lots {
+		total += lot.Quantity
+		changes[i] = lotChange{lotId: lot.LotId, quantity: -lot.Quantity}
+	}
+
+	if total != quantity {
+		return nil, ErrLotQuantityMismatch
+	}
+
+	return changes, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func mapMovement(row sqlc.StockMovement) StockMovement {
+	stockMovement := StockMovement{
+		Id:             row.ID,
+		WarehouseId:    row.WarehouseID,
+		ProductId:      row.ProductID,
+		QuantityChange: row.QuantityChange,
+		MovementType:   row.MovementType,
+	}
+
+	if row.RelatedOrderID.Valid {
+		stockMovement.RelatedOrderId = &row.RelatedOrderID.Int32
+	}
+
+	if row.RelatedMovementID.Valid {
+		stockMovement.RelatedMovementId = &row.RelatedMovementID.Int32
+	}
+
+	if row.DocumentID.Valid {
+		stockMovement.DocumentId = &row.DocumentID.Int32
+	}
+
+	if row.PurchaseOrderLineID.Valid {
+		stockMovement.PurchaseOrderLineId = &row.PurchaseOrderLineID.Int32
+	}
+
+	if row.Reason.Valid {
+		stockMovement.Reason = &row.Reason.String
+	}
+
+	if row.CreatedAt.Valid {
+		stockMovement.CreatedAt = row.CreatedAt.Time
+	}
+
+	if row.EmployeeID.Valid {
+		stockMovement.EmployeeId = &row.EmployeeID.Int32
+	}
+
+	if row.OverrideReason.Valid {
+		stockMovement.OverrideReason = &row.OverrideReason.String
+	}
+
+	return stockMovement
+}
@@ -0,0 +1,84 @@
+package purchaseorders
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/warehouse/documents"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type PurchaseOrder struct {
+	Id                        int32                    `json:"id"`
+	OrderNumber               string                   `json:"orderNumber"`
+	SupplierId                int32                    `json:"supplierId"`
+	SupplierName              string                   `json:"supplierName"`
+	WarehouseId               int32                    `json:"warehouseId"`
+	WarehouseName             string                   `json:"warehouseName"`
+	Status                    sqlc.PurchaseOrderStatus `json:"status"`
+	OrderDate                 time.Time                `json:"orderDate"`
+	ExpectedDeliveryDate      *time.Time               `json:"expectedDeliveryDate,omitempty"`
+	OverDeliveryTolerancePct  int32                    `json:"overDeliveryTolerancePct"`
+	UnderDeliveryTolerancePct int32                    `json:"underDeliveryTolerancePct"`
+	Note                      *string                  `json:"note,omitempty"`
+	CreatedBy                 int32                    `json:"createdBy"`
+}
+
+type PurchaseOrderLine struct {
+	Id                   int32           `json:"id"`
+	ProductId            int32           `json:"productId"`
+	ProductName          string          `json:"productName"`
+	Unit                 string          `json:"unit"`
+	OrderedQuantity      int32           `json:"orderedQuantity"`
+	ReceivedQuantity     int32           `json:"receivedQuantity"`
+	OutstandingQuantity  int32           `json:"outstandingQuantity"`
+	UnitPrice            decimal.Decimal `json:"unitPrice"`
+	ExpectedDeliveryDate *time.Time      `json:"expectedDeliveryDate,omitempty"`
+}
+
+type ListPurchaseOrdersResponse struct {
+	PurchaseOrders []PurchaseOrder `json:"purchaseOrders"`
+}
+
+type GetPurchaseOrderResponse struct {
+	PurchaseOrder PurchaseOrder       `json:"purchaseOrder"`
+	Lines         []PurchaseOrderLine `json:"lines"`
+}
+
+type ReceivePurchaseOrderResponse struct {
+	PurchaseOrder PurchaseOrder       `json:"purchaseOrder"`
+	Lines         []PurchaseOrderLine `json:"lines"`
+	Document      documents.Document  `json:"document"`
+}
+
+type CreatePurchaseOrderLine struct {
+	ProductId            int32           `json:"productId" validate:"required"`
+	Quantity             int32           `json:"quantity" validate:"required,gt=0"`
+	UnitPrice            decimal.Decimal `json:"unitPrice" validate:"required,decimalpos"`
+	ExpectedDeliveryDate *time.Time      `json:"expectedDeliveryDate"`
+}
+
+// CreatePurchaseOrderRequest leaves the tolerances empty to take the
+// supplier's defaults.
+type CreatePurchaseOrderRequest struct {
+	SupplierId                int32                     `json:"supplierId" validate:"required"`
+	WarehouseId               *int32                    `json:"warehouseId"`
+	ExpectedDeliveryDate      *time.Time                `json:"expectedDeliveryDate"`
+	OverDeliveryTolerancePct  *int32                    `json:"overDeliveryTolerancePct" validate:"omitempty,gte=0,lte=100"`
+	UnderDeliveryTolerancePct *int32                    `json:"underDeliveryTolerancePct" validate:"omitempty,gte=0,lte=100"`
+	Note                      *string                   `json:"note" validate:"omitempty,max=255"`
+	Lines                     []CreatePurchaseOrderLine `json:"lines" validate:"required,min=1,dive"`
+}
+
+type ReceiptLine struct {
+	LineId         int32     `json:"lineId" validate:"required"`
+	Quantity       int32     `json:"quantity" validate:"required,gt=0"`
+	BatchNumber    string    `json:"batchNumber" validate:"required,max=100"`
+	ProductionDate time.Time `json:"productionDate" validate:"required"`
+	BestBefore     time.Time `json:"bestBefore" validate:"required,gtefield=ProductionDate"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	Note  *string       `json:"note" validate:"omitempty,max=255"`
+	Lines []ReceiptLine `json:"lines" validate:"required,min=1,dive"`
+}
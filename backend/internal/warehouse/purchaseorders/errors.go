@@ -0,0 +1,14 @@
+package purchaseorders
+
+import "errors"
+
+var (
+	ErrPurchaseOrderNotFound      = errors.New("purchase order not found")
+	ErrPurchaseOrderIdRequired    = errors.New("purchase order id required")
+	ErrInvalidPurchaseOrderId     = errors.New("invalid purchase order id")
+	ErrInvalidPurchaseOrderStatus = errors.New("invalid purchase order status")
+	ErrPurchaseOrderLineNotFound  = errors.New("purchase order line not found")
+	ErrDuplicateProduct           = errors.New("product appears on more than one purchase order line")
+	ErrStatusTransitionNotAllowed = errors.New("purchase order status does not allow this operation")
+	ErrOverDelivery               = errors.New("received quantity exceeds the over-delivery tolerance")
+)
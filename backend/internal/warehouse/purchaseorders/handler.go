@@ -0,0 +1,211 @@
+package purchaseorders
+
+import (
+	"context"
+	"errors"
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/suppliers"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListPurchaseOrders(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var status *sqlc.PurchaseOrderStatus
+	if statusStr := request.URL.Query().Get("status"); statusStr != "" {
+		value := sqlc.PurchaseOrderStatus(statusStr)
+		switch value {
+		case sqlc.PurchaseOrderStatusDRAFT, sqlc.PurchaseOrderStatusSENT, sqlc.PurchaseOrderStatusPARTIALLYRECEIVED,
+			sqlc.PurchaseOrderStatusRECEIVED, sqlc.PurchaseOrderStatusCANCELLED:
+			status = &value
+		default:
+			handler.handleServiceError(writer, ErrInvalidPurchaseOrderStatus)
+			return
+		}
+	}
+
+	var supplierId *int32
+	if supplierIdStr := request.URL.Query().Get("supplierId"); supplierIdStr != "" {
+		value, err := strconv.Atoi(supplierIdStr)
+		if err != nil {
+			handler.handleServiceError(writer, suppliers.ErrInvalidSupplierId)
+			return
+		}
+		id := int32(value)
+		supplierId = &id
+	}
+
+	orders, err := handler.service.ListPurchaseOrders(request.Context(), status, supplierId, warehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListPurchaseOrdersResponse{PurchaseOrders: orders})
+}
+
+func (handler *Handler) GetPurchaseOrder(writer http.ResponseWriter, request *http.Request) {
+	purchaseOrderId, err := handler.extractPurchaseOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	order, err := handler.service.GetPurchaseOrder(request.Context(), purchaseOrderId, scope)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, order)
+}
+
+func (handler *Handler) CreatePurchaseOrder(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	var body CreatePurchaseOrderRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
+	order, err := handler.service.CreatePurchaseOrder(request.Context(), int32(claims.UserId), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, order)
+}
+
+func (handler *Handler) SendPurchaseOrder(writer http.ResponseWriter, request *http.Request) {
+	handler.changeStatus(writer, request, handler.service.SendPurchaseOrder)
+}
+
+func (handler *Handler) CancelPurchaseOrder(writer http.ResponseWriter, request *http.Request) {
+	handler.changeStatus(writer, request, handler.service.CancelPurchaseOrder)
+}
+
+func (handler *Handler) ClosePurchaseOrder(writer http.ResponseWriter, request *http.Request) {
+	handler.changeStatus(writer, request, handler.service.ClosePurchaseOrder)
+}
+
+func (handler *Handler) ReceivePurchaseOrder(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	purchaseOrderId, err := handler.extractPurchaseOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body ReceivePurchaseOrderRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	response, err := handler.service.ReceivePurchaseOrder(request.Context(), purchaseOrderId, int32(claims.UserId), scope, body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, response)
+}
+
+func (handler *Handler) changeStatus(
+	writer http.ResponseWriter,
+	request *http.Request,
+	operation func(ctx context.Context, purchaseOrderId int32, warehouseScope *int32) error,
+) {
+	purchaseOrderId, err := handler.extractPurchaseOrderId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	if err := operation(request.Context(), purchaseOrderId, scope); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractPurchaseOrderId(request *http.Request) (int32, error) {
+	purchaseOrderIdStr := chi.URLParam(request, "purchaseOrderId")
+	if purchaseOrderIdStr == "" {
+		return 0, ErrPurchaseOrderIdRequired
+	}
+
+	purchaseOrderId, err := strconv.Atoi(purchaseOrderIdStr)
+	if err != nil {
+		return 0, ErrInvalidPurchaseOrderId
+	}
+
+	return int32(purchaseOrderId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrPurchaseOrderNotFound), errors.Is(err, ErrPurchaseOrderLineNotFound),
+		errors.Is(err, suppliers.ErrSupplierNotFound), errors.Is(err, products.ErrProductNotFound),
+		errors.Is(err, locations.ErrWarehouseNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrPurchaseOrderIdRequired), errors.Is(err, ErrInvalidPurchaseOrderId),
+		errors.Is(err, ErrInvalidPurchaseOrderStatus), errors.Is(err, ErrDuplicateProduct),
+		errors.Is(err, suppliers.ErrInvalidSupplierId), errors.Is(err, locations.ErrInvalidWarehouseId):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, locations.ErrWarehouseForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, ErrStatusTransitionNotAllowed), errors.Is(err, ErrOverDelivery),
+		errors.Is(err, suppliers.ErrSupplierInactive), errors.Is(err, locations.ErrWarehouseInactive),
+		errors.Is(err, movements.ErrLotDatesMismatch):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
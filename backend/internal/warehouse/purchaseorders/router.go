@@ -0,0 +1,33 @@
+package purchaseorders
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(
+	purchaseOrdersHandler *Handler,
+	middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Get("/", purchaseOrdersHandler.ListPurchaseOrders)
+	router.Get("/{purchaseOrderId}", purchaseOrdersHandler.GetPurchaseOrder)
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Post("/", purchaseOrdersHandler.CreatePurchaseOrder)
+		r.Post("/{purchaseOrderId}/send", purchaseOrdersHandler.SendPurchaseOrder)
+		r.Post("/{purchaseOrderId}/cancel", purchaseOrdersHandler.CancelPurchaseOrder)
+		r.Post("/{purchaseOrderId}/close", purchaseOrdersHandler.ClosePurchaseOrder)
+	})
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleWAREHOUSE))
+		r.Post("/{purchaseOrderId}/receipts", purchaseOrdersHandler.ReceivePurchaseOrder)
+	})
+
+	return router
+}
@@ -0,0 +1,447 @@
+package purchaseorders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/suppliers"
+	"mleczarnia/internal/warehouse/documents"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+type Service struct {
+	query     *sqlc.Queries
+	pool      *pgxpool.Pool
+	movements *movements.Service
+	documents *documents.Service
+}
+
+func NewService(
+	queries *sqlc.Queries,
+	pool *pgxpool.Pool,
+	movementsService *movements.Service,
+	documentsService *documents.Service,
+) *Service {
+	return &Service{query: queries, pool: pool, movements: movementsService, documents: documentsService}
+}
+
+func (service *Service) ListPurchaseOrders(
+	ctx context.Context,
+	status *sqlc.PurchaseOrderStatus,
+	supplierId *int32,
+	warehouseId *int32,
+) ([]PurchaseOrder, error) {
+	params := sqlc.ListPurchaseOrdersParams{
+		SupplierID:  db.ConvertToInt4(supplierId),
+		WarehouseID: db.ConvertToInt4(warehouseId),
+	}
+	if status != nil {
+		params.Status = sqlc.NullPurchaseOrderStatus{PurchaseOrderStatus: *status, Valid: true}
+	}
+
+	rows, err := service.query.ListPurchaseOrders(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]PurchaseOrder, len(rows))
+	for i, row := range rows {
+		result[i] = mapPurchaseOrder(row)
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetPurchaseOrder(ctx context.Context, purchaseOrderId int32, warehouseScope *int32) (*GetPurchaseOrderResponse, error) {
+	return service.getPurchaseOrder(ctx, service.query, purchaseOrderId, warehouseScope)
+}
+
+// CreatePurchaseOrder stores a DRAFT order. Tolerances missing from the
+// request are taken from the supplier.
+func (service *Service) CreatePurchaseOrder(
+	ctx context.Context,
+	userId int32,
+	req CreatePurchaseOrderRequest,
+) (*GetPurchaseOrderResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*GetPurchaseOrderResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		order, err := service.DraftPurchaseOrder(ctx, qtx, userId, req)
+		if err != nil {
+			return nil, err
+		}
+
+		return service.getPurchaseOrder(ctx, qtx, order.ID, nil)
+	})
+}
+
+// DraftPurchaseOrder stores a DRAFT order with its lines inside the caller's
+// transaction.
+func (service *Service) DraftPurchaseOrder(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	userId int32,
+	req CreatePurchaseOrderRequest,
+) (*sqlc.PurchaseOrder, error) {
+	supplier, err := qtx.GetSupplierById(ctx, req.SupplierId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, suppliers.ErrSupplierNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !supplier.IsActive {
+		return nil, suppliers.ErrSupplierInactive
+	}
+
+	warehouseId, err := service.movements.ResolveWarehouse(ctx, qtx, req.WarehouseId)
+	if err != nil {
+		return nil, err
+	}
+
+	overTolerance := supplier.OverDeliveryTolerancePct
+	if req.OverDeliveryTolerancePct != nil {
+		overTolerance = *req.OverDeliveryTolerancePct
+	}
+
+	underTolerance := supplier.UnderDeliveryTolerancePct
+	if req.UnderDeliveryTolerancePct != nil {
+		underTolerance = *req.UnderDeliveryTolerancePct
+	}
+
+	order, err := qtx.CreatePurchaseOrder(ctx, sqlc.CreatePurchaseOrderParams{
+		SupplierID:                supplier.ID,
+		WarehouseID:               warehouseId,
+		ExpectedDeliveryDate:      db.ConvertToDate(req.ExpectedDeliveryDate),
+		OverDeliveryTolerancePct:  overTolerance,
+		UnderDeliveryTolerancePct: underTolerance,
+		Note:                      db.ConvertToText(req.Note),
+		CreatedBy:                 userId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	seen := make(map[int32]bool, len(req.Lines))
+	for _, line := range req.Lines {
+		if seen[line.ProductId] {
+			return nil, ErrDuplicateProduct
+		}
+		seen[line.ProductId] = true
+
+		if _, err := qtx.GetProductById(ctx, line.ProductId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, products.ErrProductNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		unitPrice, err := db.DecimalToNumeric(line.UnitPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := qtx.CreatePurchaseOrderLine(ctx, sqlc.CreatePurchaseOrderLineParams{
+			PurchaseOrderID:      order.ID,
+			ProductID:            line.ProductId,
+			OrderedQuantity:      line.Quantity,
+			UnitPrice:            unitPrice,
+			ExpectedDeliveryDate: db.ConvertToDate(line.ExpectedDeliveryDate),
+		}); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return &order, nil
+}
+
+func (service *Service) SendPurchaseOrder(ctx context.Context, purchaseOrderId int32, warehouseScope *int32) error {
+	return service.changeStatus(ctx, purchaseOrderId, warehouseScope, sqlc.PurchaseOrderStatusSENT,
+		sqlc.PurchaseOrderStatusDRAFT)
+}
+
+func (service *Service) CancelPurchaseOrder(ctx context.Context, purchaseOrderId int32, warehouseScope *int32) error {
+	return service.changeStatus(ctx, purchaseOrderId, warehouseScope, sqlc.PurchaseOrderStatusCANCELLED,
+		sqlc.PurchaseOrderStatusDRAFT, sqlc.PurchaseOrderStatusSENT)
+}
+
+// ClosePurchaseOrder marks a partially received order as RECEIVED when the
+// rest of it is not going to arrive.
+func (service *Service) ClosePurchaseOrder(ctx context.Context, purchaseOrderId int32, warehouseScope *int32) error {
+	return service.changeStatus(ctx, purchaseOrderId, warehouseScope, sqlc.PurchaseOrderStatusRECEIVED,
+		sqlc.PurchaseOrderStatusPARTIALLYRECEIVED)
+}
+
+// ReceivePurchaseOrder books one delivery against an order: it issues a PZ,
+// posts an INBOUND movement for every received line and moves the order to
+// PARTIALLY_RECEIVED or RECEIVED. A line may not receive more than the
+// over-delivery tolerance allows and counts as complete once it is within the
+// under-delivery tolerance of the ordered quantity.
+func (service *Service) ReceivePurchaseOrder(
+	ctx context.Context,
+	purchaseOrderId int32,
+	userId int32,
+	warehouseScope *int32,
+	req ReceivePurchaseOrderRequest,
+) (*ReceivePurchaseOrderResponse, error) {
+	documentId, err := db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*int32, error) {
+		qtx := service.query.WithTx(tx)
+
+		order, err := service.purchaseOrderForUpdate(ctx, qtx, purchaseOrderId, warehouseScope)
+		if err != nil {
+			return nil, err
+		}
+
+		if order.Status != sqlc.PurchaseOrderStatusSENT && order.Status != sqlc.PurchaseOrderStatusPARTIALLYRECEIVED {
+			return nil, ErrStatusTransitionNotAllowed
+		}
+
+		lines, err := qtx.ListPurchaseOrderLinesForUpdate(ctx, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		supplier, err := qtx.GetSupplierById(ctx, order.SupplierID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		empId, err := employeeIdForUser(ctx, qtx, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		document, err := service.documents.IssueGoodsReceived(ctx, qtx, documents.GoodsReceivedHeader{
+			WarehouseId:     order.WarehouseID,
+			SupplierName:    supplier.Name,
+			PurchaseOrderId: &order.ID,
+			Note:            req.Note,
+		}, empId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, receipt := range req.Lines {
+			index := slices.IndexFunc(lines, func(line sqlc.PurchaseOrderLine) bool { return line.ID == receipt.LineId })
+			if index < 0 {
+				return nil, ErrPurchaseOrderLineNotFound
+			}
+			line := &lines[index]
+
+			received := line.ReceivedQuantity + receipt.Quantity
+			if received > maxAcceptedQuantity(line.OrderedQuantity, order.OverDeliveryTolerancePct) {
+				return nil, ErrOverDelivery
+			}
+
+			if _, err := service.movements.ReceiveStock(ctx, qtx, order.WarehouseID, movements.InboundRequest{
+				ProductId:      line.ProductID,
+				Quantity:       receipt.Quantity,
+				BatchNumber:    receipt.BatchNumber,
+				ProductionDate: receipt.ProductionDate,
+				BestBefore:     receipt.BestBefore,
+				Reason:         document.DocumentNumber,
+			}, movements.Receipt{DocumentId: &document.ID, PurchaseOrderLineId: &line.ID}, empId); err != nil {
+				return nil, err
+			}
+
+			if err := qtx.UpdatePurchaseOrderLineReceived(ctx, sqlc.UpdatePurchaseOrderLineReceivedParams{
+				ID:               line.ID,
+				ReceivedQuantity: received,
+			}); err != nil {
+				return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+			line.ReceivedQuantity = received
+		}
+
+		status := sqlc.PurchaseOrderStatusRECEIVED
+		for _, line := range lines {
+			if !isFulfilled(line, order.UnderDeliveryTolerancePct) {
+				status = sqlc.PurchaseOrderStatusPARTIALLYRECEIVED
+				break
+			}
+		}
+
+		if _, err := qtx.UpdatePurchaseOrderStatus(ctx, sqlc.UpdatePurchaseOrderStatusParams{
+			ID:     order.ID,
+			Status: status,
+		}); err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return &document.ID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := service.getPurchaseOrder(ctx, service.query, purchaseOrderId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := service.documents.GetDocument(ctx, *documentId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceivePurchaseOrderResponse{PurchaseOrder: order.PurchaseOrder, Lines: order.Lines, Document: *document}, nil
+}
+
+func (service *Service) changeStatus(
+	ctx context.Context,
+	purchaseOrderId int32,
+	warehouseScope *int32,
+	target sqlc.PurchaseOrderStatus,
+	allowedFrom ...sqlc.PurchaseOrderStatus,
+) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		order, err := service.purchaseOrderForUpdate(ctx, qtx, purchaseOrderId, warehouseScope)
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(allowedFrom, order.Status) {
+			return ErrStatusTransitionNotAllowed
+		}
+
+		if _, err := qtx.UpdatePurchaseOrderStatus(ctx, sqlc.UpdatePurchaseOrderStatusParams{
+			ID:     order.ID,
+			Status: target,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+func (service *Service) purchaseOrderForUpdate(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	purchaseOrderId int32,
+	warehouseScope *int32,
+) (*sqlc.PurchaseOrder, error) {
+	order, err := qtx.GetPurchaseOrderForUpdate(ctx, purchaseOrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if warehouseScope != nil && order.WarehouseID != *warehouseScope {
+		return nil, locations.ErrWarehouseForbidden
+	}
+
+	return &order, nil
+}
+
+func (service *Service) getPurchaseOrder(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	purchaseOrderId int32,
+	warehouseScope *int32,
+) (*GetPurchaseOrderResponse, error) {
+	row, err := qtx.GetPurchaseOrderById(ctx, purchaseOrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if warehouseScope != nil && row.WarehouseID != *warehouseScope {
+		return nil, locations.ErrWarehouseForbidden
+	}
+
+	rows, err := qtx.ListPurchaseOrderLines(ctx, purchaseOrderId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	lines := make([]PurchaseOrderLine, len(rows))
+	for i, line := range rows {
+		unitPrice, err := decimal.NewFromString(line.UnitPriceText)
+		if err != nil {
+			return nil, err
+		}
+
+		lines[i] = PurchaseOrderLine{
+			Id:                  line.ID,
+			ProductId:           line.ProductID,
+			ProductName:         line.ProductName,
+			Unit:                line.Unit,
+			OrderedQuantity:     line.OrderedQuantity,
+			ReceivedQuantity:    line.ReceivedQuantity,
+			OutstandingQuantity: max(line.OrderedQuantity-line.ReceivedQuantity, 0),
+			UnitPrice:           unitPrice,
+		}
+
+		if line.ExpectedDeliveryDate.Valid {
+			lines[i].ExpectedDeliveryDate = &line.ExpectedDeliveryDate.Time
+		}
+	}
+
+	return &GetPurchaseOrderResponse{PurchaseOrder: mapPurchaseOrder(sqlc.ListPurchaseOrdersRow(row)), Lines: lines}, nil
+}
+
+func employeeIdForUser(ctx context.Context, qtx *sqlc.Queries, userId int32) (*int32, error) {
+	user, err := qtx.GetUserByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !user.EmployeeID.Valid {
+		return nil, nil
+	}
+
+	return &user.EmployeeID.Int32, nil
+}
+
+// maxAcceptedQuantity is the most a line may receive in total before the
+// delivery exceeds the over-delivery tolerance.
+func maxAcceptedQuantity(ordered int32, overTolerancePct int32) int32 {
+	return ordered + ordered*overTolerancePct/100
+}
+
+func isFulfilled(line sqlc.PurchaseOrderLine, underTolerancePct int32) bool {
+	return line.ReceivedQuantity*100 >= line.OrderedQuantity*(100-underTolerancePct)
+}
+
+func mapPurchaseOrder(row sqlc.ListPurchaseOrdersRow) PurchaseOrder {
+	order := PurchaseOrder{
+		Id:                        row.ID,
+		OrderNumber:               row.OrderNumber,
+		SupplierId:                row.SupplierID,
+		SupplierName:              row.SupplierName,
+		WarehouseId:               row.WarehouseID,
+		WarehouseName:             row.WarehouseName,
+		Status:                    row.Status,
+		OrderDate:                 row.OrderDate.Time,
+		OverDeliveryTolerancePct:  row.OverDeliveryTolerancePct,
+		UnderDeliveryTolerancePct: row.UnderDeliveryTolerancePct,
+		CreatedBy:                 row.CreatedBy,
+	}
+
+	if row.ExpectedDeliveryDate.Valid {
+		order.ExpectedDeliveryDate = &row.ExpectedDeliveryDate.Time
+	}
+
+	if row.Note.Valid {
+		order.Note = &row.Note.String
+	}
+
+	return order
+}
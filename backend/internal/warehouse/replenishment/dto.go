@@ -0,0 +1,49 @@
+package replenishment
+
+import (
+	"mleczarnia/internal/warehouse/purchaseorders"
+
+	"github.com/shopspring/decimal"
+)
+
+type Suggestion struct {
+	WarehouseId       int32            `json:"warehouseId"`
+	WarehouseName     string           `json:"warehouseName"`
+	ProductId         int32            `json:"productId"`
+	ProductName       string           `json:"productName"`
+	Unit              string           `json:"unit"`
+	Quantity          int32            `json:"quantity"`
+	ReservedQuantity  int32            `json:"reservedQuantity"`
+	OnOrderQuantity   int32            `json:"onOrderQuantity"`
+	MinQuantity       int32            `json:"minQuantity"`
+	AvgDailyDispatch  float64          `json:"avgDailyDispatch"`
+	LeadTimeDays      int32            `json:"leadTimeDays"`
+	ReorderPoint      int32            `json:"reorderPoint"`
+	TargetQuantity    int32            `json:"targetQuantity"`
+	SuggestedQuantity int32            `json:"suggestedQuantity"`
+	SupplierId        *int32           `json:"supplierId,omitempty"`
+	SupplierName      *string          `json:"supplierName,omitempty"`
+	UnitPrice         *decimal.Decimal `json:"unitPrice,omitempty"`
+}
+
+type ReportResponse struct {
+	WindowDays   int32        `json:"windowDays"`
+	CoverageDays int32        `json:"coverageDays"`
+	Suggestions  []Suggestion `json:"suggestions"`
+}
+
+// CreateDraftsRequest limits the drafts to the given products when ProductIds
+// is not empty.
+type CreateDraftsRequest struct {
+	WarehouseId  *int32  `json:"warehouseId"`
+	WindowDays   *int32  `json:"windowDays" validate:"omitempty,gte=1,lte=365"`
+	CoverageDays *int32  `json:"coverageDays" validate:"omitempty,gte=0,lte=365"`
+	ProductIds   []int32 `json:"productIds"`
+}
+
+type CreateDraftsResponse struct {
+	PurchaseOrders []purchaseorders.GetPurchaseOrderResponse `json:"purchaseOrders"`
+	// Unassigned lists suggestions left out because the product has no
+	// preferred supplier.
+	Unassigned []Suggestion `json:"unassigned"`
+}
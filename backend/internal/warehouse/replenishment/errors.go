@@ -0,0 +1,8 @@
+package replenishment
+
+import "errors"
+
+var (
+	ErrInvalidWindowDays   = errors.New("window days must be between 1 and 365")
+	ErrInvalidCoverageDays = errors.New("coverage days must be between 0 and 365")
+)
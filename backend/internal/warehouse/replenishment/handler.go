@@ -0,0 +1,116 @@
+package replenishment
+
+import (
+	"errors"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/suppliers"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/purchaseorders"
+	"net/http"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) Report(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	windowDays, err := parseDays(request, "windowDays", 1, ErrInvalidWindowDays)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	coverageDays, err := parseDays(request, "coverageDays", 0, ErrInvalidCoverageDays)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	report, err := handler.service.Report(request.Context(), warehouseId, windowDays, coverageDays)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, report)
+}
+
+func (handler *Handler) CreateDrafts(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	var body CreateDraftsRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		httputil.WriteError(writer, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
+	response, err := handler.service.CreateDrafts(request.Context(), int32(claims.UserId), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, response)
+}
+
+func parseDays(request *http.Request, name string, minDays int, invalid error) (*int32, error) {
+	valueStr := request.URL.Query().Get(name)
+	if valueStr == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil || value < minDays || value > 365 {
+		return nil, invalid
+	}
+
+	days := int32(value)
+	return &days, nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, suppliers.ErrSupplierNotFound), errors.Is(err, products.ErrProductNotFound),
+		errors.Is(err, purchaseorders.ErrPurchaseOrderNotFound), errors.Is(err, locations.ErrWarehouseNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrInvalidWindowDays), errors.Is(err, ErrInvalidCoverageDays),
+		errors.Is(err, purchaseorders.ErrDuplicateProduct), errors.Is(err, locations.ErrInvalidWarehouseId):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, locations.ErrWarehouseForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, suppliers.ErrSupplierInactive), errors.Is(err, locations.ErrWarehouseInactive):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,24 @@
+package replenishment
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(
+	replenishmentHandler *Handler,
+	middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Get("/", replenishmentHandler.Report)
+
+	router.Group(func(r chi.Router) {
+		r.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF))
+		r.Post("/purchase-orders", replenishmentHandler.CreateDrafts)
+	})
+
+	return router
+}
@@ -0,0 +1,243 @@
+package replenishment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/warehouse/purchaseorders"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+)
+
+const draftNote = "replenishment"
+
+type Service struct {
+	query          *sqlc.Queries
+	pool           *pgxpool.Pool
+	purchaseOrders *purchaseorders.Service
+	windowDays     int32
+	coverageDays   int32
+}
+
+func NewService(
+	queries *sqlc.Queries,
+	pool *pgxpool.Pool,
+	purchaseOrdersService *purchaseorders.Service,
+	windowDays int32,
+	coverageDays int32,
+) *Service {
+	return &Service{
+		query:          queries,
+		pool:           pool,
+		purchaseOrders: purchaseOrdersService,
+		windowDays:     windowDays,
+		coverageDays:   coverageDays,
+	}
+}
+
+// Report lists every product whose stock, net of reservations and with open
+// purchase orders counted in, has fallen below its reorder point. The reorder
+// point is min_quantity plus the demand expected during the supplier's lead
+// time; the suggested quantity tops stock up to that point plus coverageDays
+// of average DISPATCH volume from the last windowDays.
+func (service *Service) Report(
+	ctx context.Context,
+	warehouseId *int32,
+	windowDays *int32,
+	coverageDays *int32,
+) (*ReportResponse, error) {
+	window, coverage := service.resolveDays(windowDays, coverageDays)
+
+	suggestions, err := service.suggestions(ctx, service.query, warehouseId, window, coverage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportResponse{WindowDays: window, CoverageDays: coverage, Suggestions: suggestions}, nil
+}
+
+// CreateDrafts turns the current suggestions into DRAFT purchase orders, one
+// per supplier and warehouse. Drafts count as open orders, so running it
+// again does not order the same shortfall twice.
+func (service *Service) CreateDrafts(ctx context.Context, userId int32, req CreateDraftsRequest) (*CreateDraftsResponse, error) {
+	window, coverage := service.resolveDays(req.WindowDays, req.CoverageDays)
+
+	type draftKey struct {
+		supplierId  int32
+		warehouseId int32
+	}
+
+	var unassigned []Suggestion
+	orderIds, err := db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*[]int32, error) {
+		qtx := service.query.WithTx(tx)
+
+		suggestions, err := service.suggestions(ctx, qtx, req.WarehouseId, window, coverage)
+		if err != nil {
+			return nil, err
+		}
+
+		var keys []draftKey
+		drafts := make(map[draftKey]*purchaseorders.CreatePurchaseOrderRequest)
+		today := time.Now()
+
+		for _, suggestion := range suggestions {
+			if len(req.ProductIds) > 0 && !slices.Contains(req.ProductIds, suggestion.ProductId) {
+				continue
+			}
+
+			if suggestion.SupplierId == nil {
+				unassigned = append(unassigned, suggestion)
+				continue
+			}
+
+			key := draftKey{supplierId: *suggestion.SupplierId, warehouseId: suggestion.WarehouseId}
+			draft, ok := drafts[key]
+			if !ok {
+				note := draftNote
+				draft = &purchaseorders.CreatePurchaseOrderRequest{
+					SupplierId:  key.supplierId,
+					WarehouseId: &suggestion.WarehouseId,
+					Note:        &note,
+				}
+				drafts[key] = draft
+				keys = append(keys, key)
+			}
+
+			expected := today.AddDate(0, 0, int(suggestion.LeadTimeDays))
+			if draft.ExpectedDeliveryDate == nil || expected.After(*draft.ExpectedDeliveryDate) {
+				draft.ExpectedDeliveryDate = &expected
+			}
+
+			draft.Lines = append(draft.Lines, purchaseorders.CreatePurchaseOrderLine{
+				ProductId:            suggestion.ProductId,
+				Quantity:             suggestion.SuggestedQuantity,
+				UnitPrice:            *suggestion.UnitPrice,
+				ExpectedDeliveryDate: &expected,
+			})
+		}
+
+		orderIds := make([]int32, 0, len(keys))
+		for _, key := range keys {
+			order, err := service.purchaseOrders.DraftPurchaseOrder(ctx, qtx, userId, *drafts[key])
+			if err != nil {
+				return nil, err
+			}
+			orderIds = append(orderIds, order.ID)
+		}
+
+		return &orderIds, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateDraftsResponse{
+		PurchaseOrders: make([]purchaseorders.GetPurchaseOrderResponse, 0, len(*orderIds)),
+		Unassigned:     make([]Suggestion, 0, len(unassigned)),
+	}
+	response.Unassigned = append(response.Unassigned, unassigned...)
+
+	for _, orderId := range *orderIds {
+		order, err := service.purchaseOrders.GetPurchaseOrder(ctx, orderId, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.PurchaseOrders = append(response.PurchaseOrders, *order)
+	}
+
+	return response, nil
+}
+
+func (service *Service) resolveDays(windowDays *int32, coverageDays *int32) (int32, int32) {
+	window := service.windowDays
+	if windowDays != nil {
+		window = *windowDays
+	}
+
+	coverage := service.coverageDays
+	if coverageDays != nil {
+		coverage = *coverageDays
+	}
+
+	return max(window, 1), coverage
+}
+
+func (service *Service) suggestions(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	warehouseId *int32,
+	windowDays int32,
+	coverageDays int32,
+) ([]Suggestion, error) {
+	since := time.Now().AddDate(0, 0, -int(windowDays))
+
+	rows, err := qtx.ListReplenishmentCandidates(ctx, sqlc.ListReplenishmentCandidatesParams{
+		Since:       db.ConvertToTimestamptz(&since),
+		WarehouseID: db.ConvertToInt4(warehouseId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Suggestion, 0)
+	for _, row := range rows {
+		suggestion, err := suggest(row, windowDays, coverageDays)
+		if err != nil {
+			return nil, err
+		}
+
+		if suggestion.SuggestedQuantity > 0 {
+			result = append(result, *suggestion)
+		}
+	}
+
+	return result, nil
+}
+
+func suggest(row sqlc.ListReplenishmentCandidatesRow, windowDays int32, coverageDays int32) (*Suggestion, error) {
+	avgDaily := float64(row.DispatchedQuantity) / float64(windowDays)
+
+	suggestion := &Suggestion{
+		WarehouseId:      row.WarehouseID,
+		WarehouseName:    row.WarehouseName,
+		ProductId:        row.ProductID,
+		ProductName:      row.ProductName,
+		Unit:             row.Unit,
+		Quantity:         row.Quantity,
+		ReservedQuantity: row.ReservedQuantity,
+		OnOrderQuantity:  row.OnOrderQuantity,
+		MinQuantity:      row.MinQuantity,
+		AvgDailyDispatch: math.Round(avgDaily*100) / 100,
+		LeadTimeDays:     row.LeadTimeDays.Int32,
+	}
+
+	if row.SupplierID.Valid {
+		unitPrice, err := decimal.NewFromString(row.UnitPriceText.String)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestion.SupplierId = &row.SupplierID.Int32
+		suggestion.SupplierName = &row.SupplierName.String
+		suggestion.UnitPrice = &unitPrice
+	}
+
+	suggestion.ReorderPoint = row.MinQuantity + int32(math.Ceil(avgDaily*float64(suggestion.LeadTimeDays)))
+	suggestion.TargetQuantity = suggestion.ReorderPoint + int32(math.Ceil(avgDaily*float64(coverageDays)))
+
+	projected := row.Quantity - row.ReservedQuantity + row.OnOrderQuantity
+	if projected < suggestion.ReorderPoint {
+		suggestion.SuggestedQuantity = suggestion.TargetQuantity - projected
+		if row.MinOrderQuantity.Valid {
+			suggestion.SuggestedQuantity = max(suggestion.SuggestedQuantity, row.MinOrderQuantity.Int32)
+		}
+	}
+
+	return suggestion, nil
+}
@@ -11,17 +11,29 @@ import (
 func Router(
 	stockHandler *Handler,
 	middleware *app.Middleware,
-	movementsRouter http.Handler) http.Handler {
+	movementsRouter http.Handler,
+	locationsRouter http.Handler,
+	stocktakesRouter http.Handler,
+	documentsRouter http.Handler,
+	purchaseOrdersRouter http.Handler,
+	replenishmentRouter http.Handler) http.Handler {
 	router := chi.NewRouter()
 
 	router.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleSTAFF, sqlc.RoleWAREHOUSE))
 	router.Use(middleware.CheckBlockStatus())
+	router.Use(middleware.ScopeWarehouse())
 
 	router.Get("/", stockHandler.ListStock)
+	router.Get("/expiring", stockHandler.ListExpiringLots)
 	router.Get("/{productId}", stockHandler.GetStockByProductId)
 	router.Patch("/{productId}", stockHandler.UpdateStock)
 
 	router.Mount("/movements", movementsRouter)
+	router.Mount("/locations", locationsRouter)
+	router.Mount("/stocktakes", stocktakesRouter)
+	router.Mount("/documents", documentsRouter)
+	router.Mount("/purchase-orders", purchaseOrdersRouter)
+	router.Mount("/replenishment", replenishmentRouter)
 
 	return router
 }
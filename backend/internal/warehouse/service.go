@@ -12,15 +12,17 @@ import (
 )
 
 type Service struct {
-	query *sqlc.Queries
+	query              *sqlc.Queries
+	expiryWarningDays  int32
+	defaultWarehouseId int32
 }
 
-func NewService(queries *sqlc.Queries) *Service {
-	return &Service{query: queries}
+func NewService(queries *sqlc.Queries, expiryWarningDays int32, defaultWarehouseId int32) *Service {
+	return &Service{query: queries, expiryWarningDays: expiryWarningDays, defaultWarehouseId: defaultWarehouseId}
 }
 
-func (service *Service) ListStock(ctx context.Context) ([]Stock, error) {
-	rows, err := service.query.ListStock(ctx)
+func (service *Service) ListStock(ctx context.Context, warehouseId *int32) ([]Stock, error) {
+	rows, err := service.query.ListStock(ctx, db.ConvertToInt4(warehouseId))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrStockNotFound
@@ -31,21 +33,30 @@ func (service *Service) ListStock(ctx context.Context) ([]Stock, error) {
 	result := make([]Stock, len(rows))
 	for i, row := range rows {
 		result[i] = Stock{
-			ProductId:     row.ProductID,
-			ProductName:   row.ProductName,
-			Quantity:      row.Quantity,
-			MinQuantity:   row.MinQuantity,
-			IsLow:         row.Quantity < row.MinQuantity,
-			DamagedCount:  row.DamagedCount,
-			ReturnedCount: row.ReturnCount,
+			WarehouseId:       row.WarehouseID,
+			WarehouseName:     row.WarehouseName,
+			ProductId:         row.ProductID,
+			ProductName:       row.ProductName,
+			Quantity:          row.Quantity,
+			ReservedQuantity:  row.ReservedQuantity,
+			AvailableQuantity: max(row.Quantity-row.ReservedQuantity, 0),
+			MinQuantity:       row.MinQuantity,
+			IsLow:             row.Quantity < row.MinQuantity,
+			DamagedCount:      row.DamagedCount,
+			ReturnedCount:     row.ReturnCount,
 		}
 	}
 
 	return result, nil
 }
 
-func (service *Service) GetStockByProductId(ctx context.Context, productId int32) (*Stock, error) {
-	row, err := service.query.GetStockByProductId(ctx, productId)
+func (service *Service) GetStockByProductId(ctx context.Context, warehouseId *int32, productId int32) (*Stock, error) {
+	warehouse := service.warehouseOrDefault(warehouseId)
+
+	row, err := service.query.GetStockByProductId(ctx, sqlc.GetStockByProductIdParams{
+		WarehouseID: warehouse,
+		ProductID:   productId,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrStockNotFound
@@ -54,19 +65,45 @@ func (service *Service) GetStockByProductId(ctx context.Context, productId int32
 
 	}
 
-	return &Stock{
-		ProductId:     row.ProductID,
-		ProductName:   row.ProductName,
-		Quantity:      row.Quantity,
-		MinQuantity:   row.MinQuantity,
-		IsLow:         row.Quantity < row.MinQuantity,
-		DamagedCount:  row.DamagedCount,
-		ReturnedCount: row.ReturnCount,
-	}, nil
+	lots, err := service.query.ListStockLotsByProduct(ctx, sqlc.ListStockLotsByProductParams{
+		WarehouseID: warehouse,
+		ProductID:   productId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	stock := &Stock{
+		WarehouseId:       row.WarehouseID,
+		WarehouseName:     row.WarehouseName,
+		ProductId:         row.ProductID,
+		ProductName:       row.ProductName,
+		Quantity:          row.Quantity,
+		ReservedQuantity:  row.ReservedQuantity,
+		AvailableQuantity: max(row.Quantity-row.ReservedQuantity, 0),
+		MinQuantity:       row.MinQuantity,
+		IsLow:             row.Quantity < row.MinQuantity,
+		DamagedCount:      row.DamagedCount,
+		ReturnedCount:     row.ReturnCount,
+		Lots:              make([]StockLot, len(lots)),
+	}
+
+	for i, lot := range lots {
+		stock.Lots[i] = StockLot{
+			Id:             lot.ID,
+			BatchNumber:    lot.BatchNumber,
+			ProductionDate: lot.ProductionDate.Time,
+			BestBefore:     lot.BestBefore.Time,
+			Quantity:       lot.Quantity,
+		}
+	}
+
+	return stock, nil
 }
 
-func (service *Service) UpdateStock(ctx context.Context, productId int32, request UpdateStockRequest) error {
+func (service *Service) UpdateStock(ctx context.Context, warehouseId *int32, productId int32, request UpdateStockRequest) error {
 	if _, err := service.query.UpdateStockByProductId(ctx, sqlc.UpdateStockByProductIdParams{
+		WarehouseID: service.warehouseOrDefault(warehouseId),
 		ProductID:   productId,
 		MinQuantity: request.MinQuantity,
 	}); err != nil {
@@ -77,3 +114,44 @@ func (service *Service) UpdateStock(ctx context.Context, productId int32, reques
 	}
 	return nil
 }
+
+func (service *Service) ListExpiringLots(ctx context.Context, warehouseId *int32, days *int32) (*ListExpiringLotsResponse, error) {
+	window := service.expiryWarningDays
+	if days != nil {
+		window = *days
+	}
+
+	rows, err := service.query.ListExpiringStockLots(ctx, sqlc.ListExpiringStockLotsParams{
+		Days:        window,
+		WarehouseID: db.ConvertToInt4(warehouseId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]ExpiringLot, len(rows))
+	for i, row := range rows {
+		result[i] = ExpiringLot{
+			LotId:          row.ID,
+			WarehouseId:    row.WarehouseID,
+			WarehouseName:  row.WarehouseName,
+			ProductId:      row.ProductID,
+			ProductName:    row.ProductName,
+			Category:       row.Category,
+			BatchNumber:    row.BatchNumber,
+			ProductionDate: row.ProductionDate.Time,
+			BestBefore:     row.BestBefore.Time,
+			Quantity:       row.Quantity,
+			DaysLeft:       row.DaysLeft,
+		}
+	}
+
+	return &ListExpiringLotsResponse{Days: window, Lots: result}, nil
+}
+
+func (service *Service) warehouseOrDefault(warehouseId *int32) int32 {
+	if warehouseId != nil {
+		return *warehouseId
+	}
+	return service.defaultWarehouseId
+}
@@ -0,0 +1,57 @@
+package stocktakes
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/warehouse/movements"
+	"time"
+)
+
+type Stocktake struct {
+	Id          int32                `json:"id"`
+	WarehouseId int32                `json:"warehouseId"`
+	Status      sqlc.StocktakeStatus `json:"status"`
+	Note        *string              `json:"note,omitempty"`
+	OpenedBy    int32                `json:"openedBy"`
+	OpenedAt    time.Time            `json:"openedAt"`
+	ClosedBy    *int32               `json:"closedBy,omitempty"`
+	ClosedAt    *time.Time           `json:"closedAt,omitempty"`
+}
+
+type StocktakeCount struct {
+	Id               int32      `json:"id"`
+	ProductId        int32      `json:"productId"`
+	ProductName      string     `json:"productName"`
+	LotId            *int32     `json:"lotId,omitempty"`
+	BatchNumber      *string    `json:"batchNumber,omitempty"`
+	BestBefore       *time.Time `json:"bestBefore,omitempty"`
+	CountedQuantity  int32      `json:"countedQuantity"`
+	ExpectedQuantity int32      `json:"expectedQuantity"`
+	Difference       int32      `json:"difference"`
+	CountedBy        int32      `json:"countedBy"`
+	CountedAt        time.Time  `json:"countedAt"`
+}
+
+type ListStocktakesResponse struct {
+	Stocktakes []Stocktake `json:"stocktakes"`
+}
+
+type GetStocktakeResponse struct {
+	Stocktake Stocktake        `json:"stocktake"`
+	Counts    []StocktakeCount `json:"counts"`
+}
+
+type CloseStocktakeResponse struct {
+	Stocktake   Stocktake                 `json:"stocktake"`
+	Adjustments []movements.StockMovement `json:"adjustments"`
+}
+
+type OpenStocktakeRequest struct {
+	WarehouseId *int32  `json:"warehouseId"`
+	Note        *string `json:"note" validate:"omitempty,max=255"`
+}
+
+type SubmitCountRequest struct {
+	ProductId       int32  `json:"productId" validate:"required"`
+	LotId           *int32 `json:"lotId"`
+	CountedQuantity int32  `json:"countedQuantity" validate:"gte=0"`
+}
@@ -0,0 +1,12 @@
+package stocktakes
+
+import "errors"
+
+var (
+	ErrStocktakeNotFound    = errors.New("stocktake not found")
+	ErrStocktakeIdRequired  = errors.New("stocktake id required")
+	ErrInvalidStocktakeId   = errors.New("invalid stocktake id")
+	ErrStocktakeAlreadyOpen = errors.New("a stocktake is already open for this warehouse")
+	ErrStocktakeNotOpen     = errors.New("stocktake is not open")
+	ErrCountLevelMismatch   = errors.New("product is already counted at a different level")
+)
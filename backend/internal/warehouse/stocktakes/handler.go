@@ -0,0 +1,186 @@
+package stocktakes
+
+import (
+	"errors"
+	app "mleczarnia/internal/http"
+	"mleczarnia/internal/httputil"
+	"mleczarnia/internal/jwt"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (handler *Handler) ListStocktakes(writer http.ResponseWriter, request *http.Request) {
+	warehouseId, err := locations.ResolveQuery(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	stocktakes, err := handler.service.ListStocktakes(request.Context(), warehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, ListStocktakesResponse{Stocktakes: stocktakes})
+}
+
+func (handler *Handler) GetStocktake(writer http.ResponseWriter, request *http.Request) {
+	stocktakeId, err := handler.extractStocktakeId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	stocktake, err := handler.service.GetStocktake(request.Context(), stocktakeId, scope)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, stocktake)
+}
+
+func (handler *Handler) OpenStocktake(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	var body OpenStocktakeRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	warehouseId, err := locations.Resolve(request.Context(), body.WarehouseId)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+	body.WarehouseId = warehouseId
+
+	stocktake, err := handler.service.OpenStocktake(request.Context(), int32(claims.UserId), body)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusCreated, stocktake)
+}
+
+func (handler *Handler) SubmitCount(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	stocktakeId, err := handler.extractStocktakeId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	var body SubmitCountRequest
+	if err := httputil.DecodeAndValidateBody(writer, request.Body, &body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	if err := handler.service.SubmitCount(request.Context(), stocktakeId, int32(claims.UserId), scope, body); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) CloseStocktake(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	stocktakeId, err := handler.extractStocktakeId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	response, err := handler.service.CloseStocktake(request.Context(), stocktakeId, int32(claims.UserId), scope)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	httputil.WriteJSON(writer, http.StatusOK, response)
+}
+
+func (handler *Handler) CancelStocktake(writer http.ResponseWriter, request *http.Request) {
+	claims := request.Context().Value(app.UserCtxKey).(*jwt.Claims)
+
+	stocktakeId, err := handler.extractStocktakeId(request)
+	if err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	scope, _ := locations.Resolve(request.Context(), nil)
+
+	if err := handler.service.CancelStocktake(request.Context(), stocktakeId, int32(claims.UserId), scope); err != nil {
+		handler.handleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (handler *Handler) extractStocktakeId(request *http.Request) (int32, error) {
+	stocktakeIdStr := chi.URLParam(request, "stocktakeId")
+	if stocktakeIdStr == "" {
+		return 0, ErrStocktakeIdRequired
+	}
+
+	stocktakeId, err := strconv.Atoi(stocktakeIdStr)
+	if err != nil {
+		return 0, ErrInvalidStocktakeId
+	}
+
+	return int32(stocktakeId), nil
+}
+
+func (handler *Handler) handleServiceError(writer http.ResponseWriter, err error) {
+	statusCode, message := handler.mapErrorToResponse(err)
+	logrus.WithError(err).Info()
+	httputil.WriteError(writer, statusCode, message)
+}
+
+func (handler *Handler) mapErrorToResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrStocktakeNotFound), errors.Is(err, locations.ErrWarehouseNotFound),
+		errors.Is(err, products.ErrProductNotFound), errors.Is(err, movements.ErrLotNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, ErrStocktakeIdRequired), errors.Is(err, ErrInvalidStocktakeId), errors.Is(err, locations.ErrInvalidWarehouseId),
+		errors.Is(err, movements.ErrLotProductMismatch), errors.Is(err, movements.ErrLotWarehouseMismatch):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, locations.ErrWarehouseForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, ErrStocktakeAlreadyOpen), errors.Is(err, ErrStocktakeNotOpen), errors.Is(err, ErrCountLevelMismatch),
+		errors.Is(err, locations.ErrWarehouseInactive), errors.Is(err, movements.ErrInsufficientStock):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
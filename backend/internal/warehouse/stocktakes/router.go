@@ -0,0 +1,27 @@
+package stocktakes
+
+import (
+	"mleczarnia/internal/db/sqlc"
+	app "mleczarnia/internal/http"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Router(
+	stocktakesHandler *Handler,
+	middleware *app.Middleware) http.Handler {
+	router := chi.NewRouter()
+
+	router.Use(middleware.RequireRole(sqlc.RoleADMIN, sqlc.RoleWAREHOUSE))
+	router.Use(middleware.CheckBlockStatus())
+
+	router.Get("/", stocktakesHandler.ListStocktakes)
+	router.Post("/", stocktakesHandler.OpenStocktake)
+	router.Get("/{stocktakeId}", stocktakesHandler.GetStocktake)
+	router.Post("/{stocktakeId}/counts", stocktakesHandler.SubmitCount)
+	router.Post("/{stocktakeId}/close", stocktakesHandler.CloseStocktake)
+	router.Post("/{stocktakeId}/cancel", stocktakesHandler.CancelStocktake)
+
+	return router
+}
@@ -0,0 +1,401 @@
+package stocktakes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mleczarnia/internal/db"
+	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/products"
+	"mleczarnia/internal/warehouse/locations"
+	"mleczarnia/internal/warehouse/movements"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Service struct {
+	query     *sqlc.Queries
+	pool      *pgxpool.Pool
+	movements *movements.Service
+}
+
+func NewService(queries *sqlc.Queries, pool *pgxpool.Pool, movementsService *movements.Service) *Service {
+	return &Service{query: queries, pool: pool, movements: movementsService}
+}
+
+func (service *Service) ListStocktakes(ctx context.Context, warehouseId *int32) ([]Stocktake, error) {
+	rows, err := service.query.ListStocktakes(ctx, db.ConvertToInt4(warehouseId))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	result := make([]Stocktake, len(rows))
+	for i, row := range rows {
+		result[i] = mapStocktake(row)
+	}
+
+	return result, nil
+}
+
+func (service *Service) GetStocktake(ctx context.Context, stocktakeId int32, warehouseScope *int32) (*GetStocktakeResponse, error) {
+	stocktake, err := service.query.GetStocktakeById(ctx, stocktakeId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStocktakeNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if warehouseScope != nil && stocktake.WarehouseID != *warehouseScope {
+		return nil, locations.ErrWarehouseForbidden
+	}
+
+	rows, err := service.query.ListStocktakeCounts(ctx, stocktakeId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	counts := make([]StocktakeCount, len(rows))
+	for i, row := range rows {
+		counts[i] = mapStocktakeCount(row)
+	}
+
+	return &GetStocktakeResponse{Stocktake: mapStocktake(stocktake), Counts: counts}, nil
+}
+
+func (service *Service) OpenStocktake(ctx context.Context, userId int32, request OpenStocktakeRequest) (*Stocktake, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*Stocktake, error) {
+		qtx := service.query.WithTx(tx)
+
+		warehouseId, err := service.movements.ResolveWarehouse(ctx, qtx, request.WarehouseId)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := qtx.GetOpenStocktakeByWarehouse(ctx, warehouseId); err == nil {
+			return nil, ErrStocktakeAlreadyOpen
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		row, err := qtx.CreateStocktake(ctx, sqlc.CreateStocktakeParams{
+			WarehouseID: warehouseId,
+			Note:        db.ConvertToText(request.Note),
+			OpenedBy:    userId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		stocktake := mapStocktake(row)
+		return &stocktake, nil
+	})
+}
+
+// SubmitCount records what a counter found on the shelf. Counting the same
+// product or lot again replaces the earlier figure.
+func (service *Service) SubmitCount(
+	ctx context.Context,
+	stocktakeId int32,
+	userId int32,
+	warehouseScope *int32,
+	request SubmitCountRequest,
+) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		stocktake, err := service.openStocktakeForUpdate(ctx, qtx, stocktakeId, warehouseScope)
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.GetProductById(ctx, request.ProductId); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return products.ErrProductNotFound
+			}
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		if request.LotId != nil {
+			lot, err := qtx.GetStockLotForUpdate(ctx, *request.LotId)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return movements.ErrLotNotFound
+				}
+				return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+			}
+
+			if lot.ProductID != request.ProductId {
+				return movements.ErrLotProductMismatch
+			}
+
+			if lot.WarehouseID != stocktake.WarehouseID {
+				return movements.ErrLotWarehouseMismatch
+			}
+		}
+
+		existing, err := qtx.ListStocktakeCountsByProduct(ctx, sqlc.ListStocktakeCountsByProductParams{
+			StocktakeID: stocktakeId,
+			ProductID:   request.ProductId,
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		for _, count := range existing {
+			if count.LotID.Valid != (request.LotId != nil) {
+				return ErrCountLevelMismatch
+			}
+
+			if !count.LotID.Valid || count.LotID.Int32 == *request.LotId {
+				if err := qtx.UpdateStocktakeCount(ctx, sqlc.UpdateStocktakeCountParams{
+					ID:              count.ID,
+					CountedQuantity: request.CountedQuantity,
+					CountedBy:       userId,
+				}); err != nil {
+					return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+				}
+				return nil
+			}
+		}
+
+		if _, err := qtx.CreateStocktakeCount(ctx, sqlc.CreateStocktakeCountParams{
+			StocktakeID:     stocktakeId,
+			ProductID:       request.ProductId,
+			LotID:           db.ConvertToInt4(request.LotId),
+			CountedQuantity: request.CountedQuantity,
+			CountedBy:       userId,
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+// CloseStocktake posts one ADJUSTMENT movement per product whose count
+// differs from the books, freezes the book quantities on the counts and
+// closes the session. Products that were not counted are left untouched.
+func (service *Service) CloseStocktake(
+	ctx context.Context,
+	stocktakeId int32,
+	userId int32,
+	warehouseScope *int32,
+) (*CloseStocktakeResponse, error) {
+	return db.WithinTransactionReturning(ctx, service.pool, func(tx pgx.Tx) (*CloseStocktakeResponse, error) {
+		qtx := service.query.WithTx(tx)
+
+		stocktake, err := service.openStocktakeForUpdate(ctx, qtx, stocktakeId, warehouseScope)
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := qtx.ListStocktakeCounts(ctx, stocktakeId)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		empId, err := employeeIdForUser(ctx, qtx, userId)
+		if err != nil {
+			return nil, err
+		}
+
+		reason := fmt.Sprintf("stocktake #%d", stocktake.ID)
+		adjustments := make([]movements.StockMovement, 0)
+
+		for _, group := range groupCountsByProduct(rows) {
+			productId := group[0].ProductID
+
+			var movement *movements.StockMovement
+			if group[0].LotID.Valid {
+				counted := make([]movements.LotQuantity, len(group))
+				for i, count := range group {
+					counted[i] = movements.LotQuantity{LotId: count.LotID.Int32, Quantity: count.CountedQuantity}
+				}
+				movement, err = service.movements.AdjustLots(ctx, qtx, stocktake.WarehouseID, productId, counted, reason, empId)
+			} else {
+				movement, err = service.movements.AdjustProduct(
+					ctx, qtx, stocktake.WarehouseID, productId, group[0].CountedQuantity, reason, empId,
+				)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if err := freezeExpected(ctx, qtx, group, movement); err != nil {
+				return nil, err
+			}
+
+			if movement != nil {
+				adjustments = append(adjustments, *movement)
+			}
+		}
+
+		closed, err := qtx.FinishStocktake(ctx, sqlc.FinishStocktakeParams{
+			ID:       stocktake.ID,
+			Status:   sqlc.StocktakeStatusCLOSED,
+			ClosedBy: db.ConvertToInt4(&userId),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return &CloseStocktakeResponse{Stocktake: mapStocktake(closed), Adjustments: adjustments}, nil
+	})
+}
+
+func (service *Service) CancelStocktake(ctx context.Context, stocktakeId int32, userId int32, warehouseScope *int32) error {
+	return db.WithinTransaction(ctx, service.pool, func(tx pgx.Tx) error {
+		qtx := service.query.WithTx(tx)
+
+		if _, err := service.openStocktakeForUpdate(ctx, qtx, stocktakeId, warehouseScope); err != nil {
+			return err
+		}
+
+		if _, err := qtx.FinishStocktake(ctx, sqlc.FinishStocktakeParams{
+			ID:       stocktakeId,
+			Status:   sqlc.StocktakeStatusCANCELLED,
+			ClosedBy: db.ConvertToInt4(&userId),
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+
+		return nil
+	})
+}
+
+func (service *Service) openStocktakeForUpdate(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	stocktakeId int32,
+	warehouseScope *int32,
+) (*sqlc.Stocktake, error) {
+	stocktake, err := qtx.GetStocktakeForUpdate(ctx, stocktakeId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStocktakeNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if warehouseScope != nil && stocktake.WarehouseID != *warehouseScope {
+		return nil, locations.ErrWarehouseForbidden
+	}
+
+	if stocktake.Status != sqlc.StocktakeStatusOPEN {
+		return nil, ErrStocktakeNotOpen
+	}
+
+	return &stocktake, nil
+}
+
+func employeeIdForUser(ctx context.Context, qtx *sqlc.Queries, userId int32) (*int32, error) {
+	user, err := qtx.GetUserByID(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+	}
+
+	if !user.EmployeeID.Valid {
+		return nil, nil
+	}
+
+	return &user.EmployeeID.Int32, nil
+}
+
+// freezeExpected stores the book quantity each count was compared against,
+// worked back from the adjustment that was actually posted.
+func freezeExpected(ctx context.Context, qtx *sqlc.Queries, group []sqlc.ListStocktakeCountsRow, movement *movements.StockMovement) error {
+	for _, count := range group {
+		expected := count.CountedQuantity
+		if movement != nil {
+			if !count.LotID.Valid {
+				expected -= movement.QuantityChange
+			} else {
+				for _, lot := range movement.Lots {
+					if lot.LotId == count.LotID.Int32 {
+						expected -= lot.QuantityChange
+					}
+				}
+			}
+		}
+
+		if err := qtx.SetStocktakeCountExpected(ctx, sqlc.SetStocktakeCountExpectedParams{
+			ID:               count.ID,
+			ExpectedQuantity: pgtype.Int4{Int32: expected, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("%w: %v", db.ErrDatabaseOperation, err)
+		}
+	}
+
+	return nil
+}
+
+func groupCountsByProduct(rows []sqlc.ListStocktakeCountsRow) [][]sqlc.ListStocktakeCountsRow {
+	var groups [][]sqlc.ListStocktakeCountsRow
+	index := make(map[int32]int)
+
+	for _, row := range rows {
+		i, ok := index[row.ProductID]
+		if !ok {
+			i = len(groups)
+			index[row.ProductID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], row)
+	}
+
+	return groups
+}
+
+func mapStocktake(row sqlc.Stocktake) Stocktake {
+	stocktake := Stocktake{
+		Id:          row.ID,
+		WarehouseId: row.WarehouseID,
+		Status:      row.Status,
+		OpenedBy:    row.OpenedBy,
+		OpenedAt:    row.OpenedAt.Time,
+	}
+
+	if row.Note.Valid {
+		stocktake.Note = &row.Note.String
+	}
+
+	if row.ClosedBy.Valid {
+		stocktake.ClosedBy = &row.ClosedBy.Int32
+	}
+
+	if row.ClosedAt.Valid {
+		stocktake.ClosedAt = &row.ClosedAt.Time
+	}
+
+	return stocktake
+}
+
+func mapStocktakeCount(row sqlc.ListStocktakeCountsRow) StocktakeCount {
+	count := StocktakeCount{
+		Id:               row.ID,
+		ProductId:        row.ProductID,
+		ProductName:      row.ProductName,
+		CountedQuantity:  row.CountedQuantity,
+		ExpectedQuantity: row.ExpectedQuantity,
+		Difference:       row.CountedQuantity - row.ExpectedQuantity,
+		CountedBy:        row.CountedBy,
+		CountedAt:        row.CountedAt.Time,
+	}
+
+	if row.LotID.Valid {
+		count.LotId = &row.LotID.Int32
+	}
+
+	if row.BatchNumber.Valid {
+		count.BatchNumber = &row.BatchNumber.String
+	}
+
+	if row.BestBefore.Valid {
+		count.BestBefore = &row.BestBefore.Time
+	}
+
+	return count
+}
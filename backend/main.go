@@ -7,18 +7,34 @@ import (
 	"mleczarnia/internal/auth"
 	"mleczarnia/internal/companies"
 	"mleczarnia/internal/companies/addresses"
+	"mleczarnia/internal/companies/receivables"
+	"mleczarnia/internal/companysettings"
+	"mleczarnia/internal/customergroups"
 	"mleczarnia/internal/db"
 	"mleczarnia/internal/db/sqlc"
+	"mleczarnia/internal/discounts"
 	"mleczarnia/internal/employees"
 	app "mleczarnia/internal/http"
 	"mleczarnia/internal/invoices"
 	"mleczarnia/internal/jwt"
 	"mleczarnia/internal/me"
 	"mleczarnia/internal/orders"
+	"mleczarnia/internal/orders/proformas"
+	"mleczarnia/internal/orders/standingorders"
+	"mleczarnia/internal/payments"
+	"mleczarnia/internal/payments/statements"
+	"mleczarnia/internal/pricelists"
 	"mleczarnia/internal/products"
+	"mleczarnia/internal/scheduler"
+	"mleczarnia/internal/suppliers"
 	"mleczarnia/internal/users"
 	"mleczarnia/internal/warehouse"
+	"mleczarnia/internal/warehouse/documents"
+	"mleczarnia/internal/warehouse/locations"
 	"mleczarnia/internal/warehouse/movements"
+	"mleczarnia/internal/warehouse/purchaseorders"
+	"mleczarnia/internal/warehouse/replenishment"
+	"mleczarnia/internal/warehouse/stocktakes"
 	"net/http"
 	"time"
 
@@ -60,29 +76,98 @@ func main() {
 	addressHandler := addresses.NewHandler(addressesService)
 	addressesRouter := addresses.Router(addressHandler, middleware)
 
-	companiesService := companies.NewService(queries, pool)
+	receivablesService := receivables.NewService(queries, pool)
+	receivablesHandler := receivables.NewHandler(receivablesService)
+	statementRouter := receivables.StatementRouter(receivablesHandler, middleware)
+	agingRouter := receivables.AgingRouter(receivablesHandler, middleware)
+
+	companiesService := companies.NewService(queries, pool, companies.RiskPolicy{
+		Threshold:   cfg.AtRiskScoreThreshold,
+		OverdueDays: cfg.AtRiskOverdueDays,
+	})
 	companiesHandler := companies.NewHandler(companiesService)
-	companiesRouter := companies.Router(companiesHandler, middleware, addressesRouter)
 
 	productsService := products.NewService(queries)
 	productHandler := products.NewHandler(productsService)
 	productsRouter := products.Router(productHandler, middleware)
 
-	movementsService := movements.NewService(queries, pool)
+	movementsService := movements.NewService(queries, pool, cfg.DefaultWarehouseId)
 	movementsHandler := movements.NewHandler(movementsService)
 	movementsRouter := movements.Router(movementsHandler, middleware)
 
-	warehouseService := warehouse.NewService(queries)
+	locationsService := locations.NewService(queries)
+	locationsHandler := locations.NewHandler(locationsService)
+	locationsRouter := locations.Router(locationsHandler, middleware)
+
+	stocktakesService := stocktakes.NewService(queries, pool, movementsService)
+	stocktakesHandler := stocktakes.NewHandler(stocktakesService)
+	stocktakesRouter := stocktakes.Router(stocktakesHandler, middleware)
+
+	documentsService := documents.NewService(queries, pool, movementsService)
+	documentsHandler := documents.NewHandler(documentsService)
+	documentsRouter := documents.Router(documentsHandler, middleware)
+
+	suppliersService := suppliers.NewService(queries)
+	suppliersHandler := suppliers.NewHandler(suppliersService)
+	suppliersRouter := suppliers.Router(suppliersHandler, middleware)
+
+	purchaseOrdersService := purchaseorders.NewService(queries, pool, movementsService, documentsService)
+	purchaseOrdersHandler := purchaseorders.NewHandler(purchaseOrdersService)
+	purchaseOrdersRouter := purchaseorders.Router(purchaseOrdersHandler, middleware)
+
+	replenishmentService := replenishment.NewService(queries, pool, purchaseOrdersService, cfg.ReplenishmentWindowDays, cfg.ReplenishmentCoverageDays)
+	replenishmentHandler := replenishment.NewHandler(replenishmentService)
+	replenishmentRouter := replenishment.Router(replenishmentHandler, middleware)
+
+	warehouseService := warehouse.NewService(queries, cfg.ExpiryWarningDays, cfg.DefaultWarehouseId)
 	warehouseHandler := warehouse.NewHandler(warehouseService)
-	warehouseRouter := warehouse.Router(warehouseHandler, middleware, movementsRouter)
+	warehouseRouter := warehouse.Router(warehouseHandler, middleware, movementsRouter, locationsRouter, stocktakesRouter, documentsRouter, purchaseOrdersRouter, replenishmentRouter)
+
+	companySettingsService := companysettings.NewService(queries, pool)
+	companySettingsHandler := companysettings.NewHandler(companySettingsService)
+	companySettingsRouter := companysettings.Router(companySettingsHandler, middleware)
+
+	paymentsService := payments.NewService(queries, pool)
+	paymentsHandler := payments.NewHandler(paymentsService)
+
+	statementsService := statements.NewService(queries, pool, paymentsService)
+	statementsHandler := statements.NewHandler(statementsService)
+	statementsRouter := statements.Router(statementsHandler, middleware)
 
-	invoicesService := invoices.NewService(queries, pool)
+	paymentsRouter := payments.Router(paymentsHandler, middleware, statementsRouter)
+
+	invoicesService := invoices.NewService(queries, pool, companySettingsService, paymentsService, invoices.DunningPolicy{
+		ReminderDays: cfg.DunningDays,
+	})
 	invoicesHandler := invoices.NewHandler(invoicesService)
 	invoicesRouter := invoices.Router(invoicesHandler, middleware)
 
-	ordersService := orders.NewService(queries, pool)
+	priceListsService := pricelists.NewService(queries, pool)
+	priceListsHandler := pricelists.NewHandler(priceListsService)
+	priceListsRouter := pricelists.Router(priceListsHandler, middleware)
+
+	customerGroupsService := customergroups.NewService(queries, pool)
+	customerGroupsHandler := customergroups.NewHandler(customerGroupsService)
+	customerGroupsRouter := customergroups.Router(customerGroupsHandler, middleware)
+
+	discountsService := discounts.NewService(queries, pool)
+	discountsHandler := discounts.NewHandler(discountsService)
+	discountsRouter := discounts.Router(discountsHandler, middleware)
+
+	ordersService := orders.NewService(queries, pool, movementsService, documentsService, priceListsService, discountsService)
 	ordersHandler := orders.NewHandler(ordersService)
-	ordersRouter := orders.Router(ordersHandler, invoicesHandler, middleware)
+
+	proformasService := proformas.NewService(queries, pool, ordersService, invoicesService, paymentsService, companySettingsService)
+	proformasHandler := proformas.NewHandler(proformasService)
+	proformasRouter := proformas.Router(proformasHandler, middleware)
+
+	ordersRouter := orders.Router(ordersHandler, invoicesHandler, middleware, proformasRouter)
+
+	standingOrdersService := standingorders.NewService(queries, pool, ordersService, cfg.StandingOrderLeadDays)
+	standingOrdersHandler := standingorders.NewHandler(standingOrdersService)
+	standingOrdersRouter := standingorders.Router(standingOrdersHandler, middleware)
+
+	companiesRouter := companies.Router(companiesHandler, middleware, addressesRouter, statementRouter, agingRouter, standingOrdersRouter)
 
 	employeesService := employees.NewService(queries)
 	employeesHandler := employees.NewHandler(employeesService)
@@ -104,7 +189,14 @@ func main() {
 		logrus.WithError(err).Fatal("failed to seed company")
 	}
 
-	r := app.Router(authRouter, meRouter, usersRouter, companiesRouter, productsRouter, warehouseRouter, ordersRouter, invoicesRouter, employeesRouter)
+	jobScheduler := scheduler.New()
+	jobScheduler.Every("expired-lots-write-off", 24*time.Hour, movementsService.WriteOffExpiredLots)
+	jobScheduler.Every("invoice-dunning", 24*time.Hour, invoicesService.RunDunning)
+	jobScheduler.Every("company-risk-scoring", 24*time.Hour, companiesService.ScoreRisk)
+	jobScheduler.Every("standing-orders", 24*time.Hour, standingOrdersService.Materialize)
+	jobScheduler.Start(ctx)
+
+	r := app.Router(authRouter, meRouter, usersRouter, companiesRouter, productsRouter, warehouseRouter, ordersRouter, invoicesRouter, employeesRouter, suppliersRouter, priceListsRouter, customerGroupsRouter, discountsRouter, companySettingsRouter, paymentsRouter)
 	log.Fatal(http.ListenAndServe(":8080", r))
 
 }